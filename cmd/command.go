@@ -0,0 +1,282 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	app "github.com/abhilashdk2016/my-grpc-go-server/internal/application"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+const commandDateLayout = "2006-01-02"
+
+func runCommand(bs *app.BankService, name string, args []string) error {
+	switch name {
+	case "statement":
+		return runStatementCommand(bs, args)
+	case "audit":
+		return runAuditCommand(bs, args)
+	case "batch":
+		return runBatchCommand(bs, args)
+	case "interest":
+		return runInterestCommand(bs, args)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+func runStatementCommand(bs *app.BankService, args []string) error {
+	fs := flag.NewFlagSet("statement", flag.ExitOnError)
+	acct := fs.String("account", "", "account number")
+	fromStr := fs.String("from", "", "first day of the statement period (YYYY-MM-DD)")
+	toStr := fs.String("to", "", "last day of the statement period (YYYY-MM-DD)")
+	format := fs.String("format", "text", "output format : csv, json or text")
+	out := fs.String("out", "", "output file, defaults to stdout")
+	fs.Parse(args)
+
+	if *acct == "" || *fromStr == "" || *toStr == "" {
+		fs.Usage()
+		return fmt.Errorf("account, from and to are required")
+	}
+
+	from, err := time.Parse(commandDateLayout, *fromStr)
+	if err != nil {
+		return fmt.Errorf("invalid from date %v : %v", *fromStr, err)
+	}
+
+	to, err := time.Parse(commandDateLayout, *toStr)
+	if err != nil {
+		return fmt.Errorf("invalid to date %v : %v", *toStr, err)
+	}
+
+	st, err := bs.GenerateStatement(*acct, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("can't generate statement : %v", err)
+	}
+
+	content, err := bs.ExportStatement(st, strings.ToUpper(*format))
+	if err != nil {
+		return fmt.Errorf("can't export statement : %v", err)
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+
+	return os.WriteFile(*out, content, 0o644)
+}
+
+func runAuditCommand(bs *app.BankService, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("audit requires a subcommand : list or verify")
+	}
+
+	switch args[0] {
+	case "list":
+		return runAuditListCommand(bs, args[1:])
+	case "verify":
+		return runAuditVerifyCommand(bs)
+	default:
+		return fmt.Errorf("unknown audit subcommand %q", args[0])
+	}
+}
+
+func runAuditListCommand(bs *app.BankService, args []string) error {
+	fs := flag.NewFlagSet("audit list", flag.ExitOnError)
+	acct := fs.String("account", "", "account number")
+	actor := fs.String("actor", "", "actor who initiated the action")
+	action := fs.String("action", "", "audited action, e.g. TRANSFER")
+	fromStr := fs.String("from", "", "first day (YYYY-MM-DD)")
+	toStr := fs.String("to", "", "last day (YYYY-MM-DD)")
+	after := fs.Int64("after", 0, "only events after this sequence number")
+	limit := fs.Int("limit", 100, "maximum number of events")
+	fs.Parse(args)
+
+	f := bank.AuditEventFilter{
+		Actor:         *actor,
+		Action:        strings.ToUpper(*action),
+		AccountNumber: *acct,
+		AfterSequence: *after,
+		Limit:         *limit,
+	}
+
+	if *fromStr != "" {
+		from, err := time.Parse(commandDateLayout, *fromStr)
+		if err != nil {
+			return fmt.Errorf("invalid from date %v : %v", *fromStr, err)
+		}
+		f.From = from
+	}
+
+	if *toStr != "" {
+		to, err := time.Parse(commandDateLayout, *toStr)
+		if err != nil {
+			return fmt.Errorf("invalid to date %v : %v", *toStr, err)
+		}
+		f.To = to.AddDate(0, 0, 1)
+	}
+
+	events, err := bs.ListAuditEvents(f)
+	if err != nil {
+		return fmt.Errorf("can't list audit events : %v", err)
+	}
+
+	for _, e := range events {
+		fmt.Printf("%8d %v %-20s %-22s %-10s %12.3f %-4s %-8s %v %v %v\n",
+			e.Sequence, e.Timestamp.UTC().Format(time.RFC3339), e.Actor, e.Action, e.AccountNumber,
+			e.Amount, e.Currency, e.Outcome, e.RequestId, e.ClientAddress, e.Details)
+	}
+
+	return nil
+}
+
+func runAuditVerifyCommand(bs *app.BankService) error {
+	v, err := bs.VerifyAuditChain()
+	if err != nil {
+		return fmt.Errorf("can't verify audit chain : %v", err)
+	}
+
+	if !v.Verified {
+		return fmt.Errorf("audit chain broken at sequence %v after %v events : %v", v.BrokenSequence, v.EventsChecked, v.Reason)
+	}
+
+	fmt.Printf("Audit chain verified, %v events checked\n", v.EventsChecked)
+
+	return nil
+}
+
+func runBatchCommand(bs *app.BankService, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("batch requires a subcommand : submit or report")
+	}
+
+	switch args[0] {
+	case "submit":
+		return runBatchSubmitCommand(bs, args[1:])
+	case "report":
+		return runBatchReportCommand(bs, args[1:])
+	default:
+		return fmt.Errorf("unknown batch subcommand %q", args[0])
+	}
+}
+
+func writeCommandOutput(out string, content []byte) error {
+	if out == "" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+
+	return os.WriteFile(out, content, 0o644)
+}
+
+func runBatchSubmitCommand(bs *app.BankService, args []string) error {
+	fs := flag.NewFlagSet("batch submit", flag.ExitOnError)
+	file := fs.String("file", "", "batch file of transfers")
+	format := fs.String("format", "", "batch file format : csv or json, defaults to the file extension")
+	mode := fs.String("mode", "all-or-nothing", "all-or-nothing or best-effort")
+	reportFormat := fs.String("report-format", "csv", "report format : csv or json")
+	out := fs.String("out", "", "report file, defaults to stdout")
+	fs.Parse(args)
+
+	if *file == "" {
+		fs.Usage()
+		return fmt.Errorf("file is required")
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*file), ".")
+	}
+
+	content, err := os.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("can't read batch file : %v", err)
+	}
+
+	lines, err := bs.ParseTransferBatch(strings.ToUpper(*format), content)
+	if err != nil {
+		return fmt.Errorf("can't read batch file : %v", err)
+	}
+
+	batch, err := bs.SubmitTransferBatch(bank.TransferBatch{
+		FileName: filepath.Base(*file),
+		Format:   strings.ToUpper(*format),
+		Mode:     strings.ToUpper(strings.ReplaceAll(*mode, "-", "_")),
+		Lines:    lines,
+		Origin:   bank.Origin{Actor: bank.SystemActor},
+	})
+	if err != nil && batch.BatchUuid == uuid.Nil {
+		return fmt.Errorf("can't submit batch : %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Batch %v %v, %v of %v transfers succeeded\n",
+		batch.BatchUuid, batch.Status, batch.SucceededCount, len(batch.Lines))
+
+	report, err := bs.ExportTransferBatchReport(batch, strings.ToUpper(*reportFormat))
+	if err != nil {
+		return fmt.Errorf("can't export batch report : %v", err)
+	}
+
+	return writeCommandOutput(*out, report)
+}
+
+func runBatchReportCommand(bs *app.BankService, args []string) error {
+	fs := flag.NewFlagSet("batch report", flag.ExitOnError)
+	batchStr := fs.String("batch", "", "batch UUID")
+	format := fs.String("format", "csv", "report format : csv or json")
+	out := fs.String("out", "", "report file, defaults to stdout")
+	fs.Parse(args)
+
+	batchUuid, err := uuid.Parse(*batchStr)
+	if err != nil {
+		fs.Usage()
+		return fmt.Errorf("invalid batch UUID %q", *batchStr)
+	}
+
+	batch, err := bs.GetTransferBatch(batchUuid)
+	if err != nil {
+		return fmt.Errorf("can't get batch : %v", err)
+	}
+
+	report, err := bs.ExportTransferBatchReport(batch, strings.ToUpper(*format))
+	if err != nil {
+		return fmt.Errorf("can't export batch report : %v", err)
+	}
+
+	return writeCommandOutput(*out, report)
+}
+
+func runInterestCommand(bs *app.BankService, args []string) error {
+	fs := flag.NewFlagSet("interest", flag.ExitOnError)
+	throughStr := fs.String("through", "", "last day to accrue (YYYY-MM-DD), defaults to yesterday")
+	fs.Parse(args)
+
+	through := time.Now().UTC().AddDate(0, 0, -1)
+
+	if *throughStr != "" {
+		t, err := time.Parse(commandDateLayout, *throughStr)
+		if err != nil {
+			return fmt.Errorf("invalid through date %v : %v", *throughStr, err)
+		}
+		through = t
+	}
+
+	accrued, err := bs.AccrueInterest(through)
+	if err != nil {
+		return fmt.Errorf("can't accrue interest : %v", err)
+	}
+
+	posted, err := bs.CapitalizeInterest(through.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("can't capitalize interest : %v", err)
+	}
+
+	fmt.Printf("Accrued %v days of interest, capitalized %v periods\n", accrued, posted)
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	mygrpc "github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/grpc"
+)
+
+func getEnv(key string, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return v
+	}
+
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+
+	return fallback
+}
+
+// parseRateLimit parses a "rate:burst" limit, e.g. "10:20" for 10 calls per
+// second with bursts of 20. The burst defaults to the rounded up rate.
+func parseRateLimit(v string) (mygrpc.RateLimit, bool) {
+	rateStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(v), ":")
+
+	r, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || r < 0 {
+		return mygrpc.RateLimit{}, false
+	}
+
+	burst := int(r)
+	if float64(burst) < r {
+		burst++
+	}
+
+	if hasBurst {
+		if burst, err = strconv.Atoi(burstStr); err != nil || burst < 1 {
+			return mygrpc.RateLimit{}, false
+		}
+	}
+
+	return mygrpc.RateLimit{Rate: r, Burst: burst}, true
+}
+
+func getEnvRateLimit(key string) mygrpc.RateLimit {
+	v := os.Getenv(key)
+	if v == "" {
+		return mygrpc.RateLimit{}
+	}
+
+	l, ok := parseRateLimit(v)
+	if !ok {
+		log.Fatalf("Invalid rate limit %v=%q, expected rate:burst\n", key, v)
+	}
+
+	return l
+}
+
+// getEnvMethodRateLimits parses "Method=rate:burst,..." where Method is either
+// a BankService method name or a full gRPC method name
+func getEnvMethodRateLimits(key string) map[string]mygrpc.RateLimit {
+	limits := map[string]mygrpc.RateLimit{}
+
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		method, v, _ := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+
+		l, ok := parseRateLimit(v)
+		if !ok || method == "" {
+			log.Fatalf("Invalid rate limit %q in %v, expected Method=rate:burst\n", entry, key)
+		}
+
+		if !strings.HasPrefix(method, "/") {
+			method = "/bank.BankService/" + method
+		}
+
+		limits[method] = l
+	}
+
+	return limits
+}
+
+// grpcAdapterOptions builds the gRPC server options from the environment :
+//
+//	GRPC_TLS_CERT_FILE, GRPC_TLS_KEY_FILE  server certificate, enables TLS
+//	GRPC_TLS_CLIENT_CA_FILE                CA bundle verifying client certificates (mTLS), enables authentication by certificate
+//	GRPC_TLS_REQUIRE_CLIENT_CERT           reject clients without a certificate
+//	GRPC_TLS_RELOAD_INTERVAL               how often certificate files are checked for changes
+//	GRPC_AUTH_JWKS_FILE                    JWKS file verifying HS256/RS256 bearer tokens, enables authentication
+//	GRPC_AUTH_API_KEYS_FILE                JSON file of static API keys, enables authentication
+//	GRPC_AUTH_JWT_ISSUER, GRPC_AUTH_JWT_AUDIENCE  expected iss and aud claims
+//	GRPC_AUTH_DISABLED                     accept every caller when no auth file nor client CA is set, development only
+//	GRPC_RATE_LIMIT                        "rate:burst" calls per method per principal and per peer IP
+//	GRPC_RATE_LIMIT_METHODS                per method overrides, e.g. "TransferMultiple=1:5,GenerateStatement=0.5:2"
+//	GRPC_RATE_LIMIT_ACCOUNT                "rate:burst" calls and stream messages per account number
+//	GRPC_RATE_LIMIT_STREAM_MESSAGES        "rate:burst" messages received within one stream
+//	GRPC_TRANSFER_CONCURRENCY              transfers of one TransferMultiple stream run at the same time, 8 by default
+func grpcAdapterOptions() []mygrpc.GrpcAdapterOption {
+	var opts []mygrpc.GrpcAdapterOption
+
+	if certFile := getEnv("GRPC_TLS_CERT_FILE", ""); certFile != "" {
+		opts = append(opts, mygrpc.WithTls(mygrpc.TlsConfig{
+			CertFile:          certFile,
+			KeyFile:           getEnv("GRPC_TLS_KEY_FILE", ""),
+			ClientCAFile:      getEnv("GRPC_TLS_CLIENT_CA_FILE", ""),
+			RequireClientCert: getEnvBool("GRPC_TLS_REQUIRE_CLIENT_CERT", false),
+			ReloadInterval:    getEnvDuration("GRPC_TLS_RELOAD_INTERVAL", 30*time.Second),
+		}))
+	}
+
+	jwksFile := getEnv("GRPC_AUTH_JWKS_FILE", "")
+	apiKeysFile := getEnv("GRPC_AUTH_API_KEYS_FILE", "")
+
+	if jwksFile != "" || apiKeysFile != "" {
+		opts = append(opts, mygrpc.WithAuth(mygrpc.AuthConfig{
+			JwksFile:    jwksFile,
+			ApiKeysFile: apiKeysFile,
+			Issuer:      getEnv("GRPC_AUTH_JWT_ISSUER", ""),
+			Audience:    getEnv("GRPC_AUTH_JWT_AUDIENCE", ""),
+		}))
+	} else if getEnvBool("GRPC_AUTH_DISABLED", false) {
+		opts = append(opts, mygrpc.WithoutAuth())
+	}
+
+	rateLimit := mygrpc.RateLimitConfig{
+		Default:        getEnvRateLimit("GRPC_RATE_LIMIT"),
+		Methods:        getEnvMethodRateLimits("GRPC_RATE_LIMIT_METHODS"),
+		Account:        getEnvRateLimit("GRPC_RATE_LIMIT_ACCOUNT"),
+		StreamMessages: getEnvRateLimit("GRPC_RATE_LIMIT_STREAM_MESSAGES"),
+	}
+
+	if rateLimit.Default.Rate > 0 || len(rateLimit.Methods) > 0 || rateLimit.Account.Rate > 0 || rateLimit.StreamMessages.Rate > 0 {
+		opts = append(opts, mygrpc.WithRateLimit(rateLimit))
+	}
+
+	if n := getEnvInt("GRPC_TRANSFER_CONCURRENCY", 0); n > 0 {
+		opts = append(opts, mygrpc.WithTransferConcurrency(n))
+	}
+
+	return opts
+}
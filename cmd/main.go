@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"log"
 	"math/rand"
+	"os"
 	"time"
 
 	"github.com/abhilashdk2016/my-grpc-go-server/db"
@@ -23,7 +24,6 @@ func main() {
 	if err != nil {
 		log.Fatal("Unable to connect to database : ", err)
 	}
-	db.Migrate(sqlDB)
 	databaseAdapter, err := database.NewDatabaseAdapter(sqlDB)
 	if err != nil {
 		log.Fatal("Unable to create database adapter : ", err)
@@ -33,8 +33,24 @@ func main() {
 
 	bs := app.NewBankService(databaseAdapter)
 
+	// bring the schema up to date without dropping the data of the tables
+	// created by earlier migrations, e.g. audit events and scheduled transfers
+	if err := db.MigrateUp(sqlDB); err != nil {
+		log.Fatal("Database migration failed : ", err)
+	}
+
+	if len(os.Args) > 1 {
+		if err := runCommand(bs, os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	go generateExcahngeRates(bs, "USD", "INR", time.Second*5)
-	grpcAdapter := mygrpc.NewGrpcAdapter(bs, 8080)
+	go expireHolds(bs, time.Minute)
+	go runScheduledTransfers(bs, 30*time.Second)
+	go runInterestAccrual(bs, time.Hour)
+	grpcAdapter := mygrpc.NewGrpcAdapter(bs, 8080, grpcAdapterOptions()...)
 	grpcAdapter.Run()
 }
 
@@ -68,6 +84,54 @@ func generateExcahngeRates(bs *app.BankService, fromCurrency, toCurrency string,
 			Rate:               2000 + float64(rand.Intn(300)),
 		}
 
-		bs.CreateExchangeRate(dummyRate)
+		bs.GenerateExchangeRate(dummyRate)
+	}
+}
+
+func expireHolds(bs *app.BankService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	for range ticker.C {
+		expired, err := bs.ExpireHolds(time.Now())
+
+		if err != nil {
+			log.Println("Can't expire holds :", err)
+		} else if expired > 0 {
+			log.Printf("Expired %v holds\n", expired)
+		}
+	}
+}
+
+// runScheduledTransfers is the in-process scheduler of standing orders
+func runScheduledTransfers(bs *app.BankService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	for range ticker.C {
+		if _, err := bs.RunDueScheduledTransfers(time.Now()); err != nil {
+			log.Println("Can't run scheduled transfers :", err)
+		}
+	}
+}
+
+// runInterestAccrual accrues interest for the days that ended and
+// capitalizes the months that ended. Both are idempotent, so checking every
+// interval rather than once at midnight is harmless.
+func runInterestAccrual(bs *app.BankService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	for range ticker.C {
+		now := time.Now()
+
+		if accrued, err := bs.AccrueInterest(now.UTC().AddDate(0, 0, -1)); err != nil {
+			log.Println("Can't accrue interest :", err)
+		} else if accrued > 0 {
+			log.Printf("Accrued %v days of interest\n", accrued)
+		}
+
+		if posted, err := bs.CapitalizeInterest(now); err != nil {
+			log.Println("Can't capitalize interest :", err)
+		} else if posted > 0 {
+			log.Printf("Capitalized interest of %v accounts\n", posted)
+		}
 	}
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"log"
 
 	migrate "github.com/golang-migrate/migrate/v4"
@@ -26,3 +27,23 @@ func Migrate(conn *sql.DB) {
 	}
 	log.Println("Database migration completed")
 }
+
+// MigrateUp applies the migrations the database is missing and keeps its
+// data, unlike Migrate which recreates the schema
+func MigrateUp(conn *sql.DB) error {
+	driver, err := postgres.WithInstance(conn, &postgres.Config{})
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithDatabaseInstance("file://db/migrations", "postgres", driver)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bank_transaction_summary_reports;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS bank_transaction_summary_reports(
+  account_uuid              UUID            NOT NULL REFERENCES bank_accounts,
+  period                    VARCHAR(10)     NOT NULL,
+  period_start              TIMESTAMPTZ     NOT NULL,
+  period_end                TIMESTAMPTZ     NOT NULL,
+  sum_in                    NUMERIC(18,3)   NOT NULL,
+  sum_out                   NUMERIC(18,3)   NOT NULL,
+  count                     INTEGER         NOT NULL,
+  generated_at              TIMESTAMPTZ,
+  PRIMARY KEY (account_uuid, period, period_start),
+  CONSTRAINT bank_transaction_summary_reports_period_check
+    CHECK (period IN ('day', 'week', 'month'))
+);
\ No newline at end of file
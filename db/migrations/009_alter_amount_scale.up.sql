@@ -0,0 +1,5 @@
+ALTER TABLE bank_accounts ALTER COLUMN current_balance TYPE NUMERIC(18,3);
+
+ALTER TABLE bank_transactions ALTER COLUMN amount TYPE NUMERIC(18,3);
+
+ALTER TABLE bank_transfers ALTER COLUMN amount TYPE NUMERIC(18,3);
\ No newline at end of file
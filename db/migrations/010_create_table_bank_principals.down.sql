@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bank_account_access CASCADE;
+
+DROP TABLE IF EXISTS bank_principals CASCADE;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS bank_principals(
+  subject                   VARCHAR(100)    PRIMARY KEY,
+  role                      VARCHAR(20)     NOT NULL,
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS bank_account_access(
+  account_uuid              UUID            NOT NULL REFERENCES bank_accounts,
+  subject                   VARCHAR(100)    NOT NULL,
+  access_type               VARCHAR(20)     NOT NULL,
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ,
+  PRIMARY KEY (account_uuid, subject)
+);
@@ -0,0 +1,3 @@
+DELETE FROM bank_account_access;
+
+DELETE FROM bank_principals;
\ No newline at end of file
@@ -0,0 +1,164 @@
+INSERT
+	INTO
+	bank_principals (subject,
+	role,
+	created_at,
+	updated_at)
+VALUES('kate.bishop',
+'CUSTOMER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_principals (subject,
+	role,
+	created_at,
+	updated_at)
+VALUES('riri.williams',
+'CUSTOMER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_principals (subject,
+	role,
+	created_at,
+	updated_at)
+VALUES('cassie.lang',
+'CUSTOMER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_principals (subject,
+	role,
+	created_at,
+	updated_at)
+VALUES('shuri',
+'CUSTOMER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_principals (subject,
+	role,
+	created_at,
+	updated_at)
+VALUES('elijah.bradley',
+'CUSTOMER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_principals (subject,
+	role,
+	created_at,
+	updated_at)
+VALUES('bank.teller',
+'TELLER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_principals (subject,
+	role,
+	created_at,
+	updated_at)
+VALUES('bank.admin',
+'ADMIN',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_access (account_uuid,
+	subject,
+	access_type,
+	created_at,
+	updated_at)
+VALUES('3781b5e8-3eca-4e5a-afa2-2ca93b632e12',
+'kate.bishop',
+'OWNER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_access (account_uuid,
+	subject,
+	access_type,
+	created_at,
+	updated_at)
+VALUES('3962555b-79f0-40c4-88c0-20306257b7ac',
+'riri.williams',
+'OWNER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_access (account_uuid,
+	subject,
+	access_type,
+	created_at,
+	updated_at)
+VALUES('1e9230bd-4264-4526-a9cd-2a86d3ca9594',
+'cassie.lang',
+'OWNER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_access (account_uuid,
+	subject,
+	access_type,
+	created_at,
+	updated_at)
+VALUES('2a7d5f68-baa1-4264-bf41-facba0414c59',
+'shuri',
+'OWNER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_access (account_uuid,
+	subject,
+	access_type,
+	created_at,
+	updated_at)
+VALUES('66f93615-7c97-4395-8a26-a0e8ced7bb97',
+'elijah.bradley',
+'OWNER',
+now(),
+now())
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS audit_events(
+  sequence                  BIGINT          PRIMARY KEY,
+  event_uuid                UUID            UNIQUE NOT NULL,
+  event_timestamp           TIMESTAMPTZ     NOT NULL,
+  actor                     VARCHAR(100)    NOT NULL,
+  action                    VARCHAR(50)     NOT NULL,
+  account_number            VARCHAR(20),
+  amount                    NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  currency                  VARCHAR(5),
+  request_id                VARCHAR(100),
+  client_address            VARCHAR(100),
+  outcome                   VARCHAR(20)     NOT NULL,
+  details                   TEXT,
+  prev_hash                 VARCHAR(64)     NOT NULL,
+  hash                      VARCHAR(64)     NOT NULL,
+  created_at                TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_account_number_idx ON audit_events (account_number);
+
+CREATE INDEX IF NOT EXISTS audit_events_actor_idx ON audit_events (actor);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update_delete
+  BEFORE UPDATE OR DELETE ON audit_events
+  FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+  BEFORE TRUNCATE ON audit_events
+  FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
\ No newline at end of file
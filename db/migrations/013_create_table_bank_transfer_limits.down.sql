@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bank_transfers_from_account_timestamp;
+
+DROP TABLE IF EXISTS bank_transfer_limits CASCADE;
\ No newline at end of file
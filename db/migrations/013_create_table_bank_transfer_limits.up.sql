@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS bank_transfer_limits(
+  limit_uuid                UUID            PRIMARY KEY,
+  limit_name                VARCHAR(100)    NOT NULL,
+  account_uuid              UUID            REFERENCES bank_accounts,
+  currency                  VARCHAR(5),
+  max_amount_per_transfer   NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  max_daily_amount          NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  max_monthly_amount        NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  max_transfers_per_hour    INTEGER         NOT NULL DEFAULT 0,
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_transfers_from_account_timestamp
+  ON bank_transfers (from_account_uuid, transfer_timestamp);
\ No newline at end of file
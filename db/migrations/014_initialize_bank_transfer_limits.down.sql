@@ -0,0 +1 @@
+DELETE FROM bank_transfer_limits;
\ No newline at end of file
@@ -0,0 +1,55 @@
+INSERT
+	INTO
+	bank_transfer_limits (limit_uuid,
+	limit_name,
+	max_transfers_per_hour,
+	created_at,
+	updated_at)
+VALUES('6c0b5a52-3f0e-4b52-9a51-0c8f2f6f1d01',
+'default velocity',
+60,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_transfer_limits (limit_uuid,
+	limit_name,
+	currency,
+	max_amount_per_transfer,
+	max_daily_amount,
+	max_monthly_amount,
+	created_at,
+	updated_at)
+VALUES('6c0b5a52-3f0e-4b52-9a51-0c8f2f6f1d02',
+'default USD',
+'USD',
+10000,
+25000,
+100000,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_transfer_limits (limit_uuid,
+	limit_name,
+	currency,
+	max_amount_per_transfer,
+	max_daily_amount,
+	max_monthly_amount,
+	created_at,
+	updated_at)
+VALUES('6c0b5a52-3f0e-4b52-9a51-0c8f2f6f1d03',
+'default EUR',
+'EUR',
+10000,
+25000,
+100000,
+now(),
+now())
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE bank_accounts DROP CONSTRAINT IF EXISTS bank_accounts_overdraft_limit_check;
+
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS overdraft_fee;
+
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS overdraft_interest_rate;
+
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS overdraft_limit;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS overdraft_limit NUMERIC(18,3) NOT NULL DEFAULT 0;
+
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS overdraft_interest_rate NUMERIC(9,6) NOT NULL DEFAULT 0;
+
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS overdraft_fee NUMERIC(18,3) NOT NULL DEFAULT 0;
+
+ALTER TABLE bank_accounts ADD CONSTRAINT bank_accounts_overdraft_limit_check
+  CHECK (overdraft_limit >= 0);
\ No newline at end of file
@@ -0,0 +1,6 @@
+UPDATE bank_accounts
+SET overdraft_limit = 0,
+    overdraft_interest_rate = 0,
+    overdraft_fee = 0,
+    updated_at = now()
+WHERE account_number = '7835697001';
\ No newline at end of file
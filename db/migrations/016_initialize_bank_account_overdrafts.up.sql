@@ -0,0 +1,6 @@
+UPDATE bank_accounts
+SET overdraft_limit = 100,
+    overdraft_interest_rate = 0.18,
+    overdraft_fee = 5,
+    updated_at = now()
+WHERE account_number = '7835697001';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bank_holds CASCADE;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS bank_holds(
+  hold_uuid                 UUID            PRIMARY KEY,
+  account_uuid              UUID            NOT NULL REFERENCES bank_accounts,
+  amount                    NUMERIC(18,3)   NOT NULL,
+  currency                  VARCHAR(5)      NOT NULL,
+  status                    VARCHAR(20)     NOT NULL,
+  reference                 VARCHAR(100),
+  expires_at                TIMESTAMPTZ     NOT NULL,
+  captured_amount           NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  transaction_uuid          UUID            REFERENCES bank_transactions,
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS bank_holds_account_status_idx ON bank_holds (account_uuid, status);
\ No newline at end of file
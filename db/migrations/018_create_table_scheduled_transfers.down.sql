@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS scheduled_transfer_executions CASCADE;
+
+DROP TABLE IF EXISTS scheduled_transfers CASCADE;
\ No newline at end of file
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS scheduled_transfers(
+  schedule_uuid             UUID            PRIMARY KEY,
+  from_account_uuid         UUID            NOT NULL REFERENCES bank_accounts,
+  to_account_uuid           UUID            NOT NULL REFERENCES bank_accounts,
+  currency                  VARCHAR(5)      NOT NULL,
+  amount                    NUMERIC(18,3)   NOT NULL,
+  frequency                 VARCHAR(10)     NOT NULL,
+  interval                  INTEGER         NOT NULL DEFAULT 1,
+  start_at                  TIMESTAMPTZ     NOT NULL,
+  end_at                    TIMESTAMPTZ,
+  occurrence                INTEGER         NOT NULL DEFAULT 0,
+  next_run_at               TIMESTAMPTZ     NOT NULL,
+  next_attempt_at           TIMESTAMPTZ     NOT NULL,
+  attempts                  INTEGER         NOT NULL DEFAULT 0,
+  status                    VARCHAR(20)     NOT NULL,
+  created_by                VARCHAR(100)    NOT NULL,
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_transfers_due_idx ON scheduled_transfers (status, next_attempt_at);
+
+CREATE INDEX IF NOT EXISTS scheduled_transfers_from_account_idx ON scheduled_transfers (from_account_uuid);
+
+CREATE TABLE IF NOT EXISTS scheduled_transfer_executions(
+  execution_uuid            UUID            PRIMARY KEY,
+  schedule_uuid             UUID            NOT NULL REFERENCES scheduled_transfers,
+  scheduled_for             TIMESTAMPTZ     NOT NULL,
+  attempt                   INTEGER         NOT NULL,
+  status                    VARCHAR(20)     NOT NULL,
+  transfer_uuid             UUID            REFERENCES bank_transfers,
+  error                     TEXT,
+  started_at                TIMESTAMPTZ     NOT NULL,
+  finished_at               TIMESTAMPTZ,
+  UNIQUE (schedule_uuid, scheduled_for, attempt)
+);
\ No newline at end of file
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS bank_transfer_reversals CASCADE;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS reversal_status;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS reversed_amount;
+
+DROP INDEX IF EXISTS bank_transactions_transfer_uuid_idx;
+
+ALTER TABLE bank_transactions DROP COLUMN IF EXISTS transfer_uuid;
\ No newline at end of file
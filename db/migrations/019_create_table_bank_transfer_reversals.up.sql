@@ -0,0 +1,20 @@
+ALTER TABLE bank_transactions ADD COLUMN IF NOT EXISTS transfer_uuid UUID REFERENCES bank_transfers;
+
+CREATE INDEX IF NOT EXISTS bank_transactions_transfer_uuid_idx ON bank_transactions (transfer_uuid);
+
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS reversed_amount NUMERIC(18,3) NOT NULL DEFAULT 0;
+
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS reversal_status VARCHAR(20) NOT NULL DEFAULT 'NONE';
+
+CREATE TABLE IF NOT EXISTS bank_transfer_reversals(
+  reversal_uuid             UUID            PRIMARY KEY,
+  transfer_uuid             UUID            NOT NULL REFERENCES bank_transfers,
+  amount                    NUMERIC(18,3)   NOT NULL,
+  reason                    TEXT,
+  debit_transaction_uuid    UUID            NOT NULL REFERENCES bank_transactions,
+  credit_transaction_uuid   UUID            NOT NULL REFERENCES bank_transactions,
+  created_by                VARCHAR(100)    NOT NULL,
+  created_at                TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS bank_transfer_reversals_transfer_uuid_idx ON bank_transfer_reversals (transfer_uuid);
\ No newline at end of file
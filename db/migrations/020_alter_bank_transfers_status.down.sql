@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS bank_transfers_status_idx;
+
+ALTER TABLE bank_transfers DROP CONSTRAINT IF EXISTS bank_transfers_status_check;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS reversed_at;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS failed_at;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS completed_at;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS processing_at;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS failure_reason;
+
+ALTER TABLE bank_transfers DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,21 @@
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'PENDING';
+
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS failure_reason TEXT;
+
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS processing_at TIMESTAMPTZ;
+
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ;
+
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
+
+ALTER TABLE bank_transfers ADD COLUMN IF NOT EXISTS reversed_at TIMESTAMPTZ;
+
+UPDATE bank_transfers
+SET status = CASE WHEN transfer_success THEN 'COMPLETED' ELSE 'FAILED' END,
+  completed_at = CASE WHEN transfer_success THEN updated_at END,
+  failed_at = CASE WHEN transfer_success THEN NULL ELSE updated_at END;
+
+ALTER TABLE bank_transfers ADD CONSTRAINT bank_transfers_status_check
+  CHECK (status IN ('PENDING', 'PROCESSING', 'COMPLETED', 'FAILED', 'REVERSED'));
+
+CREATE INDEX IF NOT EXISTS bank_transfers_status_idx ON bank_transfers (status);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS bank_transfers_transfer_timestamp_transfer_uuid_idx;
+
+DROP INDEX IF EXISTS bank_transfers_to_account_uuid_transfer_timestamp_idx;
\ No newline at end of file
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS bank_transfers_to_account_uuid_transfer_timestamp_idx ON bank_transfers (to_account_uuid, transfer_timestamp);
+
+CREATE INDEX IF NOT EXISTS bank_transfers_transfer_timestamp_transfer_uuid_idx ON bank_transfers (transfer_timestamp, transfer_uuid);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS transfer_batch_lines CASCADE;
+
+DROP TABLE IF EXISTS transfer_batches CASCADE;
\ No newline at end of file
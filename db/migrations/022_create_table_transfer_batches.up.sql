@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS transfer_batches(
+  batch_uuid                UUID            PRIMARY KEY,
+  file_name                 VARCHAR(255),
+  format                    VARCHAR(10)     NOT NULL,
+  mode                      VARCHAR(20)     NOT NULL,
+  status                    VARCHAR(20)     NOT NULL,
+  line_count                INTEGER         NOT NULL DEFAULT 0,
+  succeeded_count           INTEGER         NOT NULL DEFAULT 0,
+  failed_count              INTEGER         NOT NULL DEFAULT 0,
+  created_by                VARCHAR(100)    NOT NULL,
+  created_at                TIMESTAMPTZ,
+  completed_at              TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS transfer_batch_lines(
+  batch_uuid                UUID            NOT NULL REFERENCES transfer_batches,
+  line_number               INTEGER         NOT NULL,
+  from_account_number       TEXT,
+  to_account_number         TEXT,
+  currency                  TEXT,
+  amount                    NUMERIC(18,3),
+  reference                 TEXT,
+  status                    VARCHAR(20)     NOT NULL,
+  transfer_uuid             UUID            REFERENCES bank_transfers,
+  error                     TEXT,
+  updated_at                TIMESTAMPTZ,
+  PRIMARY KEY (batch_uuid, line_number)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS bank_transactions_account_uuid_transaction_timestamp_idx;
+
+DROP INDEX IF EXISTS bank_transactions_metadata_idx;
+
+ALTER TABLE bank_transactions DROP COLUMN IF EXISTS metadata;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE bank_transactions ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS bank_transactions_metadata_idx ON bank_transactions USING GIN (metadata jsonb_path_ops);
+
+CREATE INDEX IF NOT EXISTS bank_transactions_account_uuid_transaction_timestamp_idx ON bank_transactions (account_uuid, transaction_timestamp);
\ No newline at end of file
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS bank_interest_capitalizations CASCADE;
+
+DROP TABLE IF EXISTS bank_interest_accruals CASCADE;
+
+DROP INDEX IF EXISTS bank_accounts_product_uuid_idx;
+
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS product_uuid;
+
+DROP TABLE IF EXISTS bank_account_product_interest_tiers CASCADE;
+
+DROP TABLE IF EXISTS bank_account_products CASCADE;
\ No newline at end of file
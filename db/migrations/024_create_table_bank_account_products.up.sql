@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS bank_account_products(
+  product_uuid              UUID            PRIMARY KEY,
+  product_code              VARCHAR(30)     NOT NULL UNIQUE,
+  product_name              VARCHAR(100)    NOT NULL,
+  day_count_convention      VARCHAR(10)     NOT NULL DEFAULT 'ACT_365',
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ,
+  CONSTRAINT bank_account_products_day_count_convention_check
+    CHECK (day_count_convention IN ('ACT_365', 'ACT_360', 'ACT_ACT', '30_360'))
+);
+
+CREATE TABLE IF NOT EXISTS bank_account_product_interest_tiers(
+  product_uuid              UUID            NOT NULL REFERENCES bank_account_products,
+  min_balance               NUMERIC(18,3)   NOT NULL,
+  annual_rate               NUMERIC(9,6)    NOT NULL,
+  PRIMARY KEY (product_uuid, min_balance),
+  CONSTRAINT bank_account_product_interest_tiers_check
+    CHECK (min_balance >= 0 AND annual_rate >= 0)
+);
+
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS product_uuid UUID REFERENCES bank_account_products;
+
+CREATE INDEX IF NOT EXISTS bank_accounts_product_uuid_idx ON bank_accounts (product_uuid);
+
+CREATE TABLE IF NOT EXISTS bank_interest_accruals(
+  account_uuid              UUID            NOT NULL REFERENCES bank_accounts,
+  accrual_date              DATE            NOT NULL,
+  period_start              DATE            NOT NULL,
+  balance                   NUMERIC(18,3)   NOT NULL,
+  amount                    NUMERIC(18,9)   NOT NULL,
+  day_count_convention      VARCHAR(10)     NOT NULL,
+  created_at                TIMESTAMPTZ,
+  PRIMARY KEY (account_uuid, accrual_date)
+);
+
+CREATE INDEX IF NOT EXISTS bank_interest_accruals_period_start_idx ON bank_interest_accruals (period_start, account_uuid);
+
+CREATE TABLE IF NOT EXISTS bank_interest_capitalizations(
+  account_uuid              UUID            NOT NULL REFERENCES bank_accounts,
+  period_start              DATE            NOT NULL,
+  amount                    NUMERIC(18,3)   NOT NULL,
+  status                    VARCHAR(20)     NOT NULL,
+  transaction_uuid          UUID            REFERENCES bank_transactions,
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ,
+  PRIMARY KEY (account_uuid, period_start)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE bank_accounts SET product_uuid = NULL;
+
+DELETE FROM bank_account_product_interest_tiers;
+
+DELETE FROM bank_account_products;
\ No newline at end of file
@@ -0,0 +1,38 @@
+INSERT
+	INTO
+	bank_account_products (product_uuid,
+	product_code,
+	product_name,
+	day_count_convention,
+	created_at,
+	updated_at)
+VALUES('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a01',
+'SAVINGS_TIERED',
+'Tiered savings',
+'ACT_365',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_product_interest_tiers (product_uuid,
+	min_balance,
+	annual_rate)
+VALUES('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a01',
+0,
+0.01),
+('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a01',
+1000,
+0.02),
+('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a01',
+10000,
+0.03)
+ON CONFLICT DO NOTHING;
+
+
+UPDATE bank_accounts
+SET product_uuid = '5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a01',
+    updated_at = now()
+WHERE account_number = '7835697003';
\ No newline at end of file
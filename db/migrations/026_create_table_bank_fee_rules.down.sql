@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS bank_fee_charges CASCADE;
+
+DROP TABLE IF EXISTS bank_fee_rule_tiers CASCADE;
+
+DROP TABLE IF EXISTS bank_fee_rules CASCADE;
+
+DROP TABLE IF EXISTS bank_fee_revenue_accounts CASCADE;
\ No newline at end of file
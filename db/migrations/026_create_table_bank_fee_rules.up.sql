@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS bank_fee_revenue_accounts(
+  currency                  VARCHAR(5)      PRIMARY KEY,
+  account_uuid              UUID            NOT NULL REFERENCES bank_accounts
+);
+
+CREATE TABLE IF NOT EXISTS bank_fee_rules(
+  fee_rule_uuid             UUID            PRIMARY KEY,
+  product_uuid              UUID            NOT NULL REFERENCES bank_account_products,
+  fee_name                  VARCHAR(100)    NOT NULL,
+  operation                 VARCHAR(20)     NOT NULL,
+  fee_type                  VARCHAR(20)     NOT NULL,
+  currency                  VARCHAR(5),
+  flat_amount               NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  rate                      NUMERIC(9,6)    NOT NULL DEFAULT 0,
+  min_fee                   NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  max_fee                   NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  created_at                TIMESTAMPTZ,
+  updated_at                TIMESTAMPTZ,
+  CONSTRAINT bank_fee_rules_operation_check
+    CHECK (operation IN ('TRANSFER', 'DEPOSIT', 'WITHDRAWAL')),
+  CONSTRAINT bank_fee_rules_fee_type_check
+    CHECK (fee_type IN ('FLAT', 'PERCENTAGE', 'TIERED', 'FX_MARKUP')),
+  CONSTRAINT bank_fee_rules_amount_check
+    CHECK (flat_amount >= 0 AND rate >= 0 AND min_fee >= 0 AND max_fee >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS bank_fee_rules_product_uuid_operation_idx ON bank_fee_rules (product_uuid, operation);
+
+CREATE TABLE IF NOT EXISTS bank_fee_rule_tiers(
+  fee_rule_uuid             UUID            NOT NULL REFERENCES bank_fee_rules,
+  min_amount                NUMERIC(18,3)   NOT NULL,
+  flat_amount               NUMERIC(18,3)   NOT NULL DEFAULT 0,
+  rate                      NUMERIC(9,6)    NOT NULL DEFAULT 0,
+  PRIMARY KEY (fee_rule_uuid, min_amount)
+);
+
+CREATE TABLE IF NOT EXISTS bank_fee_charges(
+  charge_uuid               UUID            PRIMARY KEY,
+  fee_rule_uuid             UUID            NOT NULL REFERENCES bank_fee_rules,
+  account_uuid              UUID            NOT NULL REFERENCES bank_accounts,
+  transfer_uuid             UUID            REFERENCES bank_transfers,
+  transaction_uuid          UUID            REFERENCES bank_transactions,
+  fee_name                  VARCHAR(100)    NOT NULL,
+  fee_type                  VARCHAR(20)     NOT NULL,
+  amount                    NUMERIC(18,3)   NOT NULL,
+  currency                  VARCHAR(5)      NOT NULL,
+  fee_transaction_uuid      UUID            NOT NULL REFERENCES bank_transactions,
+  revenue_transaction_uuid  UUID            NOT NULL REFERENCES bank_transactions,
+  created_at                TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS bank_fee_charges_transfer_uuid_idx ON bank_fee_charges (transfer_uuid);
+
+CREATE INDEX IF NOT EXISTS bank_fee_charges_transaction_uuid_idx ON bank_fee_charges (transaction_uuid);
\ No newline at end of file
@@ -0,0 +1,11 @@
+UPDATE bank_accounts SET product_uuid = NULL WHERE account_number = '7835697002';
+
+DELETE FROM bank_fee_charges;
+
+DELETE FROM bank_fee_rule_tiers;
+
+DELETE FROM bank_fee_rules;
+
+DELETE FROM bank_fee_revenue_accounts;
+
+DELETE FROM bank_account_products WHERE product_code = 'CHECKING_STANDARD';
\ No newline at end of file
@@ -0,0 +1,116 @@
+INSERT
+	INTO
+	bank_accounts (account_uuid,
+	account_number,
+	account_name,
+	currency,
+	current_balance,
+	created_at,
+	updated_at)
+VALUES('0f3c8d2e-6a4b-4c7d-9e1f-2a3b4c5d6e01',
+'7835699001',
+'Fee revenue USD',
+'USD',
+0,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_fee_revenue_accounts (currency,
+	account_uuid)
+VALUES('USD',
+'0f3c8d2e-6a4b-4c7d-9e1f-2a3b4c5d6e01')
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_products (product_uuid,
+	product_code,
+	product_name,
+	created_at,
+	updated_at)
+VALUES('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a02',
+'CHECKING_STANDARD',
+'Standard checking',
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_fee_rules (fee_rule_uuid,
+	product_uuid,
+	fee_name,
+	operation,
+	fee_type,
+	currency,
+	rate,
+	min_fee,
+	max_fee,
+	created_at,
+	updated_at)
+VALUES('8d1e4f20-7b3a-4e5c-9d6f-0a1b2c3d4e01',
+'5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a02',
+'Transfer fee',
+'TRANSFER',
+'TIERED',
+'USD',
+0,
+0,
+25,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_fee_rule_tiers (fee_rule_uuid,
+	min_amount,
+	flat_amount,
+	rate)
+VALUES('8d1e4f20-7b3a-4e5c-9d6f-0a1b2c3d4e01',
+0,
+0.5,
+0),
+('8d1e4f20-7b3a-4e5c-9d6f-0a1b2c3d4e01',
+100,
+0,
+0.005),
+('8d1e4f20-7b3a-4e5c-9d6f-0a1b2c3d4e01',
+1000,
+2,
+0.002)
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_fee_rules (fee_rule_uuid,
+	product_uuid,
+	fee_name,
+	operation,
+	fee_type,
+	flat_amount,
+	created_at,
+	updated_at)
+VALUES('8d1e4f20-7b3a-4e5c-9d6f-0a1b2c3d4e02',
+'5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a02',
+'Withdrawal fee',
+'WITHDRAWAL',
+'FLAT',
+1,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+UPDATE bank_accounts
+SET product_uuid = '5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a02',
+    updated_at = now()
+WHERE account_number = '7835697002';
\ No newline at end of file
@@ -0,0 +1,39 @@
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS overdraft_fee NUMERIC(18,3) NOT NULL DEFAULT 0;
+
+UPDATE bank_accounts a
+SET overdraft_fee = r.flat_amount,
+    updated_at = now()
+FROM bank_fee_rules r
+WHERE r.product_uuid = a.product_uuid
+	AND r.operation = 'OVERDRAFT'
+	AND r.fee_type = 'FLAT'
+	AND a.overdraft_limit > 0;
+
+UPDATE bank_accounts a
+SET overdraft_fee = r.flat_amount,
+    updated_at = now()
+FROM bank_fee_rules r
+WHERE r.account_uuid = a.account_uuid
+	AND r.operation = 'OVERDRAFT'
+	AND r.fee_type = 'FLAT';
+
+DELETE FROM bank_fee_charges
+WHERE fee_rule_uuid IN (SELECT fee_rule_uuid FROM bank_fee_rules WHERE operation = 'OVERDRAFT' OR account_uuid IS NOT NULL);
+
+DELETE FROM bank_fee_rule_tiers
+WHERE fee_rule_uuid IN (SELECT fee_rule_uuid FROM bank_fee_rules WHERE operation = 'OVERDRAFT' OR account_uuid IS NOT NULL);
+
+DELETE FROM bank_fee_rules WHERE operation = 'OVERDRAFT' OR account_uuid IS NOT NULL;
+
+DROP INDEX IF EXISTS bank_fee_rules_account_uuid_operation_idx;
+
+ALTER TABLE bank_fee_rules DROP CONSTRAINT IF EXISTS bank_fee_rules_scope_check;
+
+ALTER TABLE bank_fee_rules DROP COLUMN IF EXISTS account_uuid;
+
+ALTER TABLE bank_fee_rules ALTER COLUMN product_uuid SET NOT NULL;
+
+ALTER TABLE bank_fee_rules DROP CONSTRAINT IF EXISTS bank_fee_rules_operation_check;
+
+ALTER TABLE bank_fee_rules ADD CONSTRAINT bank_fee_rules_operation_check
+  CHECK (operation IN ('TRANSFER', 'DEPOSIT', 'WITHDRAWAL'));
\ No newline at end of file
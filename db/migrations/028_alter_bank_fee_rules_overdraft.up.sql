@@ -0,0 +1,39 @@
+ALTER TABLE bank_fee_rules DROP CONSTRAINT IF EXISTS bank_fee_rules_operation_check;
+
+ALTER TABLE bank_fee_rules ADD CONSTRAINT bank_fee_rules_operation_check
+  CHECK (operation IN ('TRANSFER', 'DEPOSIT', 'WITHDRAWAL', 'OVERDRAFT'));
+
+ALTER TABLE bank_fee_rules ALTER COLUMN product_uuid DROP NOT NULL;
+
+ALTER TABLE bank_fee_rules ADD COLUMN IF NOT EXISTS account_uuid UUID REFERENCES bank_accounts;
+
+ALTER TABLE bank_fee_rules DROP CONSTRAINT IF EXISTS bank_fee_rules_scope_check;
+
+ALTER TABLE bank_fee_rules ADD CONSTRAINT bank_fee_rules_scope_check
+  CHECK ((product_uuid IS NULL) <> (account_uuid IS NULL));
+
+CREATE INDEX IF NOT EXISTS bank_fee_rules_account_uuid_operation_idx ON bank_fee_rules (account_uuid, operation);
+
+INSERT
+	INTO
+	bank_fee_rules (fee_rule_uuid,
+	account_uuid,
+	fee_name,
+	operation,
+	fee_type,
+	flat_amount,
+	created_at,
+	updated_at)
+SELECT md5(account_uuid::text || 'OVERDRAFT')::uuid,
+	account_uuid,
+	'Overdraft fee',
+	'OVERDRAFT',
+	'FLAT',
+	overdraft_fee,
+	now(),
+	now()
+FROM bank_accounts
+WHERE overdraft_fee > 0
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS overdraft_fee;
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE bank_transfer_limits DROP COLUMN IF EXISTS product_uuid;
+
+DROP TABLE IF EXISTS bank_account_product_currencies;
+
+ALTER TABLE bank_account_products DROP CONSTRAINT IF EXISTS bank_account_products_product_type_check;
+
+ALTER TABLE bank_account_products DROP COLUMN IF EXISTS transfers_out_allowed;
+
+ALTER TABLE bank_account_products DROP COLUMN IF EXISTS min_balance;
+
+ALTER TABLE bank_account_products DROP COLUMN IF EXISTS product_type;
\ No newline at end of file
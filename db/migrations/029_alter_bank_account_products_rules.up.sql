@@ -0,0 +1,16 @@
+ALTER TABLE bank_account_products ADD COLUMN IF NOT EXISTS product_type VARCHAR(20) NOT NULL DEFAULT 'CHECKING';
+
+ALTER TABLE bank_account_products ADD COLUMN IF NOT EXISTS min_balance NUMERIC(18,3) NOT NULL DEFAULT 0;
+
+ALTER TABLE bank_account_products ADD COLUMN IF NOT EXISTS transfers_out_allowed BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE bank_account_products ADD CONSTRAINT bank_account_products_product_type_check
+  CHECK (product_type IN ('CHECKING', 'SAVINGS', 'BUSINESS'));
+
+CREATE TABLE IF NOT EXISTS bank_account_product_currencies(
+  product_uuid              UUID            NOT NULL REFERENCES bank_account_products,
+  currency                  VARCHAR(5)      NOT NULL,
+  PRIMARY KEY (product_uuid, currency)
+);
+
+ALTER TABLE bank_transfer_limits ADD COLUMN IF NOT EXISTS product_uuid UUID REFERENCES bank_account_products;
\ No newline at end of file
@@ -0,0 +1,7 @@
+UPDATE bank_accounts SET product_uuid = NULL WHERE account_number IN ('7835697001', '7835697004', '7835697005');
+
+DELETE FROM bank_transfer_limits WHERE product_uuid IS NOT NULL;
+
+DELETE FROM bank_account_product_currencies;
+
+DELETE FROM bank_account_products WHERE product_code = 'BUSINESS_STANDARD';
\ No newline at end of file
@@ -0,0 +1,83 @@
+UPDATE bank_account_products
+SET product_type = 'SAVINGS',
+    min_balance = 5,
+    transfers_out_allowed = TRUE,
+    updated_at = now()
+WHERE product_code = 'SAVINGS_TIERED';
+
+
+UPDATE bank_account_products
+SET product_type = 'CHECKING',
+    min_balance = 0,
+    transfers_out_allowed = TRUE,
+    updated_at = now()
+WHERE product_code = 'CHECKING_STANDARD';
+
+
+INSERT
+	INTO
+	bank_account_products (product_uuid,
+	product_code,
+	product_name,
+	product_type,
+	min_balance,
+	transfers_out_allowed,
+	created_at,
+	updated_at)
+VALUES('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a03',
+'BUSINESS_STANDARD',
+'Business account',
+'BUSINESS',
+0,
+TRUE,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_account_product_currencies (product_uuid,
+	currency)
+VALUES('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a01',
+'USD'),
+('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a02',
+'USD'),
+('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a02',
+'EUR'),
+('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a03',
+'USD'),
+('5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a03',
+'EUR')
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_transfer_limits (limit_uuid,
+	limit_name,
+	product_uuid,
+	max_daily_amount,
+	max_transfers_per_hour,
+	created_at,
+	updated_at)
+VALUES('6c0b5a52-3f0e-4b52-9a51-0c8f2f6f1d04',
+'savings withdrawals',
+'5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a01',
+1000,
+6,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+UPDATE bank_accounts
+SET product_uuid = '5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a02',
+    updated_at = now()
+WHERE account_number IN ('7835697001', '7835697005');
+
+
+UPDATE bank_accounts
+SET product_uuid = '5b7e2a10-4c1d-4f3e-8a6b-1d2c3e4f5a03',
+    updated_at = now()
+WHERE account_number = '7835697004';
\ No newline at end of file
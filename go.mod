@@ -30,12 +30,13 @@ require (
 )
 
 require (
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	golang.org/x/time v0.5.0
 	google.golang.org/genproto v0.0.0-20240604185151-ef581f913117
-	google.golang.org/protobuf v1.34.1 // indirect
+	google.golang.org/protobuf v1.34.1
 )
 
 require (
-	github.com/abhilashdk2016/my-grpc-proto v0.0.5
 	github.com/golang-migrate/migrate/v4 v4.17.1
 	github.com/jackc/pgx/v4 v4.18.3
 	golang.org/x/net v0.26.0 // indirect
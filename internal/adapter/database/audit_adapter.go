@@ -0,0 +1,71 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditChainLockKey serializes appends so that every event is chained to the
+// one written just before it
+const auditChainLockKey = 7835697000
+
+// AppendAuditEvent assigns the next sequence number and the hash of the last
+// event as PrevHash, then stores the event with the hash computed by hashFn
+func (a *DatabaseAdapter) AppendAuditEvent(e AuditEventOrm, hashFn func(e AuditEventOrm) string) (AuditEventOrm, error) {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockKey).Error; err != nil {
+			return err
+		}
+
+		var last AuditEventOrm
+
+		err := tx.Order("sequence DESC").First(&last).Error
+
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		e.Sequence = last.Sequence + 1
+		e.PrevHash = last.Hash
+		e.EventTimestamp = e.EventTimestamp.Truncate(time.Microsecond)
+		e.Hash = hashFn(e)
+
+		return tx.Create(&e).Error
+	})
+
+	return e, err
+}
+
+func (a *DatabaseAdapter) FindAuditEvents(actor string, action string, acct string, from time.Time, to time.Time, afterSequence int64, limit int) ([]AuditEventOrm, error) {
+	var eventOrms []AuditEventOrm
+
+	q := a.db.Where("sequence > ?", afterSequence)
+
+	if actor != "" {
+		q = q.Where("actor = ?", actor)
+	}
+
+	if action != "" {
+		q = q.Where("action = ?", action)
+	}
+
+	if acct != "" {
+		q = q.Where("account_number = ?", acct)
+	}
+
+	if !from.IsZero() {
+		q = q.Where("event_timestamp >= ?", from)
+	}
+
+	if !to.IsZero() {
+		q = q.Where("event_timestamp < ?", to)
+	}
+
+	if err := q.Order("sequence").Limit(limit).Find(&eventOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return eventOrms, nil
+}
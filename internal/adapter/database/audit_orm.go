@@ -0,0 +1,29 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEventOrm struct {
+	Sequence       int64 `gorm:"primary_key;autoIncrement:false"`
+	EventUuid      uuid.UUID
+	EventTimestamp time.Time
+	Actor          string
+	Action         string
+	AccountNumber  string
+	Amount         float64
+	Currency       string
+	RequestId      string
+	ClientAddress  string
+	Outcome        string
+	Details        string
+	PrevHash       string
+	Hash           string
+	CreatedAt      time.Time
+}
+
+func (AuditEventOrm) TableName() string {
+	return "audit_events"
+}
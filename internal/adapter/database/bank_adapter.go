@@ -6,6 +6,8 @@ import (
 
 	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (a *DatabaseAdapter) GetBankAccountByAccountNumber(acct string) (BankAccountOrm, error) {
@@ -36,33 +38,43 @@ func (a *DatabaseAdapter) GetExchangeRateAtTimestamp(fromCur string, toCur strin
 	return exchangeRateOrm, err
 }
 
-func (a *DatabaseAdapter) CreateTransaction(acct BankAccountOrm, t BankTransactionOrm) (uuid.UUID, error) {
-	tx := a.db.Begin()
-
-	if err := tx.Create(t).Error; err != nil {
-		tx.Rollback()
-		return uuid.Nil, err
-	}
-
-	newAmount := t.Amount
+// LockBankAccounts reads the accounts and locks their rows until the end of
+// the current transaction. Rows are locked in uuid order so that transactions
+// locking the same accounts can't deadlock.
+func (a *DatabaseAdapter) LockBankAccounts(accountUuids []uuid.UUID) ([]BankAccountOrm, error) {
+	var bankAccountOrms []BankAccountOrm
 
-	if t.TransactionType == bank.TransactionTypeOut {
-		newAmount = -1 * t.Amount
-	}
+	err := a.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_uuid IN ?", accountUuids).
+		Order("account_uuid").
+		Find(&bankAccountOrms).Error
 
-	newAccountBalance := acct.CurrentBalance + newAmount
+	return bankAccountOrms, err
+}
 
-	if err := tx.Model(&acct).Updates(
-		map[string]interface{}{
-			"current_balance": newAccountBalance,
-			"updated_at":      time.Now(),
-		},
-	).Error; err != nil {
-		tx.Rollback()
+func (a *DatabaseAdapter) CreateTransaction(acct BankAccountOrm, t BankTransactionOrm) (uuid.UUID, error) {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+
+		change := t.Amount
+		if t.TransactionType == bank.TransactionTypeOut {
+			change = -t.Amount
+		}
+
+		return tx.Model(&acct).Updates(
+			map[string]interface{}{
+				"current_balance": gorm.Expr("current_balance + ?", change),
+				"updated_at":      time.Now(),
+			},
+		).Error
+	})
+
+	if err != nil {
 		return uuid.Nil, err
 	}
 
-	tx.Commit()
 	return t.TransactionUuid, nil
 }
 
@@ -74,53 +86,168 @@ func (a *DatabaseAdapter) CreateTransfer(transfer BankTransferOrm) (uuid.UUID, e
 	return transfer.TransferUuid, nil
 }
 func (a *DatabaseAdapter) CreateTransferTransactionPair(fromAccountOrm BankAccountOrm, toAccountOrm BankAccountOrm, fromTransactionOrm BankTransactionOrm, toTransactionOrm BankTransactionOrm) (bool, error) {
-	tx := a.db.Begin()
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(fromTransactionOrm).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(toTransactionOrm).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&fromAccountOrm).Updates(
+			map[string]interface{}{
+				"current_balance": gorm.Expr("current_balance - ?", fromTransactionOrm.Amount),
+				"updated_at":      time.Now(),
+			},
+		).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&toAccountOrm).Updates(
+			map[string]interface{}{
+				"current_balance": gorm.Expr("current_balance + ?", toTransactionOrm.Amount),
+				"updated_at":      time.Now(),
+			},
+		).Error
+	})
+
+	return err == nil, err
+}
+
+// transferStatusColumns are the columns holding the time a transfer reached
+// each state
+var transferStatusColumns = map[string]string{
+	bank.TransferStatusProcessing: "processing_at",
+	bank.TransferStatusCompleted:  "completed_at",
+	bank.TransferStatusFailed:     "failed_at",
+	bank.TransferStatusReversed:   "reversed_at",
+}
 
-	if err := tx.Create(fromTransactionOrm).Error; err != nil {
-		tx.Rollback()
-		return false, err
+// UpdateBankTransferStatus moves a transfer to status at ts if it is in one of
+// the from states, and returns false when it is not
+func (a *DatabaseAdapter) UpdateBankTransferStatus(transferUuid uuid.UUID, from []string, status string, failureReason string, ts time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"status":           status,
+		"transfer_success": status == bank.TransferStatusCompleted || status == bank.TransferStatusReversed,
+		"updated_at":       time.Now(),
 	}
 
-	if err := tx.Create(toTransactionOrm).Error; err != nil {
-		tx.Rollback()
-		return false, err
+	if col, ok := transferStatusColumns[status]; ok {
+		updates[col] = ts
 	}
 
-	fromAccountBalance := fromAccountOrm.CurrentBalance - fromTransactionOrm.Amount
-	if err := tx.Model(&fromAccountOrm).Updates(
-		map[string]interface{}{
-			"current_balance": fromAccountBalance,
-			"updated_at":      time.Now(),
-		},
-	).Error; err != nil {
-		tx.Rollback()
-		return false, err
+	if failureReason != "" {
+		updates["failure_reason"] = failureReason
 	}
 
-	toAccountBalance := toAccountOrm.CurrentBalance + toTransactionOrm.Amount
-	if err := tx.Model(&toAccountOrm).Updates(
-		map[string]interface{}{
-			"current_balance": toAccountBalance,
-			"updated_at":      time.Now(),
-		},
-	).Error; err != nil {
-		tx.Rollback()
-		return false, err
+	res := a.db.Model(&BankTransferOrm{}).
+		Where("transfer_uuid = ? AND status IN ?", transferUuid, from).
+		Updates(updates)
+
+	if res.Error != nil {
+		return false, res.Error
 	}
 
-	tx.Commit()
+	return res.RowsAffected > 0, nil
+}
+
+func (a *DatabaseAdapter) GetBankTransactionsInPeriod(accountUuid uuid.UUID, from time.Time, to time.Time) ([]BankTransactionOrm, error) {
+	var transactionOrms []BankTransactionOrm
 
-	return true, nil
+	if err := a.db.Where("account_uuid = ? AND transaction_timestamp >= ? AND transaction_timestamp < ?", accountUuid, from, to).
+		Order("transaction_timestamp, created_at").
+		Find(&transactionOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return transactionOrms, nil
 }
-func (a *DatabaseAdapter) UpdateBankTransferStatus(transfer BankTransferOrm, status bool) error {
-	if err := a.db.Model(&transfer).Updates(
-		map[string]interface{}{
-			"transfer_success": status,
-			"updated_at":       time.Now(),
-		},
-	).Error; err != nil {
-		return err
+
+// GetBankAccountBalanceAt works the stored balance of the account back to
+// what it was just before ts by taking off the transactions posted since, so
+// a balance the account was opened with counts as well
+func (a *DatabaseAdapter) GetBankAccountBalanceAt(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	var balance float64
+
+	since := a.db.Model(&BankTransactionOrm{}).
+		Select("COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount ELSE -amount END), 0)", bank.TransactionTypeIn).
+		Where("account_uuid = ? AND transaction_timestamp >= ?", accountUuid, ts)
+
+	err := a.db.Model(&BankAccountOrm{}).
+		Select("current_balance - (?)", since).
+		Where("account_uuid = ?", accountUuid).
+		Scan(&balance).Error
+
+	return balance, err
+}
+
+func (a *DatabaseAdapter) GetBankTransactionSummaries(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]BankTransactionSummaryOrm, error) {
+	var summaryOrms []BankTransactionSummaryOrm
+
+	err := a.db.Model(&BankTransactionOrm{}).
+		Select("date_trunc(?, transaction_timestamp AT TIME ZONE 'UTC') AS summary_on_date, "+
+			"COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount ELSE 0 END), 0) AS sum_in, "+
+			"COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount ELSE 0 END), 0) AS sum_out, "+
+			"COUNT(*) AS count", period, bank.TransactionTypeIn, bank.TransactionTypeOut).
+		Where("account_uuid = ? AND transaction_timestamp >= ? AND transaction_timestamp < ?", accountUuid, from, to).
+		Group("summary_on_date").
+		Order("summary_on_date").
+		Scan(&summaryOrms).Error
+
+	return summaryOrms, err
+}
+
+func (a *DatabaseAdapter) GetBankPrincipal(subject string) (BankPrincipalOrm, error) {
+	var principalOrm BankPrincipalOrm
+
+	err := a.db.First(&principalOrm, "subject = ?", subject).Error
+
+	return principalOrm, err
+}
+
+func (a *DatabaseAdapter) GetBankAccountAccess(accountUuid uuid.UUID, subject string) (BankAccountAccessOrm, error) {
+	var accessOrm BankAccountAccessOrm
+
+	err := a.db.First(&accessOrm, "account_uuid = ? AND subject = ?", accountUuid, subject).Error
+
+	return accessOrm, err
+}
+
+// GetBankTransferLimits returns the limits of the account, of its product
+// and those that apply to every account. productUuid is nil for an account
+// without a product.
+func (a *DatabaseAdapter) GetBankTransferLimits(accountUuid uuid.UUID, productUuid *uuid.UUID, currency string) ([]BankTransferLimitOrm, error) {
+	var limitOrms []BankTransferLimitOrm
+
+	if err := a.db.Where("(account_uuid IS NULL OR account_uuid = ?) AND (product_uuid IS NULL OR product_uuid = ?) AND (currency IS NULL OR currency = ?)",
+		accountUuid, productUuid, currency).
+		Order("limit_name").
+		Find(&limitOrms).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return limitOrms, nil
+}
+
+// GetBankTransferTotalsSince totals the completed transfers from the account
+// since a time. Reversed transfers and the reversed part of partially
+// reversed ones don't count.
+func (a *DatabaseAdapter) GetBankTransferTotalsSince(fromAccountUuid uuid.UUID, since time.Time) (BankTransferTotalsOrm, error) {
+	var totalsOrm BankTransferTotalsOrm
+
+	err := a.db.Model(&BankTransferOrm{}).
+		Select("COALESCE(SUM(amount - reversed_amount), 0) AS amount, COUNT(*) AS count").
+		Where("from_account_uuid = ? AND status = ? AND transfer_timestamp >= ?", fromAccountUuid, bank.TransferStatusCompleted, since).
+		Scan(&totalsOrm).Error
+
+	return totalsOrm, err
+}
+
+func (a *DatabaseAdapter) GetBankAccountByUuid(accountUuid uuid.UUID) (BankAccountOrm, error) {
+	var bankAccountOrm BankAccountOrm
+
+	err := a.db.First(&bankAccountOrm, "account_uuid = ?", accountUuid).Error
+
+	return bankAccountOrm, err
 }
@@ -1,20 +1,26 @@
 package database
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type BankAccountOrm struct {
-	AccountUuid    uuid.UUID `gorm:"primary_key"`
-	AccountNumber  string
-	AccountName    string
-	Currency       string
-	CurrentBalance float64
-	Transactions   []BankTransactionOrm `gorm:"foreignKey:AccountUuid"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	AccountUuid           uuid.UUID `gorm:"primary_key"`
+	AccountNumber         string
+	AccountName           string
+	Currency              string
+	CurrentBalance        float64
+	OverdraftLimit        float64
+	OverdraftInterestRate float64
+	ProductUuid           *uuid.UUID
+	Transactions          []BankTransactionOrm `gorm:"foreignKey:AccountUuid"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
 }
 
 func (BankAccountOrm) TableName() string {
@@ -28,6 +34,8 @@ type BankTransactionOrm struct {
 	Amount               float64
 	TransactionType      string
 	Notes                string
+	TransferUuid         *uuid.UUID
+	Metadata             TransactionMetadataOrm `gorm:"type:jsonb"`
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
@@ -36,6 +44,44 @@ func (BankTransactionOrm) TableName() string {
 	return "bank_transactions"
 }
 
+// TransactionMetadataOrm is stored as a JSONB document, empty fields are
+// left out so that containment queries only match what was set
+type TransactionMetadataOrm struct {
+	Category              string   `json:"category,omitempty"`
+	Tags                  []string `json:"tags,omitempty"`
+	CounterpartyName      string   `json:"counterparty_name,omitempty"`
+	CounterpartyReference string   `json:"counterparty_reference,omitempty"`
+	ExternalReferenceId   string   `json:"external_reference_id,omitempty"`
+}
+
+func (m TransactionMetadataOrm) Value() (driver.Value, error) {
+	b, err := json.Marshal(m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (m *TransactionMetadataOrm) Scan(value interface{}) error {
+	var b []byte
+
+	switch v := value.(type) {
+	case nil:
+		*m = TransactionMetadataOrm{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("can't scan %T into transaction metadata", value)
+	}
+
+	return json.Unmarshal(b, m)
+}
+
 type BankExchangeRateOrm struct {
 	ExchangeRateUuid   uuid.UUID `gorm:"primary_key"`
 	FromCurrency       string
@@ -59,6 +105,14 @@ type BankTransferOrm struct {
 	Amount            float64
 	TransferTimestamp time.Time
 	TransferSuccess   bool
+	ReversedAmount    float64
+	ReversalStatus    string
+	Status            string
+	FailureReason     string
+	ProcessingAt      *time.Time
+	CompletedAt       *time.Time
+	FailedAt          *time.Time
+	ReversedAt        *time.Time
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
@@ -66,3 +120,310 @@ type BankTransferOrm struct {
 func (BankTransferOrm) TableName() string {
 	return "bank_transfers"
 }
+
+// BankTransactionSummaryOrm is not a table, it holds one bucket of the
+// aggregated bank_transactions returned by GetBankTransactionSummaries
+type BankTransactionSummaryOrm struct {
+	SummaryOnDate time.Time
+	SumIn         float64
+	SumOut        float64
+	Count         int64
+}
+
+// BankTransactionSummaryReportOrm is the stored summary of one closed period
+type BankTransactionSummaryReportOrm struct {
+	AccountUuid uuid.UUID `gorm:"primary_key"`
+	Period      string    `gorm:"primary_key"`
+	PeriodStart time.Time `gorm:"primary_key"`
+	PeriodEnd   time.Time
+	SumIn       float64
+	SumOut      float64
+	Count       int64
+	GeneratedAt time.Time
+}
+
+func (BankTransactionSummaryReportOrm) TableName() string {
+	return "bank_transaction_summary_reports"
+}
+
+type BankPrincipalOrm struct {
+	Subject   string `gorm:"primary_key"`
+	Role      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (BankPrincipalOrm) TableName() string {
+	return "bank_principals"
+}
+
+type BankAccountAccessOrm struct {
+	AccountUuid uuid.UUID `gorm:"primary_key"`
+	Subject     string    `gorm:"primary_key"`
+	AccessType  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (BankAccountAccessOrm) TableName() string {
+	return "bank_account_access"
+}
+
+// BankTransferLimitOrm applies to every account when AccountUuid is nil and
+// to every currency when Currency is nil. A zero maximum is not enforced.
+type BankTransferLimitOrm struct {
+	LimitUuid            uuid.UUID `gorm:"primary_key"`
+	LimitName            string
+	AccountUuid          *uuid.UUID
+	ProductUuid          *uuid.UUID
+	Currency             *string
+	MaxAmountPerTransfer float64
+	MaxDailyAmount       float64
+	MaxMonthlyAmount     float64
+	MaxTransfersPerHour  int64
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (BankTransferLimitOrm) TableName() string {
+	return "bank_transfer_limits"
+}
+
+// BankTransferTotalsOrm is not a table, it holds the amount and number of
+// successful transfers out of an account returned by GetBankTransferTotalsSince
+type BankTransferTotalsOrm struct {
+	Amount float64
+	Count  int64
+}
+
+type BankHoldOrm struct {
+	HoldUuid        uuid.UUID `gorm:"primary_key"`
+	AccountUuid     uuid.UUID
+	Amount          float64
+	Currency        string
+	Status          string
+	Reference       string
+	ExpiresAt       time.Time
+	CapturedAmount  float64
+	TransactionUuid *uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (BankHoldOrm) TableName() string {
+	return "bank_holds"
+}
+
+type ScheduledTransferOrm struct {
+	ScheduleUuid    uuid.UUID `gorm:"primary_key"`
+	FromAccountUuid uuid.UUID
+	ToAccountUuid   uuid.UUID
+	Currency        string
+	Amount          float64
+	Frequency       string
+	Interval        int
+	StartAt         time.Time
+	EndAt           *time.Time
+	Occurrence      int
+	NextRunAt       time.Time
+	NextAttemptAt   time.Time
+	Attempts        int
+	Status          string
+	CreatedBy       string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (ScheduledTransferOrm) TableName() string {
+	return "scheduled_transfers"
+}
+
+type ScheduledTransferExecutionOrm struct {
+	ExecutionUuid uuid.UUID `gorm:"primary_key"`
+	ScheduleUuid  uuid.UUID
+	ScheduledFor  time.Time
+	Attempt       int
+	Status        string
+	TransferUuid  *uuid.UUID
+	Error         string
+	StartedAt     time.Time
+	FinishedAt    *time.Time
+}
+
+func (ScheduledTransferExecutionOrm) TableName() string {
+	return "scheduled_transfer_executions"
+}
+
+type BankTransferReversalOrm struct {
+	ReversalUuid          uuid.UUID `gorm:"primary_key"`
+	TransferUuid          uuid.UUID
+	Amount                float64
+	Reason                string
+	DebitTransactionUuid  uuid.UUID
+	CreditTransactionUuid uuid.UUID
+	CreatedBy             string
+	CreatedAt             time.Time
+}
+
+func (BankTransferReversalOrm) TableName() string {
+	return "bank_transfer_reversals"
+}
+
+type TransferBatchOrm struct {
+	BatchUuid      uuid.UUID `gorm:"primary_key"`
+	FileName       string
+	Format         string
+	Mode           string
+	Status         string
+	LineCount      int
+	SucceededCount int
+	FailedCount    int
+	CreatedBy      string
+	CreatedAt      time.Time
+	CompletedAt    *time.Time
+	UpdatedAt      time.Time
+}
+
+func (TransferBatchOrm) TableName() string {
+	return "transfer_batches"
+}
+
+type TransferBatchLineOrm struct {
+	BatchUuid         uuid.UUID `gorm:"primary_key"`
+	LineNumber        int       `gorm:"primary_key"`
+	FromAccountNumber string
+	ToAccountNumber   string
+	Currency          string
+	Amount            float64
+	Reference         string
+	Status            string
+	TransferUuid      *uuid.UUID
+	Error             string
+	UpdatedAt         time.Time
+}
+
+func (TransferBatchLineOrm) TableName() string {
+	return "transfer_batch_lines"
+}
+
+type BankAccountProductOrm struct {
+	ProductUuid         uuid.UUID `gorm:"primary_key"`
+	ProductCode         string
+	ProductName         string
+	ProductType         string
+	DayCountConvention  string
+	MinBalance          float64
+	TransfersOutAllowed bool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (BankAccountProductOrm) TableName() string {
+	return "bank_account_products"
+}
+
+type BankAccountProductCurrencyOrm struct {
+	ProductUuid uuid.UUID `gorm:"primary_key"`
+	Currency    string    `gorm:"primary_key"`
+}
+
+func (BankAccountProductCurrencyOrm) TableName() string {
+	return "bank_account_product_currencies"
+}
+
+type BankAccountProductInterestTierOrm struct {
+	ProductUuid uuid.UUID `gorm:"primary_key"`
+	MinBalance  float64   `gorm:"primary_key"`
+	AnnualRate  float64
+}
+
+func (BankAccountProductInterestTierOrm) TableName() string {
+	return "bank_account_product_interest_tiers"
+}
+
+type BankInterestAccrualOrm struct {
+	AccountUuid        uuid.UUID `gorm:"primary_key"`
+	AccrualDate        time.Time `gorm:"primary_key"`
+	PeriodStart        time.Time
+	Balance            float64
+	Amount             float64
+	DayCountConvention string
+	CreatedAt          time.Time
+}
+
+func (BankInterestAccrualOrm) TableName() string {
+	return "bank_interest_accruals"
+}
+
+type BankInterestCapitalizationOrm struct {
+	AccountUuid     uuid.UUID `gorm:"primary_key"`
+	PeriodStart     time.Time `gorm:"primary_key"`
+	Amount          float64
+	Status          string
+	TransactionUuid *uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (BankInterestCapitalizationOrm) TableName() string {
+	return "bank_interest_capitalizations"
+}
+
+// BankInterestPeriodOrm is the interest accrued by an account over a period
+type BankInterestPeriodOrm struct {
+	AccountUuid uuid.UUID
+	PeriodStart time.Time
+	Amount      float64
+}
+
+// BankFeeRuleOrm is a fee rule of a product or, with AccountUuid set, of a
+// single account
+type BankFeeRuleOrm struct {
+	FeeRuleUuid uuid.UUID `gorm:"primary_key"`
+	ProductUuid *uuid.UUID
+	AccountUuid *uuid.UUID
+	FeeName     string
+	Operation   string
+	FeeType     string
+	Currency    *string
+	FlatAmount  float64
+	Rate        float64
+	MinFee      float64
+	MaxFee      float64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (BankFeeRuleOrm) TableName() string {
+	return "bank_fee_rules"
+}
+
+type BankFeeRuleTierOrm struct {
+	FeeRuleUuid uuid.UUID `gorm:"primary_key"`
+	MinAmount   float64   `gorm:"primary_key"`
+	FlatAmount  float64
+	Rate        float64
+}
+
+func (BankFeeRuleTierOrm) TableName() string {
+	return "bank_fee_rule_tiers"
+}
+
+type BankFeeChargeOrm struct {
+	ChargeUuid             uuid.UUID `gorm:"primary_key"`
+	FeeRuleUuid            uuid.UUID
+	AccountUuid            uuid.UUID
+	TransferUuid           *uuid.UUID
+	TransactionUuid        *uuid.UUID
+	FeeName                string
+	FeeType                string
+	Amount                 float64
+	Currency               string
+	FeeTransactionUuid     uuid.UUID
+	RevenueTransactionUuid uuid.UUID
+	CreatedAt              time.Time
+}
+
+func (BankFeeChargeOrm) TableName() string {
+	return "bank_fee_charges"
+}
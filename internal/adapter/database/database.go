@@ -8,6 +8,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrRecordNotFound is returned by lookups that match no row
+var ErrRecordNotFound = gorm.ErrRecordNotFound
+
 type DatabaseAdapter struct {
 	db *gorm.DB
 }
@@ -25,3 +28,12 @@ func NewDatabaseAdapter(conn *sql.DB) (*DatabaseAdapter, error) {
 		db: db,
 	}, nil
 }
+
+// Transaction runs fn with an adapter whose calls all belong to one database
+// transaction, committed when fn returns nil and rolled back otherwise.
+// Transactions the adapter starts within fn become savepoints of it.
+func (a *DatabaseAdapter) Transaction(fn func(tx *DatabaseAdapter) error) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&DatabaseAdapter{db: tx})
+	})
+}
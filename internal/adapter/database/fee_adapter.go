@@ -0,0 +1,89 @@
+package database
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) GetBankFeeRules(productUuid *uuid.UUID, accountUuid uuid.UUID, operation string) ([]BankFeeRuleOrm, error) {
+	var ruleOrms []BankFeeRuleOrm
+
+	q := a.db.Where("account_uuid = ? AND operation = ?", accountUuid, operation)
+
+	if productUuid != nil {
+		q = a.db.Where("(account_uuid = ? OR product_uuid = ?) AND operation = ?", accountUuid, *productUuid, operation)
+	}
+
+	if err := q.Order("fee_name").
+		Find(&ruleOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return ruleOrms, nil
+}
+
+func (a *DatabaseAdapter) GetBankFeeRuleTiers(feeRuleUuids []uuid.UUID) ([]BankFeeRuleTierOrm, error) {
+	var tierOrms []BankFeeRuleTierOrm
+
+	if len(feeRuleUuids) == 0 {
+		return tierOrms, nil
+	}
+
+	if err := a.db.Where("fee_rule_uuid IN ?", feeRuleUuids).Order("min_amount").Find(&tierOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return tierOrms, nil
+}
+
+// GetBankFeeRevenueAccount returns the internal account fees in currency
+// are credited to
+func (a *DatabaseAdapter) GetBankFeeRevenueAccount(currency string) (BankAccountOrm, error) {
+	var accountOrm BankAccountOrm
+
+	err := a.db.Where("account_uuid = (SELECT account_uuid FROM bank_fee_revenue_accounts WHERE currency = ?)", currency).
+		First(&accountOrm).Error
+
+	return accountOrm, err
+}
+
+// CreateBankFeeCharge posts the fee debit and the revenue credit of a charge
+// and records the charge, all or nothing
+func (a *DatabaseAdapter) CreateBankFeeCharge(c BankFeeChargeOrm, debit BankTransactionOrm, credit BankTransactionOrm) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range []BankTransactionOrm{debit, credit} {
+			if err := tx.Create(&t).Error; err != nil {
+				return err
+			}
+
+			change := t.Amount
+			if t.TransactionType == bank.TransactionTypeOut {
+				change = -change
+			}
+
+			if err := tx.Model(&BankAccountOrm{}).
+				Where("account_uuid = ?", t.AccountUuid).
+				Updates(map[string]interface{}{
+					"current_balance": gorm.Expr("current_balance + ?", change),
+					"updated_at":      time.Now(),
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&c).Error
+	})
+}
+
+func (a *DatabaseAdapter) GetBankFeeChargesByTransfer(transferUuid uuid.UUID) ([]BankFeeChargeOrm, error) {
+	var chargeOrms []BankFeeChargeOrm
+
+	if err := a.db.Where("transfer_uuid = ?", transferUuid).Order("created_at, fee_name").Find(&chargeOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return chargeOrms, nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) CreateBankHold(h BankHoldOrm) (uuid.UUID, error) {
+	if err := a.db.Create(h).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	return h.HoldUuid, nil
+}
+
+func (a *DatabaseAdapter) GetBankHold(holdUuid uuid.UUID) (BankHoldOrm, error) {
+	var holdOrm BankHoldOrm
+
+	err := a.db.First(&holdOrm, "hold_uuid = ?", holdUuid).Error
+
+	return holdOrm, err
+}
+
+// GetBankHoldsActiveAmount sums the holds of the account that still reserve
+// funds at ts, including active holds past their expiry not yet swept
+func (a *DatabaseAdapter) GetBankHoldsActiveAmount(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	var amount float64
+
+	err := a.db.Model(&BankHoldOrm{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("account_uuid = ? AND status = ? AND expires_at > ?", accountUuid, bank.HoldStatusActive, ts).
+		Scan(&amount).Error
+
+	return amount, err
+}
+
+// CaptureBankHold posts the capture transaction and marks the hold captured
+// in one database transaction. It returns false without changes when the
+// hold is no longer active at ts.
+func (a *DatabaseAdapter) CaptureBankHold(h BankHoldOrm, t BankTransactionOrm, ts time.Time) (bool, error) {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(&BankHoldOrm{}).
+			Where("hold_uuid = ? AND status = ? AND expires_at > ?", h.HoldUuid, bank.HoldStatusActive, ts).
+			Updates(map[string]interface{}{
+				"status":           bank.HoldStatusCaptured,
+				"captured_amount":  t.Amount,
+				"transaction_uuid": t.TransactionUuid,
+				"updated_at":       time.Now(),
+			})
+
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return bank.ErrHoldNotActive
+		}
+
+		if err := tx.Model(&BankAccountOrm{}).
+			Where("account_uuid = ?", h.AccountUuid).
+			Updates(map[string]interface{}{
+				"current_balance": gorm.Expr("current_balance - ?", t.Amount),
+				"updated_at":      time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, bank.ErrHoldNotActive) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// UpdateBankHoldStatus moves an active hold to status, and returns false when
+// the hold was no longer active
+func (a *DatabaseAdapter) UpdateBankHoldStatus(holdUuid uuid.UUID, status string) (bool, error) {
+	res := a.db.Model(&BankHoldOrm{}).
+		Where("hold_uuid = ? AND status = ?", holdUuid, bank.HoldStatusActive).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		})
+
+	return res.RowsAffected > 0, res.Error
+}
+
+// ExpireBankHolds marks the active holds past their expiry at ts as expired
+func (a *DatabaseAdapter) ExpireBankHolds(ts time.Time) (int64, error) {
+	res := a.db.Model(&BankHoldOrm{}).
+		Where("status = ? AND expires_at <= ?", bank.HoldStatusActive, ts).
+		Updates(map[string]interface{}{
+			"status":     bank.HoldStatusExpired,
+			"updated_at": time.Now(),
+		})
+
+	return res.RowsAffected, res.Error
+}
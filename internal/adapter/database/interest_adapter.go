@@ -0,0 +1,103 @@
+package database
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) GetBankAccountProductInterestTiers(productUuid uuid.UUID) ([]BankAccountProductInterestTierOrm, error) {
+	var tierOrms []BankAccountProductInterestTierOrm
+
+	if err := a.db.Where("product_uuid = ?", productUuid).Order("min_balance").Find(&tierOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return tierOrms, nil
+}
+
+// FindInterestBearingBankAccounts returns the accounts whose product has at
+// least one interest tier and those charged interest when overdrawn
+func (a *DatabaseAdapter) FindInterestBearingBankAccounts() ([]BankAccountOrm, error) {
+	var accountOrms []BankAccountOrm
+
+	if err := a.db.Where("product_uuid IN (SELECT product_uuid FROM bank_account_product_interest_tiers) OR overdraft_interest_rate > 0").
+		Order("account_number").
+		Find(&accountOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return accountOrms, nil
+}
+
+func (a *DatabaseAdapter) GetLatestBankInterestAccrual(accountUuid uuid.UUID) (BankInterestAccrualOrm, error) {
+	var accrualOrm BankInterestAccrualOrm
+
+	err := a.db.Where("account_uuid = ?", accountUuid).Order("accrual_date DESC").First(&accrualOrm).Error
+
+	return accrualOrm, err
+}
+
+// CreateBankInterestAccrual records the interest of one day, it returns
+// false when that day was already accrued
+func (a *DatabaseAdapter) CreateBankInterestAccrual(accrualOrm BankInterestAccrualOrm) (bool, error) {
+	res := a.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&accrualOrm)
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// FindUncapitalizedBankInterestPeriods sums the interest accrued over each
+// period starting before periodStart that has not been capitalized yet
+func (a *DatabaseAdapter) FindUncapitalizedBankInterestPeriods(periodStart time.Time) ([]BankInterestPeriodOrm, error) {
+	var periodOrms []BankInterestPeriodOrm
+
+	err := a.db.Model(&BankInterestAccrualOrm{}).
+		Select("account_uuid, period_start, SUM(amount) AS amount").
+		Where("period_start < ?", periodStart).
+		Where("NOT EXISTS (SELECT 1 FROM bank_interest_capitalizations c "+
+			"WHERE c.account_uuid = bank_interest_accruals.account_uuid "+
+			"AND c.period_start = bank_interest_accruals.period_start AND c.status <> ?)", bank.InterestCapitalizationPending).
+		Group("account_uuid, period_start").
+		Order("period_start, account_uuid").
+		Scan(&periodOrms).Error
+
+	return periodOrms, err
+}
+
+// CreateBankInterestCapitalization records the capitalization of a period
+// unless it exists already, and returns the stored one either way
+func (a *DatabaseAdapter) CreateBankInterestCapitalization(c BankInterestCapitalizationOrm) (BankInterestCapitalizationOrm, error) {
+	if err := a.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&c).Error; err != nil {
+		return BankInterestCapitalizationOrm{}, err
+	}
+
+	var capitalizationOrm BankInterestCapitalizationOrm
+
+	err := a.db.First(&capitalizationOrm, "account_uuid = ? AND period_start = ?", c.AccountUuid, c.PeriodStart).Error
+
+	return capitalizationOrm, err
+}
+
+// FinishBankInterestCapitalization moves a pending capitalization to status,
+// it returns false when the capitalization was no longer pending
+func (a *DatabaseAdapter) FinishBankInterestCapitalization(accountUuid uuid.UUID, periodStart time.Time, status string, transactionUuid *uuid.UUID) (bool, error) {
+	res := a.db.Model(&BankInterestCapitalizationOrm{}).
+		Where("account_uuid = ? AND period_start = ? AND status = ?", accountUuid, periodStart, bank.InterestCapitalizationPending).
+		Updates(map[string]interface{}{
+			"status":           status,
+			"transaction_uuid": transactionUuid,
+			"updated_at":       time.Now(),
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
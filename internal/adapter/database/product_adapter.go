@@ -0,0 +1,23 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+func (a *DatabaseAdapter) GetBankAccountProduct(productUuid uuid.UUID) (BankAccountProductOrm, error) {
+	var productOrm BankAccountProductOrm
+
+	err := a.db.First(&productOrm, "product_uuid = ?", productUuid).Error
+
+	return productOrm, err
+}
+
+func (a *DatabaseAdapter) GetBankAccountProductCurrencies(productUuid uuid.UUID) ([]BankAccountProductCurrencyOrm, error) {
+	var currencyOrms []BankAccountProductCurrencyOrm
+
+	if err := a.db.Where("product_uuid = ?", productUuid).Order("currency").Find(&currencyOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return currencyOrms, nil
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) CreateScheduledTransfer(s ScheduledTransferOrm) (uuid.UUID, error) {
+	if err := a.db.Create(s).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.ScheduleUuid, nil
+}
+
+func (a *DatabaseAdapter) GetScheduledTransfer(scheduleUuid uuid.UUID) (ScheduledTransferOrm, error) {
+	var scheduleOrm ScheduledTransferOrm
+
+	err := a.db.First(&scheduleOrm, "schedule_uuid = ?", scheduleUuid).Error
+
+	return scheduleOrm, err
+}
+
+func (a *DatabaseAdapter) FindScheduledTransfers(fromAccountUuid uuid.UUID) ([]ScheduledTransferOrm, error) {
+	var scheduleOrms []ScheduledTransferOrm
+
+	if err := a.db.Where("from_account_uuid = ?", fromAccountUuid).
+		Order("created_at").
+		Find(&scheduleOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return scheduleOrms, nil
+}
+
+func (a *DatabaseAdapter) GetScheduledTransferExecutions(scheduleUuid uuid.UUID) ([]ScheduledTransferExecutionOrm, error) {
+	var executionOrms []ScheduledTransferExecutionOrm
+
+	if err := a.db.Where("schedule_uuid = ?", scheduleUuid).
+		Order("scheduled_for, attempt").
+		Find(&executionOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return executionOrms, nil
+}
+
+// UpdateScheduledTransferStatus moves an active schedule to status, and
+// returns false when the schedule was no longer active
+func (a *DatabaseAdapter) UpdateScheduledTransferStatus(scheduleUuid uuid.UUID, status string) (bool, error) {
+	res := a.db.Model(&ScheduledTransferOrm{}).
+		Where("schedule_uuid = ? AND status = ?", scheduleUuid, bank.ScheduleStatusActive).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		})
+
+	return res.RowsAffected > 0, res.Error
+}
+
+// ClaimDueScheduledTransfer locks one active schedule due at ts, skipping
+// schedules claimed by another scheduler, and pushes its next attempt to
+// leaseUntil so it is not claimed again while it runs. It returns the
+// schedule as it was before the claim, or false when nothing is due.
+func (a *DatabaseAdapter) ClaimDueScheduledTransfer(ts time.Time, leaseUntil time.Time) (ScheduledTransferOrm, bool, error) {
+	var scheduleOrm ScheduledTransferOrm
+
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", bank.ScheduleStatusActive, ts).
+			Order("next_attempt_at").
+			First(&scheduleOrm).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&ScheduledTransferOrm{}).
+			Where("schedule_uuid = ?", scheduleOrm.ScheduleUuid).
+			Updates(map[string]interface{}{
+				"next_attempt_at": leaseUntil,
+				"updated_at":      time.Now(),
+			}).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return scheduleOrm, false, nil
+	}
+
+	return scheduleOrm, err == nil, err
+}
+
+// GetStartedScheduledTransferExecution returns the execution of the schedule
+// that was started but never finished, if any
+func (a *DatabaseAdapter) GetStartedScheduledTransferExecution(scheduleUuid uuid.UUID) (ScheduledTransferExecutionOrm, error) {
+	var executionOrm ScheduledTransferExecutionOrm
+
+	err := a.db.First(&executionOrm, "schedule_uuid = ? AND status = ?", scheduleUuid, bank.ExecutionStatusStarted).Error
+
+	return executionOrm, err
+}
+
+func (a *DatabaseAdapter) CreateScheduledTransferExecution(e ScheduledTransferExecutionOrm) (uuid.UUID, error) {
+	if err := a.db.Create(e).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	return e.ExecutionUuid, nil
+}
+
+// FinishScheduledTransferExecution stores the result of an execution and the
+// schedule state that follows from it in one database transaction. A schedule
+// cancelled while the execution ran stays cancelled.
+func (a *DatabaseAdapter) FinishScheduledTransferExecution(e ScheduledTransferExecutionOrm, s ScheduledTransferOrm) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ScheduledTransferExecutionOrm{}).
+			Where("execution_uuid = ?", e.ExecutionUuid).
+			Updates(map[string]interface{}{
+				"status":        e.Status,
+				"transfer_uuid": e.TransferUuid,
+				"error":         e.Error,
+				"finished_at":   e.FinishedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&ScheduledTransferOrm{}).
+			Where("schedule_uuid = ? AND status = ?", s.ScheduleUuid, bank.ScheduleStatusActive).
+			Updates(map[string]interface{}{
+				"occurrence":      s.Occurrence,
+				"next_run_at":     s.NextRunAt,
+				"next_attempt_at": s.NextAttemptAt,
+				"attempts":        s.Attempts,
+				"status":          s.Status,
+				"updated_at":      time.Now(),
+			}).Error
+	})
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// GetBankTransactionSummaryReports returns the stored reports of the periods
+// lying within from and to
+func (a *DatabaseAdapter) GetBankTransactionSummaryReports(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]BankTransactionSummaryReportOrm, error) {
+	var reportOrms []BankTransactionSummaryReportOrm
+
+	if err := a.db.Where("account_uuid = ? AND period = ? AND period_start >= ? AND period_end <= ?", accountUuid, period, from, to).
+		Order("period_start").
+		Find(&reportOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return reportOrms, nil
+}
+
+// CreateBankTransactionSummaryReports stores reports, a period already stored
+// is left alone
+func (a *DatabaseAdapter) CreateBankTransactionSummaryReports(reports []BankTransactionSummaryReportOrm) error {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	return a.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reports).Error
+}
+
+// DeleteBankTransactionSummaryReports drops the stored reports of every
+// period ts falls in, they are generated again when next asked for
+func (a *DatabaseAdapter) DeleteBankTransactionSummaryReports(accountUuid uuid.UUID, ts time.Time) error {
+	return a.db.Where("account_uuid = ? AND period_start <= ? AND period_end > ?", accountUuid, ts, ts).
+		Delete(&BankTransactionSummaryReportOrm{}).Error
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankTransactionQuery selects transactions of an account for
+// FindBankTransactions. Zero fields do not filter. Results are ordered newest
+// first and continue after the transaction identified by AfterTimestamp and
+// AfterUuid when those are set.
+type BankTransactionQuery struct {
+	AccountUuid         uuid.UUID
+	TransactionType     string
+	From                time.Time
+	To                  time.Time
+	Category            string
+	Tag                 string
+	CounterpartyName    string
+	ExternalReferenceId string
+	AfterTimestamp      time.Time
+	AfterUuid           uuid.UUID
+	Limit               int
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (a *DatabaseAdapter) FindBankTransactions(q BankTransactionQuery) ([]BankTransactionOrm, error) {
+	var transactionOrms []BankTransactionOrm
+
+	tx := a.db.Model(&BankTransactionOrm{}).Where("account_uuid = ?", q.AccountUuid)
+
+	if q.TransactionType != "" {
+		tx = tx.Where("transaction_type = ?", q.TransactionType)
+	}
+
+	if !q.From.IsZero() {
+		tx = tx.Where("transaction_timestamp >= ?", q.From)
+	}
+
+	if !q.To.IsZero() {
+		tx = tx.Where("transaction_timestamp < ?", q.To)
+	}
+
+	// Exact matches are expressed as JSONB containment so that they can use
+	// the GIN index on metadata
+	contains := TransactionMetadataOrm{
+		Category:            q.Category,
+		ExternalReferenceId: q.ExternalReferenceId,
+	}
+
+	if q.Tag != "" {
+		contains.Tags = []string{q.Tag}
+	}
+
+	if contains.Category != "" || contains.ExternalReferenceId != "" || len(contains.Tags) > 0 {
+		tx = tx.Where("metadata @> ?::jsonb", contains)
+	}
+
+	if q.CounterpartyName != "" {
+		tx = tx.Where("metadata->>'counterparty_name' ILIKE ?", "%"+likeEscaper.Replace(q.CounterpartyName)+"%")
+	}
+
+	if !q.AfterTimestamp.IsZero() {
+		tx = tx.Where("(transaction_timestamp, transaction_uuid) < (?, ?)", q.AfterTimestamp, q.AfterUuid)
+	}
+
+	if err := tx.Order("transaction_timestamp DESC, transaction_uuid DESC").Limit(q.Limit).Find(&transactionOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return transactionOrms, nil
+}
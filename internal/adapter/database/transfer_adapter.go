@@ -0,0 +1,93 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankTransferQuery selects transfers for FindBankTransfers. Zero fields do
+// not filter. Results are ordered newest first and continue after the
+// transfer identified by AfterTimestamp and AfterUuid when those are set.
+type BankTransferQuery struct {
+	AccountUuid     uuid.UUID
+	FromAccountUuid uuid.UUID
+	ToAccountUuid   uuid.UUID
+	Status          string
+	Currency        string
+	MinAmount       float64
+	MaxAmount       float64
+	From            time.Time
+	To              time.Time
+	AfterTimestamp  time.Time
+	AfterUuid       uuid.UUID
+	Limit           int
+}
+
+func (a *DatabaseAdapter) FindBankTransfers(q BankTransferQuery) ([]BankTransferOrm, error) {
+	var transferOrms []BankTransferOrm
+
+	tx := a.db.Model(&BankTransferOrm{})
+
+	if q.AccountUuid != uuid.Nil {
+		tx = tx.Where("(from_account_uuid = ? OR to_account_uuid = ?)", q.AccountUuid, q.AccountUuid)
+	}
+
+	if q.FromAccountUuid != uuid.Nil {
+		tx = tx.Where("from_account_uuid = ?", q.FromAccountUuid)
+	}
+
+	if q.ToAccountUuid != uuid.Nil {
+		tx = tx.Where("to_account_uuid = ?", q.ToAccountUuid)
+	}
+
+	if q.Status != "" {
+		tx = tx.Where("status = ?", q.Status)
+	}
+
+	if q.Currency != "" {
+		tx = tx.Where("currency = ?", q.Currency)
+	}
+
+	if q.MinAmount > 0 {
+		tx = tx.Where("amount >= ?", q.MinAmount)
+	}
+
+	if q.MaxAmount > 0 {
+		tx = tx.Where("amount <= ?", q.MaxAmount)
+	}
+
+	if !q.From.IsZero() {
+		tx = tx.Where("transfer_timestamp >= ?", q.From)
+	}
+
+	if !q.To.IsZero() {
+		tx = tx.Where("transfer_timestamp < ?", q.To)
+	}
+
+	if !q.AfterTimestamp.IsZero() {
+		tx = tx.Where("(transfer_timestamp, transfer_uuid) < (?, ?)", q.AfterTimestamp, q.AfterUuid)
+	}
+
+	if err := tx.Order("transfer_timestamp DESC, transfer_uuid DESC").Limit(q.Limit).Find(&transferOrms).Error; err != nil {
+		return nil, err
+	}
+
+	return transferOrms, nil
+}
+
+// GetBankTransactionsByTransfers returns the transactions posted by the
+// given transfers and their reversals
+func (a *DatabaseAdapter) GetBankTransactionsByTransfers(transferUuids []uuid.UUID) ([]BankTransactionOrm, error) {
+	var transactionOrms []BankTransactionOrm
+
+	if len(transferUuids) == 0 {
+		return transactionOrms, nil
+	}
+
+	err := a.db.Where("transfer_uuid IN ?", transferUuids).
+		Order("transaction_timestamp").
+		Find(&transactionOrms).Error
+
+	return transactionOrms, err
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const transferBatchInsertSize = 500
+
+// CreateTransferBatch stores a batch with all its lines in one database
+// transaction
+func (a *DatabaseAdapter) CreateTransferBatch(b TransferBatchOrm, lines []TransferBatchLineOrm) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&b).Error; err != nil {
+			return err
+		}
+
+		if len(lines) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(lines, transferBatchInsertSize).Error
+	})
+}
+
+func (a *DatabaseAdapter) GetTransferBatch(batchUuid uuid.UUID) (TransferBatchOrm, error) {
+	var batchOrm TransferBatchOrm
+
+	err := a.db.First(&batchOrm, "batch_uuid = ?", batchUuid).Error
+
+	return batchOrm, err
+}
+
+func (a *DatabaseAdapter) GetTransferBatchLines(batchUuid uuid.UUID) ([]TransferBatchLineOrm, error) {
+	var lineOrms []TransferBatchLineOrm
+
+	err := a.db.Where("batch_uuid = ?", batchUuid).
+		Order("line_number").
+		Find(&lineOrms).Error
+
+	return lineOrms, err
+}
+
+func (a *DatabaseAdapter) UpdateTransferBatchLine(l TransferBatchLineOrm) error {
+	return a.db.Model(&TransferBatchLineOrm{}).
+		Where("batch_uuid = ? AND line_number = ?", l.BatchUuid, l.LineNumber).
+		Updates(map[string]interface{}{
+			"status":        l.Status,
+			"transfer_uuid": l.TransferUuid,
+			"error":         l.Error,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+func (a *DatabaseAdapter) UpdateTransferBatch(b TransferBatchOrm) error {
+	return a.db.Model(&TransferBatchOrm{}).
+		Where("batch_uuid = ?", b.BatchUuid).
+		Updates(map[string]interface{}{
+			"status":          b.Status,
+			"succeeded_count": b.SucceededCount,
+			"failed_count":    b.FailedCount,
+			"completed_at":    b.CompletedAt,
+			"updated_at":      time.Now(),
+		}).Error
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) GetBankTransfer(transferUuid uuid.UUID) (BankTransferOrm, error) {
+	var transferOrm BankTransferOrm
+
+	err := a.db.First(&transferOrm, "transfer_uuid = ?", transferUuid).Error
+
+	return transferOrm, err
+}
+
+func (a *DatabaseAdapter) GetBankTransferReversals(transferUuid uuid.UUID) ([]BankTransferReversalOrm, error) {
+	var reversalOrms []BankTransferReversalOrm
+
+	err := a.db.Where("transfer_uuid = ?", transferUuid).
+		Order("created_at").
+		Find(&reversalOrms).Error
+
+	return reversalOrms, err
+}
+
+// ReverseBankTransfer posts the compensating debit and credit of a reversal,
+// adds its amount to the reversed amount of the transfer and records it, in
+// one database transaction. A transfer reversed in full becomes REVERSED. It
+// returns false without changes when the transfer is not completed or has
+// less than the reversal amount left to reverse, and
+// bank.ErrReversalInsufficientFunds when the destination account can't fund
+// the debit.
+func (a *DatabaseAdapter) ReverseBankTransfer(r BankTransferReversalOrm, debit BankTransactionOrm, credit BankTransactionOrm) (bool, error) {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&BankTransferOrm{}).
+			Where("transfer_uuid = ? AND status = ? AND reversed_amount + ? <= amount", r.TransferUuid, bank.TransferStatusCompleted, r.Amount).
+			Updates(map[string]interface{}{
+				"reversed_amount": gorm.Expr("reversed_amount + ?", r.Amount),
+				"reversal_status": gorm.Expr("CASE WHEN reversed_amount + ? >= amount THEN ? ELSE ? END",
+					r.Amount, bank.ReversalStatusFull, bank.ReversalStatusPartial),
+				"status": gorm.Expr("CASE WHEN reversed_amount + ? >= amount THEN ? ELSE status END",
+					r.Amount, bank.TransferStatusReversed),
+				"reversed_at": gorm.Expr("CASE WHEN reversed_amount + ? >= amount THEN ? ELSE reversed_at END",
+					r.Amount, r.CreatedAt),
+				"updated_at": time.Now(),
+			})
+
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return bank.ErrReversalExceedsRemaining
+		}
+
+		for _, t := range []BankTransactionOrm{debit, credit} {
+			if err := tx.Create(t).Error; err != nil {
+				return err
+			}
+
+			change := t.Amount
+			q := tx.Model(&BankAccountOrm{}).Where("account_uuid = ?", t.AccountUuid)
+
+			// the debited account must still be able to fund the reversal
+			// once its active holds are set aside
+			if t.TransactionType == bank.TransactionTypeOut {
+				change = -t.Amount
+				q = q.Where("current_balance + overdraft_limit - (SELECT COALESCE(SUM(amount), 0) FROM bank_holds "+
+					"WHERE account_uuid = ? AND status = ? AND expires_at > ?) >= ?",
+					t.AccountUuid, bank.HoldStatusActive, t.TransactionTimestamp, t.Amount)
+			}
+
+			res := q.Updates(map[string]interface{}{
+				"current_balance": gorm.Expr("current_balance + ?", change),
+				"updated_at":      time.Now(),
+			})
+
+			if res.Error != nil {
+				return res.Error
+			}
+
+			if res.RowsAffected == 0 {
+				return bank.ErrReversalInsufficientFunds
+			}
+		}
+
+		return tx.Create(r).Error
+	})
+
+	if errors.Is(err, bank.ErrReversalExceedsRemaining) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
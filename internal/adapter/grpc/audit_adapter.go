@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+)
+
+func (a *GrpcAdapter) ListAuditEvents(ctx context.Context, req *bank_proto.AuditEventRequest) (*bank_proto.AuditEventList, error) {
+	if err := a.authorize(ctx, auth.ActionReadAudit, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	f := bank.AuditEventFilter{
+		Actor:         req.Actor,
+		Action:        req.Action,
+		AccountNumber: req.AccountNumber,
+		AfterSequence: req.AfterSequence,
+		Limit:         int(req.Limit),
+	}
+
+	if req.FromDate != nil {
+		f.From = dateToTime(req.FromDate)
+	}
+
+	if req.ToDate != nil {
+		f.To = dateToTime(req.ToDate).AddDate(0, 0, 1)
+	}
+
+	events, err := a.bankService.ListAuditEvents(f)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	res := &bank_proto.AuditEventList{
+		Events: make([]*bank_proto.AuditEvent, 0, len(events)),
+	}
+
+	for _, e := range events {
+		res.Events = append(res.Events, &bank_proto.AuditEvent{
+			Sequence:      e.Sequence,
+			EventUuid:     e.EventUuid.String(),
+			Timestamp:     timeToDateTime(e.Timestamp),
+			Actor:         e.Actor,
+			Action:        e.Action,
+			AccountNumber: e.AccountNumber,
+			Amount:        e.Amount,
+			Currency:      e.Currency,
+			RequestId:     e.RequestId,
+			ClientAddress: e.ClientAddress,
+			Outcome:       e.Outcome,
+			Details:       e.Details,
+			PrevHash:      e.PrevHash,
+			Hash:          e.Hash,
+		})
+	}
+
+	return res, nil
+}
+
+func (a *GrpcAdapter) VerifyAuditChain(ctx context.Context, req *bank_proto.AuditChainRequest) (*bank_proto.AuditChainVerification, error) {
+	if err := a.authorize(ctx, auth.ActionReadAudit, ""); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	v, err := a.bankService.VerifyAuditChain()
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	log.Printf("Audit chain verified in %v : verified=%v events=%v\n", time.Since(start), v.Verified, v.EventsChecked)
+
+	return &bank_proto.AuditChainVerification{
+		Verified:       v.Verified,
+		EventsChecked:  v.EventsChecked,
+		BrokenSequence: v.BrokenSequence,
+		Reason:         v.Reason,
+	}, nil
+}
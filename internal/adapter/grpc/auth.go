@@ -0,0 +1,341 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	apiKeyHeader        = "x-api-key"
+	bearerPrefix        = "bearer "
+)
+
+// AuthConfig enables authentication of every call. Callers present either a
+// JWT in the authorization header ("Bearer <token>"), verified against the
+// keys of a local JWKS file, or a static API key in the x-api-key header.
+// Without either, the verified client certificate of an mTLS connection
+// authenticates the caller by its common name.
+type AuthConfig struct {
+	JwksFile      string
+	Issuer        string
+	Audience      string
+	ApiKeysFile   string
+	PublicMethods []string
+}
+
+// defaultPublicMethods can be called without credentials
+var defaultPublicMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+type apiKey struct {
+	Subject string   `json:"subject"`
+	Key     string   `json:"key"`
+	Roles   []string `json:"roles"`
+}
+
+type jwtClaims struct {
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+type authenticator struct {
+	cfg           AuthConfig
+	rsaKeys       map[string]*rsa.PublicKey
+	hmacKeys      map[string][]byte
+	apiKeys       map[[sha256.Size]byte]apiKey
+	publicMethods []string
+}
+
+func newAuthenticator(cfg AuthConfig) (*authenticator, error) {
+	a := &authenticator{
+		cfg:           cfg,
+		rsaKeys:       map[string]*rsa.PublicKey{},
+		hmacKeys:      map[string][]byte{},
+		apiKeys:       map[[sha256.Size]byte]apiKey{},
+		publicMethods: append(defaultPublicMethods, cfg.PublicMethods...),
+	}
+
+	if cfg.JwksFile != "" {
+		if err := a.loadJwks(cfg.JwksFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ApiKeysFile != "" {
+		if err := a.loadApiKeys(cfg.ApiKeysFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+func (a *authenticator) loadJwks(file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("can't read JWKS file : %v", err)
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(content, &jwks); err != nil {
+		return fmt.Errorf("can't parse JWKS file : %v", err)
+	}
+
+	for _, k := range jwks.Keys {
+		switch k.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return fmt.Errorf("invalid modulus for key %v : %v", k.Kid, err)
+			}
+
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return fmt.Errorf("invalid exponent for key %v : %v", k.Kid, err)
+			}
+
+			a.rsaKeys[k.Kid] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return fmt.Errorf("invalid secret for key %v : %v", k.Kid, err)
+			}
+
+			a.hmacKeys[k.Kid] = secret
+		}
+	}
+
+	return nil
+}
+
+func (a *authenticator) loadApiKeys(file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("can't read API keys file : %v", err)
+	}
+
+	var keys struct {
+		Keys []apiKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(content, &keys); err != nil {
+		return fmt.Errorf("can't parse API keys file : %v", err)
+	}
+
+	for _, k := range keys.Keys {
+		a.apiKeys[sha256.Sum256([]byte(k.Key))] = k
+	}
+
+	return nil
+}
+
+// jwtKey picks the verification key for the token : by kid when the token has
+// one, otherwise the only key of the signing algorithm family
+func (a *authenticator) jwtKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA:
+		if k, ok := a.rsaKeys[kid]; ok {
+			return k, nil
+		}
+
+		if kid == "" && len(a.rsaKeys) == 1 {
+			for _, k := range a.rsaKeys {
+				return k, nil
+			}
+		}
+	case *jwt.SigningMethodHMAC:
+		if k, ok := a.hmacKeys[kid]; ok {
+			return k, nil
+		}
+
+		if kid == "" && len(a.hmacKeys) == 1 {
+			for _, k := range a.hmacKeys {
+				return k, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no key found for kid %q", kid)
+}
+
+func (a *authenticator) authenticateJwt(raw string) (auth.Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+
+	if a.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.cfg.Issuer))
+	}
+
+	if a.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(a.cfg.Audience))
+	}
+
+	var claims jwtClaims
+
+	if _, err := jwt.ParseWithClaims(raw, &claims, a.jwtKey, opts...); err != nil {
+		return auth.Principal{}, fmt.Errorf("%w : %v", auth.ErrInvalidCredentials, err)
+	}
+
+	if claims.Subject == "" {
+		return auth.Principal{}, fmt.Errorf("%w : token has no subject", auth.ErrInvalidCredentials)
+	}
+
+	return auth.Principal{
+		Subject: claims.Subject,
+		Method:  auth.MethodJwt,
+		Roles:   claims.Roles,
+	}, nil
+}
+
+func (a *authenticator) authenticateApiKey(raw string) (auth.Principal, error) {
+	k, ok := a.apiKeys[sha256.Sum256([]byte(raw))]
+	if !ok {
+		return auth.Principal{}, fmt.Errorf("%w : unknown API key", auth.ErrInvalidCredentials)
+	}
+
+	return auth.Principal{
+		Subject: k.Subject,
+		Method:  auth.MethodApiKey,
+		Roles:   k.Roles,
+	}, nil
+}
+
+func (a *authenticator) authenticateClientCert(id ClientIdentity) (auth.Principal, error) {
+	if id.CommonName == "" {
+		return auth.Principal{}, fmt.Errorf("%w : client certificate has no common name", auth.ErrInvalidCredentials)
+	}
+
+	return auth.Principal{
+		Subject: id.CommonName,
+		Method:  auth.MethodClientCert,
+	}, nil
+}
+
+func (a *authenticator) isPublic(method string) bool {
+	for _, m := range a.publicMethods {
+		if strings.HasPrefix(method, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authenticate returns a context carrying the principal of the caller
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if a.isPublic(method) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var principal auth.Principal
+	err := auth.ErrMissingCredentials
+
+	if values := md.Get(authorizationHeader); len(values) > 0 {
+		if !strings.HasPrefix(strings.ToLower(values[0]), bearerPrefix) {
+			err = fmt.Errorf("%w : authorization header must be a bearer token", auth.ErrInvalidCredentials)
+		} else {
+			principal, err = a.authenticateJwt(strings.TrimSpace(values[0][len(bearerPrefix):]))
+		}
+	} else if values := md.Get(apiKeyHeader); len(values) > 0 {
+		principal, err = a.authenticateApiKey(values[0])
+	} else if id, ok := ClientIdentityFromContext(ctx); ok {
+		principal, err = a.authenticateClientCert(id)
+	}
+
+	if err != nil {
+		if errors.Is(err, auth.ErrMissingCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "credentials are required")
+		}
+
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *authenticator) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextServerStream replaces the context of a stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize checks that the caller may act on the account. Without
+// authentication configured there is no principal, calls are only allowed
+// when authentication was explicitly disabled.
+func (a *GrpcAdapter) authorize(ctx context.Context, action string, acct string) error {
+	if a.authConfig == nil {
+		if a.authDisabled {
+			return nil
+		}
+
+		return status.Error(codes.Unauthenticated, "authentication is not configured")
+	}
+
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "credentials are required")
+	}
+
+	return toGrpcStatus(a.bankService.Authorize(originFromContext(ctx), p, action, acct))
+}
@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizeWithoutAuthConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []GrpcAdapterOption
+		wantCode codes.Code
+	}{
+		{"not configured", nil, codes.Unauthenticated},
+		{"explicitly disabled", []GrpcAdapterOption{WithoutAuth()}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewGrpcAdapter(nil, 0, tt.opts...)
+
+			err := a.authorize(context.Background(), auth.ActionTransfer, "7835697001xxxx")
+
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("authorize code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func writeAuthFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func signHs256(t *testing.T, secret []byte, kid string, claims jwtClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	raw, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return raw
+}
+
+func TestAuthenticate(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	jwks := `{"keys":[{"kty":"oct","kid":"k1","alg":"HS256","k":"` +
+		base64.RawURLEncoding.EncodeToString(secret) + `"}]}`
+	apiKeys := `{"keys":[{"subject":"batch-job","key":"s3cr3t","roles":["TELLER"]}]}`
+
+	a, err := newAuthenticator(AuthConfig{
+		JwksFile:    writeAuthFile(t, "jwks.json", jwks),
+		ApiKeysFile: writeAuthFile(t, "api_keys.json", apiKeys),
+		Issuer:      "my-grpc-bank",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := func(sub string, iss string, exp time.Duration) jwtClaims {
+		return jwtClaims{
+			Roles: []string{auth.RoleCustomer},
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   sub,
+				Issuer:    iss,
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(exp)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		md          metadata.MD
+		wantCode    codes.Code
+		wantSubject string
+		wantMethod  string
+	}{
+		{
+			name:        "valid jwt",
+			md:          metadata.Pairs(authorizationHeader, "Bearer "+signHs256(t, secret, "k1", claims("alice", "my-grpc-bank", time.Hour))),
+			wantSubject: "alice",
+			wantMethod:  auth.MethodJwt,
+		},
+		{
+			name:        "valid jwt without kid",
+			md:          metadata.Pairs(authorizationHeader, "bearer "+signHs256(t, secret, "", claims("alice", "my-grpc-bank", time.Hour))),
+			wantSubject: "alice",
+			wantMethod:  auth.MethodJwt,
+		},
+		{
+			name:     "expired jwt",
+			md:       metadata.Pairs(authorizationHeader, "Bearer "+signHs256(t, secret, "k1", claims("alice", "my-grpc-bank", -time.Hour))),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "jwt of another issuer",
+			md:       metadata.Pairs(authorizationHeader, "Bearer "+signHs256(t, secret, "k1", claims("alice", "elsewhere", time.Hour))),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "jwt signed with another key",
+			md:       metadata.Pairs(authorizationHeader, "Bearer "+signHs256(t, []byte("another secret of 32 bytes......"), "k1", claims("alice", "my-grpc-bank", time.Hour))),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "jwt without subject",
+			md:       metadata.Pairs(authorizationHeader, "Bearer "+signHs256(t, secret, "k1", claims("", "my-grpc-bank", time.Hour))),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "authorization not a bearer token",
+			md:       metadata.Pairs(authorizationHeader, "Basic YWxpY2U6cHc="),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:        "valid api key",
+			md:          metadata.Pairs(apiKeyHeader, "s3cr3t"),
+			wantSubject: "batch-job",
+			wantMethod:  auth.MethodApiKey,
+		},
+		{
+			name:     "unknown api key",
+			md:       metadata.Pairs(apiKeyHeader, "guess"),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "no credentials",
+			md:       metadata.MD{},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:   "public method without credentials",
+			method: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+			md:     metadata.MD{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = "/bank.BankService/GetCurrentBalance"
+			}
+
+			ctx, err := a.authenticate(metadata.NewIncomingContext(context.Background(), tt.md), method)
+
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("authenticate code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			p, ok := auth.FromContext(ctx)
+
+			if tt.wantSubject == "" {
+				if ok {
+					t.Errorf("got principal %v, want none", p.Subject)
+				}
+				return
+			}
+
+			if !ok || p.Subject != tt.wantSubject || p.Method != tt.wantMethod {
+				t.Errorf("principal = %+v, want subject %v by %v", p, tt.wantSubject, tt.wantMethod)
+			}
+		})
+	}
+}
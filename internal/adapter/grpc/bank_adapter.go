@@ -2,33 +2,40 @@ package grpc
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"io"
 	"log"
 	"time"
 
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
 	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
-	bank_proto "github.com/abhilashdk2016/my-grpc-proto/protogen/go/bank-proto"
-	"github.com/google/uuid"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/genproto/googleapis/type/datetime"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 func (a *GrpcAdapter) GetCurrentBalance(ctx context.Context, req *bank_proto.CurrentBalanceRequest) (*bank_proto.CurrentBalanceResponse, error) {
+	if err := a.authorize(ctx, auth.ActionReadAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
-	bal, err := a.bankService.FindCurrentBalance(req.AccountNumber)
+	bal, err := a.bankService.FindAccountBalance(req.AccountNumber)
 	if err != nil {
-		return nil, status.Errorf(
-			codes.FailedPrecondition,
-			"account %v not found", req.AccountNumber,
-		)
+		return nil, toGrpcStatus(err)
 	}
 	return &bank_proto.CurrentBalanceResponse{
-		Amount: bal,
+		Amount:           bal.LedgerBalance,
+		LedgerBalance:    bal.LedgerBalance,
+		AvailableBalance: bal.AvailableBalance,
+		OverdraftLimit:   bal.Overdraft.Limit,
+		HeldAmount:       bal.HeldAmount,
+		ProductCode:      bal.ProductCode,
+		ProductType:      bal.ProductType,
+		MinBalance:       bal.MinBalance,
 		CurrentDate: &date.Date{
 			Year:  int32(now.Year()),
 			Month: int32(now.Month()),
@@ -47,20 +54,10 @@ func (a *GrpcAdapter) FetchExchangeRates(req *bank_proto.ExchangeRateRequest, st
 			return nil
 		default:
 			now := time.Now().Truncate(time.Second)
-			rate, err := a.bankService.FindExchangeRate(req.FromCurrency, req.FromCurrency, now)
+			rate, err := a.bankService.FindExchangeRate(req.FromCurrency, req.ToCurrency, now)
 
 			if err != nil {
-				s := status.New(codes.InvalidArgument, "Currency not valid. Please use valid currency for both to and from")
-				s, _ = s.WithDetails(&errdetails.ErrorInfo{
-					Domain: "my-grpc-bank-server",
-					Reason: "INVALID_CURRENCY",
-					Metadata: map[string]string{
-						"from_currency": req.FromCurrency,
-						"to_currency":   req.ToCurrency,
-					},
-				})
-
-				return s.Err()
+				return toGrpcStatus(err)
 			}
 
 			stream.Send(
@@ -79,66 +76,100 @@ func (a *GrpcAdapter) FetchExchangeRates(req *bank_proto.ExchangeRateRequest, st
 	}
 }
 
+// toTime reads a client date time. A date time without an offset or time
+// zone is taken as UTC.
 func toTime(dt *datetime.DateTime) (time.Time, error) {
 	if dt == nil {
-		now := time.Now()
-
-		dt = &datetime.DateTime{
-			Year:    int32(now.Year()),
-			Month:   int32(now.Month()),
-			Day:     int32(now.Day()),
-			Hours:   int32(now.Hour()),
-			Minutes: int32(now.Minute()),
-			Seconds: int32(now.Second()),
-			Nanos:   int32(now.Nanosecond()),
+		return time.Now(), nil
+	}
+
+	loc := time.UTC
+
+	if offset := dt.GetUtcOffset(); offset != nil {
+		loc = time.FixedZone("", int(offset.AsDuration().Seconds()))
+	} else if tz := dt.GetTimeZone(); tz != nil && tz.Id != "" {
+		l, err := time.LoadLocation(tz.Id)
+
+		if err != nil {
+			return time.Time{}, err
 		}
+
+		loc = l
 	}
 
 	res := time.Date(int(dt.Year), time.Month(dt.Month), int(dt.Day),
-		int(dt.Hours), int(dt.Minutes), int(dt.Seconds), int(dt.Nanos), time.UTC)
+		int(dt.Hours), int(dt.Minutes), int(dt.Seconds), int(dt.Nanos), loc)
 
 	return res, nil
 }
 
-func (a *GrpcAdapter) SummarizeTransactions(stream bank_proto.BankService_SummarizeTransactionsServer) error {
-	tsum := bank.TransactionSummary{
-		SummaryOnDate: time.Now(),
-		SumIn:         0,
-		SumOut:        0,
-		SumTotal:      0,
+func toProtoTransactionSummary(s bank.TransactionSummary) *bank_proto.TransactionSummary {
+	res := &bank_proto.TransactionSummary{
+		AccountNumber:    s.AccountNumber,
+		SumAmountIn:      s.SumIn,
+		SumAmountOut:     s.SumOut,
+		SumTotal:         s.SumTotal,
+		TransactionCount: s.Count,
 	}
 
-	acct := ""
+	if !s.SummaryOnDate.IsZero() {
+		res.TransactionDate = &datetime.DateTime{
+			Year:  int32(s.SummaryOnDate.Year()),
+			Month: int32(s.SummaryOnDate.Month()),
+			Day:   int32(s.SummaryOnDate.Day()),
+		}
+	}
+
+	return res
+}
+
+// SummarizeTransactions records a stream of transactions and returns their
+// summary. Transactions are dated with their timestamp when the client sends
+// one. A stream that mixes accounts gets a summary per account as well.
+func (a *GrpcAdapter) SummarizeTransactions(stream bank_proto.BankService_SummarizeTransactionsServer) error {
+	tsum := bank.TransactionSummary{}
+	accounts := map[string]*bank.TransactionSummary{}
 
 	for {
 		req, err := stream.Recv()
 
 		if err == io.EOF {
-			res := bank_proto.TransactionSummary{
-				AccountNumber: acct,
-				SumAmountIn:   tsum.SumIn,
-				SumAmountOut:  tsum.SumOut,
-				SumTotal:      tsum.SumTotal,
-				TransactionDate: &datetime.DateTime{
-					Year:  int32(tsum.SummaryOnDate.Year()),
-					Month: int32(tsum.SummaryOnDate.Month()),
-					Day:   int32(tsum.SummaryOnDate.Day()),
-				},
+			if len(accounts) == 1 {
+				for _, s := range accounts {
+					tsum.AccountNumber = s.AccountNumber
+				}
 			}
 
-			return stream.SendAndClose(&res)
+			res := toProtoTransactionSummary(tsum)
+
+			if len(accounts) > 1 {
+				res.AccountSummaries = make(map[string]*bank_proto.TransactionSummary, len(accounts))
+
+				for acct, s := range accounts {
+					res.AccountSummaries[acct] = toProtoTransactionSummary(*s)
+				}
+			}
+
+			return stream.SendAndClose(res)
 		}
 
 		if err != nil {
-			log.Fatalln("Error while reading from client :", err)
+			log.Println("Error while reading from client :", err)
+			return err
 		}
 
-		acct = req.AccountNumber
+		if err := a.authorize(stream.Context(), auth.ActionCreateTransaction, req.AccountNumber); err != nil {
+			return err
+		}
 
-		ts, err := toTime(req.Timestamp)
+		var ts time.Time
 
-		if err != nil {
-			log.Fatalf("Error while parsing timestamp %v : %v", req.Timestamp, err)
+		if req.Timestamp != nil {
+			ts, err = toTime(req.Timestamp)
+
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "timestamp is not valid : %v", err)
+			}
 		}
 
 		ttype := bank.TransactionTypeUnknown
@@ -153,36 +184,30 @@ func (a *GrpcAdapter) SummarizeTransactions(stream bank_proto.BankService_Summar
 			Amount:          req.Amount,
 			Timestamp:       ts,
 			TransactionType: ttype,
+			Notes:           req.Notes,
+			Metadata:        toTransactionMetadata(req.Metadata),
+			Origin:          originFromContext(stream.Context()),
 		}
 
-		accountuuid, err := a.bankService.CreateTransaction(req.AccountNumber, tcur)
-
-		if err != nil && accountuuid == uuid.Nil {
-			s := status.New(codes.InvalidArgument, err.Error())
-			s, _ = s.WithDetails(&errdetails.BadRequest{
-				FieldViolations: []*errdetails.BadRequest_FieldViolation{
-					{
-						Field:       "account_number",
-						Description: "Invalid Account Number",
-					},
-				},
-			})
-			return s.Err()
-		} else if err != nil && accountuuid != uuid.Nil {
-			s := status.New(codes.InvalidArgument, err.Error())
-			s, _ = s.WithDetails(&errdetails.BadRequest{
-				FieldViolations: []*errdetails.BadRequest_FieldViolation{
-					{
-						Field:       "amount",
-						Description: fmt.Sprintf("Requested amount %v exceeds available banalnce", req.Amount),
-					},
-				},
-			})
-			return s.Err()
-		}
+		_, err = a.bankService.CreateTransaction(req.AccountNumber, tcur)
 
 		if err != nil {
 			log.Println("Error while creating transaction :", err)
+			return toGrpcStatus(err)
+		}
+
+		if tcur.Timestamp.IsZero() {
+			tcur.Timestamp = time.Now()
+		}
+
+		acctSum, ok := accounts[req.AccountNumber]
+		if !ok {
+			acctSum = &bank.TransactionSummary{AccountNumber: req.AccountNumber}
+			accounts[req.AccountNumber] = acctSum
+		}
+
+		if err := a.bankService.CalculateTransactionSummary(acctSum, tcur); err != nil {
+			return err
 		}
 
 		err = a.bankService.CalculateTransactionSummary(&tsum, tcur)
@@ -206,115 +231,244 @@ func currentTime() *datetime.DateTime {
 	}
 }
 
-func (a *GrpcAdapter) TransferMultiple(stream bank_proto.BankService_TransferMultipleServer) error {
-	context := stream.Context()
-	for {
-		select {
-		case <-context.Done():
-			log.Println("Client cancelled stream")
-			return nil
-		default:
-			req, err := stream.Recv()
+// transferErrorModeHeader selects how TransferMultiple handles a failed
+// transfer. By default the first failure ends the stream with its status.
+// With transferErrorModeContinue the failure is reported in the response to
+// that message and the stream goes on with the next one.
+const transferErrorModeHeader = "x-transfer-error-mode"
 
-			if err == io.EOF {
-				return nil
-			}
+const transferErrorModeContinue = "continue"
 
-			if err != nil {
-				log.Fatalln("Error while reading from client :", err)
-			}
+func continueOnTransferError(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
 
-			tt := bank.TrasferTransaction{
-				FromAccountNumber: req.FromAccountNumber,
-				ToAccountNumber:   req.ToAccountNumber,
-				Currency:          req.Currency,
-				Amount:            req.Amount,
-			}
+	values := md.Get(transferErrorModeHeader)
 
-			_, transferSuccess, err := a.bankService.Transfer(tt)
+	return len(values) > 0 && values[0] == transferErrorModeContinue
+}
 
-			if err != nil {
-				return buildTransferErrorStatusGrpc(err, *req)
-			}
+func (a *GrpcAdapter) transfer(ctx context.Context, req *bank_proto.TransferRequest) (*bank_proto.TransferResponse, error) {
+	if err := a.authorize(ctx, auth.ActionTransfer, req.FromAccountNumber); err != nil {
+		return nil, err
+	}
 
-			res := bank_proto.TransferResponse{
-				FromAccountNumber: req.FromAccountNumber,
-				ToAccountNumber:   req.ToAccountNumber,
-				Currency:          req.Currency,
-				Amount:            req.Amount,
-				Timestamp:         currentTime(),
-			}
+	tt := bank.TrasferTransaction{
+		FromAccountNumber: req.FromAccountNumber,
+		ToAccountNumber:   req.ToAccountNumber,
+		Currency:          req.Currency,
+		Amount:            req.Amount,
+		Origin:            originFromContext(ctx),
+	}
 
-			if transferSuccess {
-				res.Status = bank_proto.TransferStatus_TRANSFER_STATUS_SUCCESS
-			} else {
-				res.Status = bank_proto.TransferStatus_TRANSFER_STATUS_FAIL
-			}
+	transferUuid, transferSuccess, err := a.bankService.Transfer(tt)
 
-			err = stream.Send(&res)
-			if err != nil {
-				log.Fatalln("Error while sending response to client :", err)
-			}
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	res := &bank_proto.TransferResponse{
+		FromAccountNumber: req.FromAccountNumber,
+		ToAccountNumber:   req.ToAccountNumber,
+		Currency:          req.Currency,
+		Amount:            req.Amount,
+		Timestamp:         currentTime(),
+		TransferUuid:      transferUuid.String(),
+		CorrelationId:     req.CorrelationId,
+	}
+
+	if transferSuccess {
+		res.Status = bank_proto.TransferStatus_TRANSFER_STATUS_SUCCESS
+
+		// the transfer is committed with its fees, a lookup failure must not
+		// report it as failed
+		if fees, err := a.bankService.GetTransferFees(transferUuid); err != nil {
+			log.Printf("Can't get fees of transfer %v : %v\n", transferUuid, err)
+		} else {
+			res.Fees, res.FeeTotal = toProtoTransferFees(fees)
+		}
+	} else {
+		res.Status = bank_proto.TransferStatus_TRANSFER_STATUS_FAIL
+	}
+
+	return res, nil
+}
+
+// toProtoTransferError carries the status a failed transfer would have ended
+// the stream with
+func toProtoTransferError(err error) *bank_proto.TransferError {
+	s := status.Convert(toGrpcStatus(err))
+
+	res := &bank_proto.TransferError{
+		Code:    s.Code().String(),
+		Message: s.Message(),
+	}
+
+	for _, d := range s.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			res.Reason = info.Reason
+			res.Metadata = info.Metadata
+			break
 		}
 	}
+
+	return res
 }
 
-func buildTransferErrorStatusGrpc(err error, req bank_proto.TransferRequest) error {
-	switch {
-	case errors.Is(err, bank.ErrTransferSourceAccountNotFound):
-		s := status.New(codes.FailedPrecondition, err.Error())
-		s, _ = s.WithDetails(&errdetails.PreconditionFailure{
-			Violations: []*errdetails.PreconditionFailure_Violation{
-				{
-					Type:        "INVALID_ACCOUNT",
-					Subject:     "Source account not found",
-					Description: fmt.Sprintf("source account (from %v) not found", req.FromAccountNumber),
-				},
-			},
-		})
+func failedTransferResponse(req *bank_proto.TransferRequest, err error) *bank_proto.TransferResponse {
+	return &bank_proto.TransferResponse{
+		FromAccountNumber: req.FromAccountNumber,
+		ToAccountNumber:   req.ToAccountNumber,
+		Currency:          req.Currency,
+		Amount:            req.Amount,
+		Status:            bank_proto.TransferStatus_TRANSFER_STATUS_FAIL,
+		Timestamp:         currentTime(),
+		CorrelationId:     req.CorrelationId,
+		Error:             toProtoTransferError(err),
+	}
+}
 
-		return s.Err()
-	case errors.Is(err, bank.ErrTransferDestinationAccountNotFound):
-		s := status.New(codes.FailedPrecondition, err.Error())
-		s, _ = s.WithDetails(&errdetails.PreconditionFailure{
-			Violations: []*errdetails.PreconditionFailure_Violation{
-				{
-					Type:        "INVALID_ACCOUNT",
-					Subject:     "Destination account not found",
-					Description: fmt.Sprintf("destination account (to %v) not found", req.ToAccountNumber),
-				},
-			},
-		})
+func dateToTime(d *date.Date) time.Time {
+	return time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, time.UTC)
+}
 
-		return s.Err()
-	case errors.Is(err, bank.ErrTransferRecordFailed):
-		s := status.New(codes.Internal, err.Error())
-		s, _ = s.WithDetails(&errdetails.Help{
-			Links: []*errdetails.Help_Link{
-				{
-					Url:         "my-grpc-bank.com/faq",
-					Description: "Bank FAQ",
-				},
-			},
-		})
+func timeToDateTime(t time.Time) *datetime.DateTime {
+	t = t.UTC()
+	return &datetime.DateTime{
+		Year:       int32(t.Year()),
+		Month:      int32(t.Month()),
+		Day:        int32(t.Day()),
+		Hours:      int32(t.Hour()),
+		Minutes:    int32(t.Minute()),
+		Seconds:    int32(t.Second()),
+		Nanos:      int32(t.Nanosecond()),
+		TimeOffset: &datetime.DateTime_UtcOffset{},
+	}
+}
+
+func toProtoTransactionType(ttype string) bank_proto.TransactionType {
+	switch ttype {
+	case bank.TransactionTypeIn:
+		return bank_proto.TransactionType_TRANSACION_TYPE_IN
+	case bank.TransactionTypeOut:
+		return bank_proto.TransactionType_TRANSACION_TYPE_OUT
+	default:
+		return bank_proto.TransactionType_TRANSACION_TYPE_UNSPECIFIED
+	}
+}
+
+func toStatementFormat(f bank_proto.StatementFormat) string {
+	switch f {
+	case bank_proto.StatementFormat_STATEMENT_FORMAT_JSON:
+		return bank.StatementFormatJson
+	case bank_proto.StatementFormat_STATEMENT_FORMAT_TEXT:
+		return bank.StatementFormatText
+	default:
+		return bank.StatementFormatCsv
+	}
+}
+
+func (a *GrpcAdapter) GenerateStatement(ctx context.Context, req *bank_proto.StatementRequest) (*bank_proto.StatementResponse, error) {
+	if err := a.authorize(ctx, auth.ActionReadAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	from := dateToTime(req.FromDate)
+	// to_date is inclusive, the statement covers the whole of that day
+	to := dateToTime(req.ToDate).AddDate(0, 0, 1)
 
-		return s.Err()
-	case errors.Is(err, bank.ErrTransferTransactionPair):
-		s := status.New(codes.InvalidArgument, err.Error())
-		s, _ = s.WithDetails(&errdetails.ErrorInfo{
-			Domain: "my-grpc-bank.com",
-			Reason: "TRANSACTION_PAIR_FAILED",
-			Metadata: map[string]string{
-				"from_account": req.FromAccountNumber,
-				"to_account":   req.ToAccountNumber,
-				"currency":     req.Currency,
-				"amount":       fmt.Sprintf("%f", req.Amount),
-			},
+	st, err := a.bankService.GenerateStatement(req.AccountNumber, from, to)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	format := req.Format
+	if format == bank_proto.StatementFormat_STATEMENT_FORMAT_UNSPECIFIED {
+		format = bank_proto.StatementFormat_STATEMENT_FORMAT_CSV
+	}
+
+	content, err := a.bankService.ExportStatement(st, toStatementFormat(format))
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	res := &bank_proto.StatementResponse{
+		AccountNumber:  st.AccountNumber,
+		AccountName:    st.AccountName,
+		Currency:       st.Currency,
+		FromDate:       req.FromDate,
+		ToDate:         req.ToDate,
+		OpeningBalance: st.OpeningBalance,
+		ClosingBalance: st.ClosingBalance,
+		Lines:          make([]*bank_proto.StatementLine, 0, len(st.Lines)),
+		Format:         format,
+		Content:        content,
+	}
+
+	for _, l := range st.Lines {
+		res.Lines = append(res.Lines, &bank_proto.StatementLine{
+			TransactionUuid: l.TransactionUuid.String(),
+			Timestamp:       timeToDateTime(l.Timestamp),
+			Type:            toProtoTransactionType(l.TransactionType),
+			Amount:          l.Amount,
+			RunningBalance:  l.RunningBalance,
+			Notes:           l.Notes,
 		})
+	}
 
-		return s.Err()
+	return res, nil
+}
+
+func toSummaryPeriod(p bank_proto.SummaryPeriod) string {
+	switch p {
+	case bank_proto.SummaryPeriod_SUMMARY_PERIOD_WEEK:
+		return bank.SummaryPeriodWeek
+	case bank_proto.SummaryPeriod_SUMMARY_PERIOD_MONTH:
+		return bank.SummaryPeriodMonth
 	default:
-		s := status.New(codes.Unknown, err.Error())
-		return s.Err()
+		return bank.SummaryPeriodDay
 	}
 }
+
+func (a *GrpcAdapter) GetTransactionSummaries(ctx context.Context, req *bank_proto.TransactionSummaryRequest) (*bank_proto.TransactionSummaryReport, error) {
+	if err := a.authorize(ctx, auth.ActionReadAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	period := req.Period
+	if period == bank_proto.SummaryPeriod_SUMMARY_PERIOD_UNSPECIFIED {
+		period = bank_proto.SummaryPeriod_SUMMARY_PERIOD_DAY
+	}
+
+	from := dateToTime(req.FromDate)
+	to := dateToTime(req.ToDate).AddDate(0, 0, 1)
+
+	summaries, err := a.bankService.SummarizeTransactionsByPeriod(req.AccountNumber, toSummaryPeriod(period), from, to)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	res := &bank_proto.TransactionSummaryReport{
+		AccountNumber: req.AccountNumber,
+		Period:        period,
+		Summaries:     make([]*bank_proto.TransactionSummary, 0, len(summaries)),
+	}
+
+	for _, s := range summaries {
+		res.Summaries = append(res.Summaries, &bank_proto.TransactionSummary{
+			AccountNumber:    req.AccountNumber,
+			SumAmountIn:      s.SumIn,
+			SumAmountOut:     s.SumOut,
+			SumTotal:         s.SumTotal,
+			TransactionDate:  timeToDateTime(s.SummaryOnDate),
+			TransactionCount: s.Count,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const errorDomain = "my-grpc-bank.com"
+
+// sentinelError maps a domain sentinel that is not carried by a typed error
+// to its status code and reason
+type sentinelError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+var sentinelErrors = []sentinelError{
+	{bank.ErrCurrencyNotSupported, codes.FailedPrecondition, bank.ReasonCurrencyNotSupported},
+	{bank.ErrCurrencyDisabled, codes.FailedPrecondition, bank.ReasonCurrencyDisabled},
+	{bank.ErrCurrencyMismatch, codes.FailedPrecondition, bank.ReasonCurrencyMismatch},
+	{bank.ErrAmountBelowMinorUnit, codes.FailedPrecondition, bank.ReasonAmountBelowMinorUnit},
+	{bank.ErrStatementInvalidPeriod, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrStatementUnknownFormat, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrSummaryUnknownPeriod, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrHoldInvalidExpiry, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrHoldCaptureExceedsAmount, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrScheduleUnknownFrequency, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrScheduleInvalidPeriod, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransferInvalidPageToken, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransactionTooOld, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransactionInFuture, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransactionMetadataInvalid, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransactionInvalidPageToken, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransferBatchUnknownFormat, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransferBatchUnknownMode, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransferBatchMalformed, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransferBatchEmpty, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransferBatchTooLarge, codes.InvalidArgument, bank.ReasonInvalidArgument},
+	{bank.ErrTransferNotReversible, codes.FailedPrecondition, bank.ReasonTransferNotReversible},
+	{bank.ErrReversalExceedsRemaining, codes.FailedPrecondition, bank.ReasonReversalTooLarge},
+	{bank.ErrFeeRevenueAccountNotFound, codes.FailedPrecondition, bank.ReasonFeeNotConfigured},
+	{bank.ErrProductCurrencyNotAllowed, codes.FailedPrecondition, bank.ReasonProductCurrency},
+	{bank.ErrProductMinBalance, codes.FailedPrecondition, bank.ReasonProductMinBalance},
+	{bank.ErrProductTransfersOutNotAllowed, codes.FailedPrecondition, bank.ReasonProductTransfersOut},
+}
+
+func newStatus(code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	s := status.New(code, msg)
+
+	if withDetails, err := s.WithDetails(details...); err == nil {
+		s = withDetails
+	}
+
+	return s.Err()
+}
+
+func errorInfo(reason string, metadata map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Domain:   errorDomain,
+		Reason:   reason,
+		Metadata: metadata,
+	}
+}
+
+// toGrpcStatus is the single place where errors returned by the bank service
+// are translated to gRPC status codes and details
+func toGrpcStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var notFound *bank.NotFoundError
+	var insufficientFunds *bank.InsufficientFundsError
+	var conflict *bank.ConflictError
+	var unavailable *bank.UnavailableError
+	var permissionDenied *bank.PermissionDeniedError
+	var transferLimit *bank.TransferLimitError
+	var transferFailed *bank.TransferFailedError
+
+	switch {
+	case errors.As(err, &notFound):
+		return newStatus(codes.NotFound, notFound.Error(),
+			errorInfo(notFound.Reason, map[string]string{
+				"resource": notFound.Resource,
+				"key":      notFound.Key,
+			}),
+		)
+	case errors.As(err, &insufficientFunds):
+		return newStatus(codes.FailedPrecondition, insufficientFunds.Error(),
+			errorInfo(bank.ReasonInsufficientFunds, map[string]string{
+				"account_number": insufficientFunds.AccountNumber,
+				"available":      fmt.Sprintf("%v", insufficientFunds.Available),
+				"requested":      fmt.Sprintf("%v", insufficientFunds.Requested),
+			}),
+			&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{
+					{
+						Type:        bank.ReasonInsufficientFunds,
+						Subject:     insufficientFunds.AccountNumber,
+						Description: insufficientFunds.Error(),
+					},
+				},
+			},
+		)
+	case errors.As(err, &transferLimit):
+		return newStatus(codes.FailedPrecondition, transferLimit.Error(),
+			errorInfo(bank.ReasonTransferLimitExceeded, map[string]string{
+				"account_number": transferLimit.AccountNumber,
+				"limit":          transferLimit.Limit,
+				"rule":           transferLimit.Rule,
+				"maximum":        fmt.Sprintf("%v", transferLimit.Maximum),
+				"current":        fmt.Sprintf("%v", transferLimit.Current),
+				"requested":      fmt.Sprintf("%v", transferLimit.Requested),
+			}),
+			&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{
+					{
+						Type:        transferLimit.Rule,
+						Subject:     transferLimit.AccountNumber,
+						Description: transferLimit.Error(),
+					},
+				},
+			},
+		)
+	case errors.As(err, &permissionDenied):
+		return newStatus(codes.PermissionDenied, permissionDenied.Error(),
+			errorInfo(bank.ReasonPermissionDenied, map[string]string{
+				"subject":        permissionDenied.Subject,
+				"action":         permissionDenied.Action,
+				"account_number": permissionDenied.AccountNumber,
+			}),
+		)
+	case errors.As(err, &conflict):
+		return newStatus(codes.Aborted, conflict.Error(),
+			errorInfo(conflict.Reason, map[string]string{
+				"resource": conflict.Resource,
+				"key":      conflict.Key,
+			}),
+		)
+	case errors.As(err, &transferFailed):
+		// checked before unavailable, the transfer is recorded as failed and
+		// retrying the request would start another one
+		return newStatus(codes.Internal, transferFailed.Error(),
+			errorInfo(bank.ReasonTransferFailed, map[string]string{
+				"transfer_uuid": transferFailed.TransferUuid.String(),
+			}),
+		)
+	case errors.As(err, &unavailable):
+		return newStatus(codes.Unavailable, unavailable.Error(),
+			errorInfo(bank.ReasonStorageUnavailable, map[string]string{
+				"operation": unavailable.Operation,
+			}),
+			&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(unavailable.RetryAfter),
+			},
+		)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	for _, se := range sentinelErrors {
+		if !errors.Is(err, se.err) {
+			continue
+		}
+
+		if se.code == codes.FailedPrecondition {
+			return newStatus(se.code, err.Error(),
+				errorInfo(se.reason, nil),
+				&errdetails.PreconditionFailure{
+					Violations: []*errdetails.PreconditionFailure_Violation{
+						{
+							Type:        se.reason,
+							Description: err.Error(),
+						},
+					},
+				},
+			)
+		}
+
+		return newStatus(se.code, err.Error(), errorInfo(se.reason, nil))
+	}
+
+	return newStatus(codes.Internal, err.Error(), errorInfo(bank.ReasonInternal, nil))
+}
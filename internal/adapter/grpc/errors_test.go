@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToGrpcStatus(t *testing.T) {
+	transferUuid := uuid.New()
+
+	tests := []struct {
+		name      string
+		err       error
+		code      codes.Code
+		reason    string
+		retryInfo bool
+	}{
+		{"account not found", bank.NewAccountNotFoundError("7835697001", bank.ErrAccountNotFound), codes.NotFound, bank.ReasonAccountNotFound, false},
+		{"insufficient funds", &bank.InsufficientFundsError{AccountNumber: "7835697001", Available: 1, Requested: 2}, codes.FailedPrecondition, bank.ReasonInsufficientFunds, false},
+		{"transfer limit", &bank.TransferLimitError{Limit: "default USD", Rule: bank.TransferRuleMaxAmountPerTransfer}, codes.FailedPrecondition, bank.ReasonTransferLimitExceeded, false},
+		{"conflict", &bank.ConflictError{Reason: bank.ReasonConflict}, codes.Aborted, bank.ReasonConflict, false},
+		{"unavailable", bank.NewUnavailableError("account lookup", errors.New("connection refused")), codes.Unavailable, bank.ReasonStorageUnavailable, true},
+		{"failed transfer is not retryable", &bank.TransferFailedError{TransferUuid: transferUuid, Err: bank.NewUnavailableError("transfer", errors.New("deadlock"))}, codes.Internal, bank.ReasonTransferFailed, false},
+		{"permission denied", &bank.PermissionDeniedError{Subject: "alice", Action: "TRANSFER"}, codes.PermissionDenied, bank.ReasonPermissionDenied, false},
+		{"wrapped sentinel", fmt.Errorf("account currency EUR : %w", bank.ErrCurrencyMismatch), codes.FailedPrecondition, bank.ReasonCurrencyMismatch, false},
+		{"invalid argument sentinel", bank.ErrStatementInvalidPeriod, codes.InvalidArgument, bank.ReasonInvalidArgument, false},
+		{"unknown error", errors.New("boom"), codes.Internal, bank.ReasonInternal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := status.Convert(toGrpcStatus(tt.err))
+
+			if s.Code() != tt.code {
+				t.Fatalf("code = %v, want %v", s.Code(), tt.code)
+			}
+
+			var reason string
+			var retryInfo bool
+
+			for _, d := range s.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					reason = d.Reason
+				case *errdetails.RetryInfo:
+					retryInfo = true
+				}
+			}
+
+			if reason != tt.reason {
+				t.Errorf("reason = %q, want %q", reason, tt.reason)
+			}
+
+			if retryInfo != tt.retryInfo {
+				t.Errorf("retry info = %v, want %v", retryInfo, tt.retryInfo)
+			}
+		})
+	}
+}
+
+func TestToGrpcStatusPassesThrough(t *testing.T) {
+	if err := toGrpcStatus(nil); err != nil {
+		t.Errorf("toGrpcStatus(nil) = %v, want nil", err)
+	}
+
+	st := status.Error(codes.Unauthenticated, "missing token")
+	if got := toGrpcStatus(st); status.Code(got) != codes.Unauthenticated {
+		t.Errorf("code = %v, want Unauthenticated", status.Code(got))
+	}
+
+	if got := toGrpcStatus(context.Canceled); status.Code(got) != codes.Canceled {
+		t.Errorf("code = %v, want Canceled", status.Code(got))
+	}
+}
@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toProtoHoldStatus(status string) bank_proto.HoldStatus {
+	switch status {
+	case bank.HoldStatusActive:
+		return bank_proto.HoldStatus_HOLD_STATUS_ACTIVE
+	case bank.HoldStatusCaptured:
+		return bank_proto.HoldStatus_HOLD_STATUS_CAPTURED
+	case bank.HoldStatusReleased:
+		return bank_proto.HoldStatus_HOLD_STATUS_RELEASED
+	case bank.HoldStatusExpired:
+		return bank_proto.HoldStatus_HOLD_STATUS_EXPIRED
+	default:
+		return bank_proto.HoldStatus_HOLD_STATUS_UNSPECIFIED
+	}
+}
+
+func toProtoHold(h bank.Hold) *bank_proto.Hold {
+	res := &bank_proto.Hold{
+		HoldUuid:       h.HoldUuid.String(),
+		AccountNumber:  h.AccountNumber,
+		Currency:       h.Currency,
+		Amount:         h.Amount,
+		Status:         toProtoHoldStatus(h.Status),
+		Reference:      h.Reference,
+		ExpiresAt:      timeToDateTime(h.ExpiresAt),
+		CapturedAmount: h.CapturedAmount,
+		CreatedAt:      timeToDateTime(h.CreatedAt),
+	}
+
+	if h.TransactionUuid != uuid.Nil {
+		res.TransactionUuid = h.TransactionUuid.String()
+	}
+
+	return res
+}
+
+func (a *GrpcAdapter) PlaceHold(ctx context.Context, req *bank_proto.PlaceHoldRequest) (*bank_proto.Hold, error) {
+	if err := a.authorize(ctx, auth.ActionManageHold, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	h := bank.Hold{
+		AccountNumber: req.AccountNumber,
+		Currency:      req.Currency,
+		Amount:        req.Amount,
+		Reference:     req.Reference,
+		Origin:        originFromContext(ctx),
+	}
+
+	if req.ExpiresAt != nil {
+		expiresAt, _ := toTime(req.ExpiresAt)
+		h.ExpiresAt = expiresAt
+	}
+
+	placed, err := a.bankService.PlaceHold(h)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoHold(placed), nil
+}
+
+func (a *GrpcAdapter) CaptureHold(ctx context.Context, req *bank_proto.CaptureHoldRequest) (*bank_proto.Hold, error) {
+	if err := a.authorize(ctx, auth.ActionManageHold, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	holdUuid, err := uuid.Parse(req.HoldUuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "hold_uuid is not a valid UUID")
+	}
+
+	captured, err := a.bankService.CaptureHold(originFromContext(ctx), req.AccountNumber, holdUuid, req.Amount)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoHold(captured), nil
+}
+
+func (a *GrpcAdapter) ReleaseHold(ctx context.Context, req *bank_proto.ReleaseHoldRequest) (*bank_proto.Hold, error) {
+	if err := a.authorize(ctx, auth.ActionManageHold, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	holdUuid, err := uuid.Parse(req.HoldUuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "hold_uuid is not a valid UUID")
+	}
+
+	released, err := a.bankService.ReleaseHold(originFromContext(ctx), req.AccountNumber, holdUuid)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoHold(released), nil
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func validationUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func validationStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingServerStream{ServerStream: ss})
+}
+
+// validatingServerStream validates every message received from the client
+// before it reaches the handler
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if err := validateRequest(m); err != nil {
+		return &rejectedMessageError{err: err}
+	}
+
+	return nil
+}
+
+// rejectedMessageError is returned by RecvMsg when a message was received
+// but refused by an interceptor. Unlike other receive errors the stream is
+// still usable, so a handler may answer the message and read the next one.
+type rejectedMessageError struct {
+	err error
+}
+
+func (e *rejectedMessageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rejectedMessageError) Unwrap() error {
+	return e.err
+}
+
+func (e *rejectedMessageError) GRPCStatus() *status.Status {
+	return status.Convert(e.err)
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const requestIdHeader = "x-request-id"
+
+const anonymousActor = "anonymous"
+
+// originFromContext identifies the caller of a request for the audit log.
+// Requests without an x-request-id header get a generated one.
+func originFromContext(ctx context.Context) bank.Origin {
+	origin := bank.Origin{
+		Actor: anonymousActor,
+	}
+
+	if p, ok := auth.FromContext(ctx); ok {
+		origin.Actor = p.Subject
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIdHeader); len(values) > 0 {
+			origin.RequestId = values[0]
+		}
+	}
+
+	if origin.RequestId == "" {
+		origin.RequestId = uuid.NewString()
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		origin.ClientAddress = p.Addr.String()
+	}
+
+	return origin
+}
@@ -0,0 +1,291 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimit allows Rate events per second on average with bursts of up to
+// Burst events. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l RateLimit) enabled() bool {
+	return l.Rate > 0
+}
+
+// RateLimitConfig limits calls per method for each principal and each peer
+// IP, calls and stream messages per account number, and messages within a
+// single stream. Methods overrides Default for the given full method names,
+// e.g. "/bank.BankService/TransferMultiple".
+type RateLimitConfig struct {
+	Default        RateLimit
+	Methods        map[string]RateLimit
+	Account        RateLimit
+	StreamMessages RateLimit
+	IdleTimeout    time.Duration
+}
+
+const defaultRateLimitIdleTimeout = 10 * time.Minute
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type rateLimiter struct {
+	cfg      RateLimitConfig
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+	sweptAt  time.Time
+	nowFunc  func() time.Time
+}
+
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = defaultRateLimitIdleTimeout
+	}
+
+	return &rateLimiter{
+		cfg:      cfg,
+		limiters: map[string]*limiterEntry{},
+		sweptAt:  time.Now(),
+		nowFunc:  time.Now,
+	}
+}
+
+func (r *rateLimiter) methodLimit(method string) RateLimit {
+	if l, ok := r.cfg.Methods[method]; ok {
+		return l
+	}
+
+	return r.cfg.Default
+}
+
+// sweep drops the limiters of keys that have not been seen for a while, it
+// must be called with r.mu held
+func (r *rateLimiter) sweep(now time.Time) {
+	if now.Sub(r.sweptAt) < r.cfg.IdleTimeout {
+		return
+	}
+
+	for k, e := range r.limiters {
+		if now.Sub(e.lastSeen) >= r.cfg.IdleTimeout {
+			delete(r.limiters, k)
+		}
+	}
+
+	r.sweptAt = now
+}
+
+// allow takes a token from the bucket of key, or returns how long the caller
+// should wait before retrying
+func (r *rateLimiter) allow(key string, limit RateLimit) (bool, time.Duration) {
+	if !limit.enabled() {
+		return true, 0
+	}
+
+	now := r.nowFunc()
+
+	r.mu.Lock()
+	r.sweep(now)
+
+	e, ok := r.limiters[key]
+	if !ok {
+		e = &limiterEntry{
+			limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst),
+		}
+		r.limiters[key] = e
+	}
+	e.lastSeen = now
+	r.mu.Unlock()
+
+	res := e.limiter.ReserveN(now, 1)
+
+	if !res.OK() {
+		return false, time.Second
+	}
+
+	if delay := res.DelayFrom(now); delay > 0 {
+		res.CancelAt(now)
+		return false, delay
+	}
+
+	return true, 0
+}
+
+func rateLimitedError(scope string, key string, retryAfter time.Duration) error {
+	return newStatus(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded for %v %v", scope, key),
+		errorInfo(bank.ReasonRateLimited, map[string]string{
+			"scope": scope,
+			"key":   key,
+		}),
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryAfter),
+		},
+	)
+}
+
+func peerIp(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// checkCall applies the per method limits of the principal and of the peer IP
+func (r *rateLimiter) checkCall(ctx context.Context, method string) error {
+	limit := r.methodLimit(method)
+
+	if p, ok := auth.FromContext(ctx); ok {
+		if ok, retryAfter := r.allow("principal|"+p.Subject+"|"+method, limit); !ok {
+			return rateLimitedError("principal", p.Subject, retryAfter)
+		}
+	}
+
+	if ip := peerIp(ctx); ip != "" {
+		if ok, retryAfter := r.allow("ip|"+ip+"|"+method, limit); !ok {
+			return rateLimitedError("client", ip, retryAfter)
+		}
+	}
+
+	return nil
+}
+
+// checkAccounts applies the per account limit to every account the request
+// acts on
+func (r *rateLimiter) checkAccounts(req interface{}) error {
+	for _, acct := range requestAccountNumbers(req) {
+		if ok, retryAfter := r.allow("account|"+acct, r.cfg.Account); !ok {
+			return rateLimitedError("account", acct, retryAfter)
+		}
+	}
+
+	return nil
+}
+
+func requestAccountNumbers(req interface{}) []string {
+	var acct string
+
+	switch r := req.(type) {
+	case *bank_proto.CurrentBalanceRequest:
+		acct = r.AccountNumber
+	case *bank_proto.Transaction:
+		acct = r.AccountNumber
+	case *bank_proto.TransferRequest:
+		acct = r.FromAccountNumber
+	case *bank_proto.StatementRequest:
+		acct = r.AccountNumber
+	case *bank_proto.TransactionSummaryRequest:
+		acct = r.AccountNumber
+	case *bank_proto.PlaceHoldRequest:
+		acct = r.AccountNumber
+	case *bank_proto.CaptureHoldRequest:
+		acct = r.AccountNumber
+	case *bank_proto.ReleaseHoldRequest:
+		acct = r.AccountNumber
+	case *bank_proto.ScheduledTransferRequest:
+		acct = r.FromAccountNumber
+	case *bank_proto.ScheduledTransferLookup:
+		acct = r.AccountNumber
+	case *bank_proto.ScheduledTransferListRequest:
+		acct = r.AccountNumber
+	case *bank_proto.GetTransferRequest:
+		acct = r.AccountNumber
+	case *bank_proto.ListTransfersRequest:
+		acct = r.AccountNumber
+	case *bank_proto.ListTransactionsRequest:
+		acct = r.AccountNumber
+	}
+
+	if acct == "" {
+		return nil
+	}
+
+	return []string{acct}
+}
+
+func (r *rateLimiter) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := r.checkCall(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	if err := r.checkAccounts(req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (r *rateLimiter) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := r.checkCall(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	stream := &rateLimitedServerStream{
+		ServerStream: ss,
+		limiter:      r,
+	}
+
+	if r.cfg.StreamMessages.enabled() {
+		stream.messages = rate.NewLimiter(rate.Limit(r.cfg.StreamMessages.Rate), r.cfg.StreamMessages.Burst)
+	}
+
+	return handler(srv, stream)
+}
+
+// rateLimitedServerStream limits the messages a client sends on one stream
+// and the messages acting on each account
+type rateLimitedServerStream struct {
+	grpc.ServerStream
+	limiter  *rateLimiter
+	messages *rate.Limiter
+}
+
+func (s *rateLimitedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if s.messages != nil {
+		now := time.Now()
+		res := s.messages.ReserveN(now, 1)
+
+		if !res.OK() {
+			return &rejectedMessageError{err: rateLimitedError("stream", "messages", time.Second)}
+		}
+
+		if delay := res.DelayFrom(now); delay > 0 {
+			res.CancelAt(now)
+			return &rejectedMessageError{err: rateLimitedError("stream", "messages", delay)}
+		}
+	}
+
+	if err := s.limiter.checkAccounts(m); err != nil {
+		return &rejectedMessageError{err: err}
+	}
+
+	return nil
+}
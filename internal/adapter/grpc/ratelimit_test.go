@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// testRateLimiter returns a limiter whose clock only moves when the returned
+// function is called
+func testRateLimiter(cfg RateLimitConfig) (*rateLimiter, func(d time.Duration)) {
+	r := newRateLimiter(cfg)
+	now := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	r.sweptAt = now
+	r.nowFunc = func() time.Time { return now }
+
+	return r, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	type call struct {
+		after   time.Duration
+		key     string
+		allowed bool
+	}
+
+	tests := []struct {
+		name  string
+		limit RateLimit
+		calls []call
+	}{
+		{"disabled", RateLimit{}, []call{{0, "a", true}, {0, "a", true}, {0, "a", true}}},
+		{"burst then refused", RateLimit{Rate: 1, Burst: 2}, []call{{0, "a", true}, {0, "a", true}, {0, "a", false}}},
+		{"refilled over time", RateLimit{Rate: 1, Burst: 1}, []call{{0, "a", true}, {500 * time.Millisecond, "a", false}, {500 * time.Millisecond, "a", true}}},
+		{"keys are independent", RateLimit{Rate: 1, Burst: 1}, []call{{0, "a", true}, {0, "b", true}, {0, "a", false}}},
+		{"zero burst never allows", RateLimit{Rate: 1}, []call{{0, "a", false}, {time.Minute, "a", false}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, advance := testRateLimiter(RateLimitConfig{})
+
+			for i, c := range tt.calls {
+				advance(c.after)
+
+				ok, retryAfter := r.allow(c.key, tt.limit)
+
+				if ok != c.allowed {
+					t.Fatalf("call %v allow() = %v, want %v", i, ok, c.allowed)
+				}
+
+				if !ok && retryAfter <= 0 {
+					t.Errorf("call %v retry after = %v, want a positive delay", i, retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	r, advance := testRateLimiter(RateLimitConfig{IdleTimeout: time.Minute})
+	limit := RateLimit{Rate: 1, Burst: 1}
+
+	r.allow("idle", limit)
+	advance(30 * time.Second)
+	r.allow("busy", limit)
+	advance(30 * time.Second)
+	r.allow("busy", limit)
+
+	if _, ok := r.limiters["idle"]; ok {
+		t.Error("limiter of an idle key was kept")
+	}
+
+	if _, ok := r.limiters["busy"]; !ok {
+		t.Error("limiter of a busy key was dropped")
+	}
+}
+
+func TestRateLimiterCheckCall(t *testing.T) {
+	const method = "/bank.BankService/Transfer"
+
+	alice := auth.NewContext(context.Background(), auth.Principal{Subject: "alice"})
+	bob := auth.NewContext(context.Background(), auth.Principal{Subject: "bob"})
+	fromIp := func(ctx context.Context, ip string) context.Context {
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50051}})
+	}
+
+	tests := []struct {
+		name   string
+		cfg    RateLimitConfig
+		first  context.Context
+		second context.Context
+		want   codes.Code
+	}{
+		{"same principal", RateLimitConfig{Default: RateLimit{Rate: 1, Burst: 1}}, alice, alice, codes.ResourceExhausted},
+		{"other principal", RateLimitConfig{Default: RateLimit{Rate: 1, Burst: 1}}, alice, bob, codes.OK},
+		{"other principal same ip", RateLimitConfig{Default: RateLimit{Rate: 1, Burst: 1}}, fromIp(alice, "10.0.0.1"), fromIp(bob, "10.0.0.1"), codes.ResourceExhausted},
+		{"anonymous other ip", RateLimitConfig{Default: RateLimit{Rate: 1, Burst: 1}}, fromIp(context.Background(), "10.0.0.1"), fromIp(context.Background(), "10.0.0.2"), codes.OK},
+		{"method override", RateLimitConfig{
+			Default: RateLimit{Rate: 1, Burst: 1},
+			Methods: map[string]RateLimit{method: {Rate: 1, Burst: 2}},
+		}, alice, alice, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := testRateLimiter(tt.cfg)
+
+			if err := r.checkCall(tt.first, method); err != nil {
+				t.Fatalf("first checkCall() = %v", err)
+			}
+
+			err := r.checkCall(tt.second, method)
+
+			if status.Code(err) != tt.want {
+				t.Fatalf("second checkCall() = %v, want %v", err, tt.want)
+			}
+
+			if err == nil {
+				return
+			}
+
+			var retry *errdetails.RetryInfo
+			for _, d := range status.Convert(err).Details() {
+				if ri, ok := d.(*errdetails.RetryInfo); ok {
+					retry = ri
+				}
+			}
+
+			if retry == nil || retry.RetryDelay.AsDuration() <= 0 {
+				t.Errorf("second checkCall() retry info = %v, want a positive delay", retry)
+			}
+		})
+	}
+}
+
+func TestRateLimiterCheckAccounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  interface{}
+		second interface{}
+		want   codes.Code
+	}{
+		{"same account", &bank_proto.CurrentBalanceRequest{AccountNumber: "7835697001"}, &bank_proto.Transaction{AccountNumber: "7835697001"}, codes.ResourceExhausted},
+		{"other account", &bank_proto.CurrentBalanceRequest{AccountNumber: "7835697001"}, &bank_proto.CurrentBalanceRequest{AccountNumber: "7835697002"}, codes.OK},
+		{"transfer counts against the source", &bank_proto.TransferRequest{FromAccountNumber: "7835697001", ToAccountNumber: "7835697002"}, &bank_proto.CurrentBalanceRequest{AccountNumber: "7835697002"}, codes.OK},
+		{"request without account", &bank_proto.ListTransfersRequest{}, &bank_proto.ListTransfersRequest{}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := testRateLimiter(RateLimitConfig{Account: RateLimit{Rate: 1, Burst: 1}})
+
+			if err := r.checkAccounts(tt.first); err != nil {
+				t.Fatalf("first checkAccounts() = %v", err)
+			}
+
+			if err := r.checkAccounts(tt.second); status.Code(err) != tt.want {
+				t.Errorf("second checkAccounts() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toScheduleFrequency(f bank_proto.ScheduleFrequency) string {
+	switch f {
+	case bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_ONCE:
+		return bank.ScheduleFrequencyOnce
+	case bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_DAILY:
+		return bank.ScheduleFrequencyDaily
+	case bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_WEEKLY:
+		return bank.ScheduleFrequencyWeekly
+	case bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_MONTHLY:
+		return bank.ScheduleFrequencyMonthly
+	default:
+		return ""
+	}
+}
+
+func toProtoScheduleFrequency(f string) bank_proto.ScheduleFrequency {
+	switch f {
+	case bank.ScheduleFrequencyOnce:
+		return bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_ONCE
+	case bank.ScheduleFrequencyDaily:
+		return bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_DAILY
+	case bank.ScheduleFrequencyWeekly:
+		return bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_WEEKLY
+	case bank.ScheduleFrequencyMonthly:
+		return bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_MONTHLY
+	default:
+		return bank_proto.ScheduleFrequency_SCHEDULE_FREQUENCY_UNSPECIFIED
+	}
+}
+
+func toProtoScheduleStatus(s string) bank_proto.ScheduleStatus {
+	switch s {
+	case bank.ScheduleStatusActive:
+		return bank_proto.ScheduleStatus_SCHEDULE_STATUS_ACTIVE
+	case bank.ScheduleStatusCompleted:
+		return bank_proto.ScheduleStatus_SCHEDULE_STATUS_COMPLETED
+	case bank.ScheduleStatusCancelled:
+		return bank_proto.ScheduleStatus_SCHEDULE_STATUS_CANCELLED
+	default:
+		return bank_proto.ScheduleStatus_SCHEDULE_STATUS_UNSPECIFIED
+	}
+}
+
+func toProtoScheduledTransfer(st bank.ScheduledTransfer) *bank_proto.ScheduledTransfer {
+	res := &bank_proto.ScheduledTransfer{
+		ScheduleUuid:      st.ScheduleUuid.String(),
+		FromAccountNumber: st.FromAccountNumber,
+		ToAccountNumber:   st.ToAccountNumber,
+		Currency:          st.Currency,
+		Amount:            st.Amount,
+		Frequency:         toProtoScheduleFrequency(st.Frequency),
+		Interval:          int32(st.Interval),
+		StartAt:           timeToDateTime(st.StartAt),
+		NextRunAt:         timeToDateTime(st.NextRunAt),
+		Status:            toProtoScheduleStatus(st.Status),
+		CreatedBy:         st.CreatedBy,
+	}
+
+	if !st.EndAt.IsZero() {
+		res.EndAt = timeToDateTime(st.EndAt)
+	}
+
+	for _, e := range st.Executions {
+		ex := &bank_proto.ScheduledTransferExecution{
+			ExecutionUuid: e.ExecutionUuid.String(),
+			ScheduledFor:  timeToDateTime(e.ScheduledFor),
+			Attempt:       int32(e.Attempt),
+			Status:        e.Status,
+			Error:         e.Error,
+			StartedAt:     timeToDateTime(e.StartedAt),
+		}
+
+		if e.TransferUuid != uuid.Nil {
+			ex.TransferUuid = e.TransferUuid.String()
+		}
+
+		if !e.FinishedAt.IsZero() {
+			ex.FinishedAt = timeToDateTime(e.FinishedAt)
+		}
+
+		res.Executions = append(res.Executions, ex)
+	}
+
+	return res
+}
+
+func (a *GrpcAdapter) CreateScheduledTransfer(ctx context.Context, req *bank_proto.ScheduledTransferRequest) (*bank_proto.ScheduledTransfer, error) {
+	if err := a.authorize(ctx, auth.ActionTransfer, req.FromAccountNumber); err != nil {
+		return nil, err
+	}
+
+	st := bank.ScheduledTransfer{
+		FromAccountNumber: req.FromAccountNumber,
+		ToAccountNumber:   req.ToAccountNumber,
+		Currency:          req.Currency,
+		Amount:            req.Amount,
+		Frequency:         toScheduleFrequency(req.Frequency),
+		Interval:          int(req.Interval),
+		Origin:            originFromContext(ctx),
+	}
+
+	if req.StartAt != nil {
+		st.StartAt, _ = toTime(req.StartAt)
+	}
+
+	if req.EndAt != nil {
+		st.EndAt, _ = toTime(req.EndAt)
+	}
+
+	created, err := a.bankService.CreateScheduledTransfer(st)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoScheduledTransfer(created), nil
+}
+
+func (a *GrpcAdapter) GetScheduledTransfer(ctx context.Context, req *bank_proto.ScheduledTransferLookup) (*bank_proto.ScheduledTransfer, error) {
+	if err := a.authorize(ctx, auth.ActionReadAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	scheduleUuid, err := uuid.Parse(req.ScheduleUuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "schedule_uuid is not a valid UUID")
+	}
+
+	st, err := a.bankService.GetScheduledTransfer(req.AccountNumber, scheduleUuid)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoScheduledTransfer(st), nil
+}
+
+func (a *GrpcAdapter) ListScheduledTransfers(ctx context.Context, req *bank_proto.ScheduledTransferListRequest) (*bank_proto.ScheduledTransferList, error) {
+	if err := a.authorize(ctx, auth.ActionReadAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	sts, err := a.bankService.ListScheduledTransfers(req.AccountNumber)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	res := &bank_proto.ScheduledTransferList{
+		ScheduledTransfers: make([]*bank_proto.ScheduledTransfer, 0, len(sts)),
+	}
+
+	for _, st := range sts {
+		res.ScheduledTransfers = append(res.ScheduledTransfers, toProtoScheduledTransfer(st))
+	}
+
+	return res, nil
+}
+
+func (a *GrpcAdapter) CancelScheduledTransfer(ctx context.Context, req *bank_proto.ScheduledTransferLookup) (*bank_proto.ScheduledTransfer, error) {
+	if err := a.authorize(ctx, auth.ActionTransfer, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	scheduleUuid, err := uuid.Parse(req.ScheduleUuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "schedule_uuid is not a valid UUID")
+	}
+
+	st, err := a.bankService.CancelScheduledTransfer(originFromContext(ctx), req.AccountNumber, scheduleUuid)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoScheduledTransfer(st), nil
+}
@@ -6,7 +6,7 @@ import (
 	"net"
 
 	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
-	bank_proto "github.com/abhilashdk2016/my-grpc-proto/protogen/go/bank-proto"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -15,14 +15,79 @@ type GrpcAdapter struct {
 	grpcPort    int
 	server      *grpc.Server
 	bankService port.BankServicePort
+	tlsConfig   *TlsConfig
+	authConfig  *AuthConfig
+	// authDisabled accepts every caller when no authConfig is set, without
+	// it the server refuses to start unauthenticated
+	authDisabled bool
+	rateLimit    *RateLimitConfig
+	// transferConcurrency is how many transfers of one TransferMultiple
+	// stream run at the same time
+	transferConcurrency int
 	bank_proto.BankServiceServer
+	bank_proto.ScheduledTransferServiceServer
+	bank_proto.TransferBatchServiceServer
 }
 
-func NewGrpcAdapter(bankService port.BankServicePort, grpcPort int) *GrpcAdapter {
-	return &GrpcAdapter{
+type GrpcAdapterOption func(a *GrpcAdapter)
+
+// WithTls serves gRPC over TLS, and mTLS when cfg.ClientCAFile is set. With
+// mTLS, verified client certificates authenticate callers even without
+// WithAuth.
+func WithTls(cfg TlsConfig) GrpcAdapterOption {
+	return func(a *GrpcAdapter) {
+		a.tlsConfig = &cfg
+	}
+}
+
+// WithAuth rejects calls without a valid JWT or API key
+func WithAuth(cfg AuthConfig) GrpcAdapterOption {
+	return func(a *GrpcAdapter) {
+		a.authConfig = &cfg
+	}
+}
+
+// WithoutAuth runs the server without authentication, every caller is
+// accepted and allowed on every account. Only meant for local development,
+// it is ignored when WithAuth is also given.
+func WithoutAuth() GrpcAdapterOption {
+	return func(a *GrpcAdapter) {
+		a.authDisabled = true
+	}
+}
+
+// WithRateLimit throttles calls per principal, peer IP and account, and
+// messages within streams
+func WithRateLimit(cfg RateLimitConfig) GrpcAdapterOption {
+	return func(a *GrpcAdapter) {
+		a.rateLimit = &cfg
+	}
+}
+
+// WithTransferConcurrency sets how many transfers of one TransferMultiple
+// stream run at the same time, 1 processes them one by one. Streams asking
+// for stream order always run one by one.
+func WithTransferConcurrency(n int) GrpcAdapterOption {
+	return func(a *GrpcAdapter) {
+		a.transferConcurrency = n
+	}
+}
+
+func NewGrpcAdapter(bankService port.BankServicePort, grpcPort int, opts ...GrpcAdapterOption) *GrpcAdapter {
+	a := &GrpcAdapter{
 		grpcPort:    grpcPort,
 		bankService: bankService,
 	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	if a.authConfig == nil && a.tlsConfig != nil && a.tlsConfig.ClientCAFile != "" {
+		a.authConfig = &AuthConfig{}
+	}
+
+	return a
 }
 
 func (a *GrpcAdapter) Run() {
@@ -36,10 +101,58 @@ func (a *GrpcAdapter) Run() {
 
 	log.Printf("Server listening on port %d\n", a.grpcPort)
 
-	grpcServer := grpc.NewServer()
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+
+	if a.authConfig != nil {
+		authenticator, err := newAuthenticator(*a.authConfig)
+
+		if err != nil {
+			log.Fatalf("Failed to configure authentication: %v\n", err)
+		}
+
+		unaryInterceptors = append(unaryInterceptors, authenticator.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, authenticator.streamInterceptor)
+		log.Println("Authentication enabled")
+	} else if a.authDisabled {
+		log.Println("WARNING : authentication is DISABLED, every caller is accepted and allowed on every account. Never run this way in production.")
+	} else {
+		log.Fatalln("Authentication is not configured : set GRPC_AUTH_JWKS_FILE, GRPC_AUTH_API_KEYS_FILE or GRPC_TLS_CLIENT_CA_FILE, or GRPC_AUTH_DISABLED=true to explicitly accept every caller")
+	}
+
+	if a.rateLimit != nil {
+		limiter := newRateLimiter(*a.rateLimit)
+
+		unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
+		log.Println("Rate limiting enabled")
+	}
+
+	unaryInterceptors = append(unaryInterceptors, validationUnaryInterceptor)
+	streamInterceptors = append(streamInterceptors, validationStreamInterceptor)
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+
+	if a.tlsConfig != nil {
+		creds, err := newServerCredentials(*a.tlsConfig)
+
+		if err != nil {
+			log.Fatalf("Failed to configure TLS: %v\n", err)
+		}
+
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+		log.Printf("TLS enabled (client certificates verified : %v)\n", a.tlsConfig.ClientCAFile != "")
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
 	a.server = grpcServer
 	reflection.Register(grpcServer)
 	bank_proto.RegisterBankServiceServer(grpcServer, a)
+	bank_proto.RegisterScheduledTransferServiceServer(grpcServer, a)
+	bank_proto.RegisterTransferBatchServiceServer(grpcServer, a)
 	if err = grpcServer.Serve(listen); err != nil {
 		log.Fatalf("Failed to serve gRPC on port %d: %v\n", a.grpcPort, err)
 	}
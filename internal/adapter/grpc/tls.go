@@ -0,0 +1,213 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// TlsConfig enables TLS on the gRPC server. When ClientCAFile is set the
+// server also verifies client certificates against that CA bundle (mTLS).
+// Certificate files are re-read when they change on disk, checked at most
+// once per ReloadInterval.
+type TlsConfig struct {
+	CertFile          string
+	KeyFile           string
+	ClientCAFile      string
+	RequireClientCert bool
+	ReloadInterval    time.Duration
+}
+
+const defaultTlsReloadInterval = 30 * time.Second
+
+type tlsReloader struct {
+	cfg       TlsConfig
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+	checkedAt time.Time
+}
+
+func newTlsReloader(cfg TlsConfig) (*tlsReloader, error) {
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = defaultTlsReloadInterval
+	}
+
+	r := &tlsReloader{
+		cfg:      cfg,
+		modTimes: map[string]time.Time{},
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *tlsReloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+
+	return files
+}
+
+func (r *tlsReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("can't load server certificate : %v", err)
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("can't read client CA bundle : %v", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in client CA bundle %v", r.cfg.ClientCAFile)
+		}
+	}
+
+	modTimes := map[string]time.Time{}
+	for _, f := range r.files() {
+		if fi, err := os.Stat(f); err == nil {
+			modTimes[f] = fi.ModTime()
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.checkedAt = time.Now()
+
+	return nil
+}
+
+// reloadIfChanged reloads the certificates when any file changed on disk. A
+// failed reload keeps serving the previously loaded certificates.
+func (r *tlsReloader) reloadIfChanged() {
+	r.mu.RLock()
+	due := time.Since(r.checkedAt) >= r.cfg.ReloadInterval
+	r.mu.RUnlock()
+
+	if !due {
+		return
+	}
+
+	changed := false
+
+	r.mu.Lock()
+	for _, f := range r.files() {
+		fi, err := os.Stat(f)
+		if err == nil && !fi.ModTime().Equal(r.modTimes[f]) {
+			changed = true
+		}
+	}
+	r.checkedAt = time.Now()
+	r.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if err := r.load(); err != nil {
+		log.Println("TLS certificate reload failed, keeping previous certificates :", err)
+		return
+	}
+
+	log.Println("TLS certificates reloaded")
+}
+
+func (r *tlsReloader) serverConfig(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.reloadIfChanged()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// the config returned here replaces the one credentials.NewTLS added h2
+	// to, so it must offer h2 itself for ALPN to succeed
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+		ClientAuth:   tls.NoClientCert,
+		NextProtos:   []string{"h2"},
+	}
+
+	if r.clientCAs != nil {
+		cfg.ClientCAs = r.clientCAs
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+
+		if r.cfg.RequireClientCert {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	return cfg, nil
+}
+
+func newServerCredentials(cfg TlsConfig) (credentials.TransportCredentials, error) {
+	r, err := newTlsReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.serverConfig,
+	}), nil
+}
+
+// ClientIdentity is the verified client certificate of an mTLS connection
+type ClientIdentity struct {
+	CommonName   string
+	Organization []string
+	DNSNames     []string
+	SerialNumber string
+	Fingerprint  string
+}
+
+// ClientIdentityFromContext returns the identity of the verified client
+// certificate used for the call, if any
+func ClientIdentityFromContext(ctx context.Context) (ClientIdentity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ClientIdentity{}, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ClientIdentity{}, false
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	return ClientIdentity{
+		CommonName:   cert.Subject.CommonName,
+		Organization: cert.Subject.Organization,
+		DNSNames:     cert.DNSNames,
+		SerialNumber: cert.SerialNumber.String(),
+		Fingerprint:  hex.EncodeToString(fingerprint[:]),
+	}, true
+}
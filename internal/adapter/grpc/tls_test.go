@@ -0,0 +1,459 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var testSerial int64
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func newTestCA(t *testing.T, cn string) testCA {
+	t.Helper()
+
+	key := newTestKey(t)
+	testSerial++
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(testSerial),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns the PEM certificate and key of a leaf signed by the CA, for
+// localhost when server is set and for client authentication otherwise
+func (ca testCA) issue(t *testing.T, cn string, server bool) ([]byte, []byte) {
+	t.Helper()
+
+	key := newTestKey(t)
+	testSerial++
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(testSerial),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"my-grpc-bank"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	if server {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		tmpl.DNSNames = []string{"localhost"}
+		tmpl.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func (ca testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func (ca testCA) clientCert(t *testing.T, cn string) tls.Certificate {
+	t.Helper()
+
+	certPem, keyPem := ca.issue(t, cn, false)
+
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+// writeFile writes content and moves its modification time to mod, so a
+// rewrite within the same clock tick is still seen as a change
+func writeFile(t *testing.T, path string, content []byte, mod time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeServerFiles writes a server certificate of ca and returns a TlsConfig
+// using it
+func writeServerFiles(t *testing.T, dir string, ca testCA, cn string, mod time.Time) TlsConfig {
+	t.Helper()
+
+	certPem, keyPem := ca.issue(t, cn, true)
+
+	cfg := TlsConfig{
+		CertFile: filepath.Join(dir, "server.crt"),
+		KeyFile:  filepath.Join(dir, "server.key"),
+	}
+
+	writeFile(t, cfg.CertFile, certPem, mod)
+	writeFile(t, cfg.KeyFile, keyPem, mod)
+
+	return cfg
+}
+
+// startTlsServer serves the health service over cfg and returns its address
+// and the client identities seen by calls
+func startTlsServer(t *testing.T, cfg TlsConfig) (string, <-chan ClientIdentity) {
+	t.Helper()
+
+	creds, err := newServerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identities := make(chan ClientIdentity, 10)
+
+	srv := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if id, ok := ClientIdentityFromContext(ctx); ok {
+				identities <- id
+			}
+			return handler(ctx, req)
+		}))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), identities
+}
+
+func checkHealth(addr string, tlsCfg *tls.Config) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	return err
+}
+
+// dialTls completes a TLS handshake and returns the connection state
+func dialTls(t *testing.T, addr string, tlsCfg *tls.Config) tls.ConnectionState {
+	t.Helper()
+
+	conn, err := tls.Dial("tcp", addr, tlsCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState()
+}
+
+func TestTlsServer(t *testing.T) {
+	ca := newTestCA(t, "test CA")
+	cfg := writeServerFiles(t, t.TempDir(), ca, "server", time.Now())
+
+	addr, identities := startTlsServer(t, cfg)
+
+	clientCfg := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"}
+
+	if err := checkHealth(addr, clientCfg); err != nil {
+		t.Fatalf("health check over TLS failed : %v", err)
+	}
+
+	select {
+	case id := <-identities:
+		t.Errorf("got client identity %v without a client certificate", id.CommonName)
+	default:
+	}
+
+	state := dialTls(t, addr, &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", NextProtos: []string{"h2"}})
+
+	if state.NegotiatedProtocol != "h2" {
+		t.Errorf("negotiated protocol = %q, want h2", state.NegotiatedProtocol)
+	}
+
+	untrusted := &tls.Config{RootCAs: newTestCA(t, "other CA").pool(), ServerName: "localhost"}
+
+	if err := checkHealth(addr, untrusted); err == nil {
+		t.Error("client not trusting the server CA connected")
+	}
+}
+
+func TestMutualTls(t *testing.T) {
+	ca := newTestCA(t, "test CA")
+	dir := t.TempDir()
+
+	cfg := writeServerFiles(t, dir, ca, "server", time.Now())
+	cfg.ClientCAFile = filepath.Join(dir, "clients.pem")
+	writeFile(t, cfg.ClientCAFile, ca.pem, time.Now())
+
+	tests := []struct {
+		name       string
+		require    bool
+		clientCert *tls.Certificate
+		wantOk     bool
+		wantCN     string
+	}{
+		{"required and verified", true, &[]tls.Certificate{ca.clientCert(t, "teller-1")}[0], true, "teller-1"},
+		{"required but missing", true, nil, false, ""},
+		{"required but from another CA", true, &[]tls.Certificate{newTestCA(t, "other CA").clientCert(t, "intruder")}[0], false, ""},
+		{"optional and missing", false, nil, true, ""},
+		{"optional but from another CA", false, &[]tls.Certificate{newTestCA(t, "other CA").clientCert(t, "intruder")}[0], false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := cfg
+			cfg.RequireClientCert = tt.require
+
+			addr, identities := startTlsServer(t, cfg)
+
+			clientCfg := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"}
+			if tt.clientCert != nil {
+				// always send the certificate, crypto/tls skips one the
+				// server's list of acceptable CAs does not match
+				clientCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					return tt.clientCert, nil
+				}
+			}
+
+			err := checkHealth(addr, clientCfg)
+
+			if tt.wantOk && err != nil {
+				t.Fatalf("health check failed : %v", err)
+			}
+
+			if !tt.wantOk {
+				if err == nil {
+					t.Fatal("health check succeeded, want the client rejected")
+				}
+				return
+			}
+
+			var cn string
+			select {
+			case id := <-identities:
+				cn = id.CommonName
+			default:
+			}
+
+			if cn != tt.wantCN {
+				t.Errorf("client identity = %q, want %q", cn, tt.wantCN)
+			}
+		})
+	}
+}
+
+func TestTlsHotReload(t *testing.T) {
+	ca := newTestCA(t, "test CA")
+	dir := t.TempDir()
+	start := time.Now()
+
+	cfg := writeServerFiles(t, dir, ca, "server-a", start)
+	cfg.ReloadInterval = time.Millisecond
+
+	addr, _ := startTlsServer(t, cfg)
+
+	clientCfg := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"}
+
+	serverName := func() string {
+		return dialTls(t, addr, clientCfg).PeerCertificates[0].Subject.CommonName
+	}
+
+	if got := serverName(); got != "server-a" {
+		t.Fatalf("server certificate = %q, want server-a", got)
+	}
+
+	writeServerFiles(t, dir, ca, "server-b", start.Add(time.Minute))
+	time.Sleep(5 * time.Millisecond)
+
+	if got := serverName(); got != "server-b" {
+		t.Fatalf("server certificate after rotation = %q, want server-b", got)
+	}
+
+	writeFile(t, cfg.CertFile, []byte("not a certificate"), start.Add(2*time.Minute))
+	time.Sleep(5 * time.Millisecond)
+
+	if got := serverName(); got != "server-b" {
+		t.Errorf("server certificate after a broken rotation = %q, want server-b kept", got)
+	}
+
+	if err := checkHealth(addr, clientCfg); err != nil {
+		t.Errorf("health check after a broken rotation failed : %v", err)
+	}
+}
+
+// certAuthorizeService lets the subjects it lists read any account and
+// records the origin of every decision, any other call panics
+type certAuthorizeService struct {
+	port.BankServicePort
+	allowed map[string]bool
+	origins chan bank.Origin
+}
+
+func (s *certAuthorizeService) Authorize(origin bank.Origin, p auth.Principal, action string, acct string) error {
+	s.origins <- origin
+
+	if p.Method != auth.MethodClientCert || !s.allowed[p.Subject] {
+		return &bank.PermissionDeniedError{Subject: p.Subject, Action: action, AccountNumber: acct}
+	}
+
+	return nil
+}
+
+func TestMutualTlsAuthorization(t *testing.T) {
+	ca := newTestCA(t, "test CA")
+	dir := t.TempDir()
+
+	cfg := writeServerFiles(t, dir, ca, "server", time.Now())
+	cfg.ClientCAFile = filepath.Join(dir, "clients.pem")
+	writeFile(t, cfg.ClientCAFile, ca.pem, time.Now())
+
+	svc := &certAuthorizeService{allowed: map[string]bool{"teller-1": true}, origins: make(chan bank.Origin, 10)}
+
+	// only mTLS is configured, no JWT nor API key
+	a := NewGrpcAdapter(svc, 0, WithTls(cfg))
+
+	authenticator, err := newAuthenticator(*a.authConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := newServerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := grpc.NewServer(grpc.Creds(creds), grpc.ChainUnaryInterceptor(authenticator.unaryInterceptor,
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := a.authorize(ctx, auth.ActionReadAccount, "7835697001"); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	tests := []struct {
+		name       string
+		clientCert *tls.Certificate
+		wantCode   codes.Code
+		wantActor  string
+	}{
+		{"allowed subject", &[]tls.Certificate{ca.clientCert(t, "teller-1")}[0], codes.OK, "teller-1"},
+		{"other subject", &[]tls.Certificate{ca.clientCert(t, "teller-2")}[0], codes.PermissionDenied, "teller-2"},
+		{"no certificate", nil, codes.Unauthenticated, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"}
+			if tt.clientCert != nil {
+				clientCfg.Certificates = []tls.Certificate{*tt.clientCert}
+			}
+
+			err := checkHealth(lis.Addr().String(), clientCfg)
+
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("health check code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+
+			var actor string
+			select {
+			case origin := <-svc.origins:
+				actor = origin.Actor
+			default:
+			}
+
+			if actor != tt.wantActor {
+				t.Errorf("audit actor = %q, want %q", actor, tt.wantActor)
+			}
+		})
+	}
+}
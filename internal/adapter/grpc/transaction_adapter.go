@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+)
+
+func toTransactionType(t bank_proto.TransactionType) string {
+	switch t {
+	case bank_proto.TransactionType_TRANSACION_TYPE_IN:
+		return bank.TransactionTypeIn
+	case bank_proto.TransactionType_TRANSACION_TYPE_OUT:
+		return bank.TransactionTypeOut
+	default:
+		return ""
+	}
+}
+
+func toTransactionMetadata(m *bank_proto.TransactionMetadata) bank.TransactionMetadata {
+	if m == nil {
+		return bank.TransactionMetadata{}
+	}
+
+	return bank.TransactionMetadata{
+		Category:              m.Category,
+		Tags:                  m.Tags,
+		CounterpartyName:      m.CounterpartyName,
+		CounterpartyReference: m.CounterpartyReference,
+		ExternalReferenceId:   m.ExternalReferenceId,
+	}
+}
+
+func toProtoTransactionMetadata(m bank.TransactionMetadata) *bank_proto.TransactionMetadata {
+	return &bank_proto.TransactionMetadata{
+		Category:              m.Category,
+		Tags:                  m.Tags,
+		CounterpartyName:      m.CounterpartyName,
+		CounterpartyReference: m.CounterpartyReference,
+		ExternalReferenceId:   m.ExternalReferenceId,
+	}
+}
+
+func toProtoTransactionRecord(t bank.TransactionRecord) *bank_proto.TransactionRecord {
+	res := &bank_proto.TransactionRecord{
+		TransactionUuid: t.TransactionUuid.String(),
+		AccountNumber:   t.AccountNumber,
+		Type:            toProtoTransactionType(t.TransactionType),
+		Amount:          t.Amount,
+		Timestamp:       timeToDateTime(t.Timestamp),
+		Notes:           t.Notes,
+		Metadata:        toProtoTransactionMetadata(t.Metadata),
+	}
+
+	if t.TransferUuid != uuid.Nil {
+		res.TransferUuid = t.TransferUuid.String()
+	}
+
+	return res
+}
+
+func (a *GrpcAdapter) ListTransactions(ctx context.Context, req *bank_proto.ListTransactionsRequest) (*bank_proto.TransactionList, error) {
+	if err := a.authorize(ctx, auth.ActionReadAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	f := bank.TransactionFilter{
+		AccountNumber:       req.AccountNumber,
+		TransactionType:     toTransactionType(req.Type),
+		Category:            req.Category,
+		Tag:                 req.Tag,
+		CounterpartyName:    req.CounterpartyName,
+		ExternalReferenceId: req.ExternalReferenceId,
+		PageToken:           req.PageToken,
+		Limit:               int(req.Limit),
+	}
+
+	if req.FromDate != nil {
+		f.From = dateToTime(req.FromDate)
+	}
+
+	if req.ToDate != nil {
+		f.To = dateToTime(req.ToDate).AddDate(0, 0, 1)
+	}
+
+	transactions, nextPageToken, err := a.bankService.ListTransactions(f)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	res := &bank_proto.TransactionList{
+		Transactions:  make([]*bank_proto.TransactionRecord, 0, len(transactions)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, t := range transactions {
+		res.Transactions = append(res.Transactions, toProtoTransactionRecord(t))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,253 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toProtoReversalStatus(status string) bank_proto.ReversalStatus {
+	switch status {
+	case bank.ReversalStatusNone:
+		return bank_proto.ReversalStatus_REVERSAL_STATUS_NONE
+	case bank.ReversalStatusPartial:
+		return bank_proto.ReversalStatus_REVERSAL_STATUS_PARTIAL
+	case bank.ReversalStatusFull:
+		return bank_proto.ReversalStatus_REVERSAL_STATUS_FULL
+	default:
+		return bank_proto.ReversalStatus_REVERSAL_STATUS_UNSPECIFIED
+	}
+}
+
+func toTransferStatus(state bank_proto.TransferState) string {
+	switch state {
+	case bank_proto.TransferState_TRANSFER_STATE_PENDING:
+		return bank.TransferStatusPending
+	case bank_proto.TransferState_TRANSFER_STATE_PROCESSING:
+		return bank.TransferStatusProcessing
+	case bank_proto.TransferState_TRANSFER_STATE_COMPLETED:
+		return bank.TransferStatusCompleted
+	case bank_proto.TransferState_TRANSFER_STATE_FAILED:
+		return bank.TransferStatusFailed
+	case bank_proto.TransferState_TRANSFER_STATE_REVERSED:
+		return bank.TransferStatusReversed
+	default:
+		return ""
+	}
+}
+
+func toProtoTransferState(status string) bank_proto.TransferState {
+	switch status {
+	case bank.TransferStatusPending:
+		return bank_proto.TransferState_TRANSFER_STATE_PENDING
+	case bank.TransferStatusProcessing:
+		return bank_proto.TransferState_TRANSFER_STATE_PROCESSING
+	case bank.TransferStatusCompleted:
+		return bank_proto.TransferState_TRANSFER_STATE_COMPLETED
+	case bank.TransferStatusFailed:
+		return bank_proto.TransferState_TRANSFER_STATE_FAILED
+	case bank.TransferStatusReversed:
+		return bank_proto.TransferState_TRANSFER_STATE_REVERSED
+	default:
+		return bank_proto.TransferState_TRANSFER_STATE_UNSPECIFIED
+	}
+}
+
+// optionalDateTime leaves the time of a state the transfer has not reached
+// unset
+func optionalDateTime(t time.Time) *datetime.DateTime {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timeToDateTime(t)
+}
+
+func toProtoTransferReversal(r bank.TransferReversal) *bank_proto.TransferReversal {
+	return &bank_proto.TransferReversal{
+		ReversalUuid:          r.ReversalUuid.String(),
+		TransferUuid:          r.TransferUuid.String(),
+		Currency:              r.Currency,
+		Amount:                r.Amount,
+		Reason:                r.Reason,
+		ReversedAmount:        r.ReversedAmount,
+		RemainingAmount:       r.RemainingAmount,
+		Status:                toProtoReversalStatus(r.ReversalStatus),
+		DebitTransactionUuid:  r.DebitTransactionUuid.String(),
+		CreditTransactionUuid: r.CreditTransactionUuid.String(),
+		Timestamp:             timeToDateTime(r.Timestamp),
+	}
+}
+
+// toProtoTransferFees returns the fees with their total
+func toProtoTransferFees(fees []bank.Fee) ([]*bank_proto.TransferFee, float64) {
+	res := make([]*bank_proto.TransferFee, 0, len(fees))
+
+	var total float64
+
+	for _, f := range fees {
+		res = append(res, &bank_proto.TransferFee{
+			ChargeUuid:         f.ChargeUuid.String(),
+			FeeName:            f.FeeName,
+			FeeType:            f.FeeType,
+			Amount:             f.Amount,
+			Currency:           f.Currency,
+			FeeTransactionUuid: f.FeeTransactionUuid.String(),
+		})
+		total += f.Amount
+	}
+
+	// the fees of a transfer are all in its currency
+	if len(fees) > 0 {
+		if cur, err := bank.Currencies.Find(fees[0].Currency); err == nil {
+			total = cur.Round(total)
+		}
+	}
+
+	return res, total
+}
+
+func toProtoTransfer(t bank.Transfer) *bank_proto.Transfer {
+	res := &bank_proto.Transfer{
+		TransferUuid:      t.TransferUuid.String(),
+		FromAccountNumber: t.FromAccountNumber,
+		ToAccountNumber:   t.ToAccountNumber,
+		Currency:          t.Currency,
+		Amount:            t.Amount,
+		State:             toProtoTransferState(t.Status),
+		FailureReason:     t.FailureReason,
+		ReversedAmount:    t.ReversedAmount,
+		ReversalStatus:    toProtoReversalStatus(t.ReversalStatus),
+		CreatedAt:         timeToDateTime(t.CreatedAt),
+		ProcessingAt:      optionalDateTime(t.ProcessingAt),
+		CompletedAt:       optionalDateTime(t.CompletedAt),
+		FailedAt:          optionalDateTime(t.FailedAt),
+		ReversedAt:        optionalDateTime(t.ReversedAt),
+		Reversals:         make([]*bank_proto.TransferReversal, 0, len(t.Reversals)),
+		Transactions:      make([]*bank_proto.TransferTransaction, 0, len(t.Transactions)),
+	}
+
+	for _, r := range t.Reversals {
+		res.Reversals = append(res.Reversals, toProtoTransferReversal(r))
+	}
+
+	res.Fees, _ = toProtoTransferFees(t.Fees)
+
+	for _, tt := range t.Transactions {
+		res.Transactions = append(res.Transactions, &bank_proto.TransferTransaction{
+			TransactionUuid: tt.TransactionUuid.String(),
+			AccountNumber:   tt.AccountNumber,
+			Type:            toProtoTransactionType(tt.TransactionType),
+			Amount:          tt.Amount,
+			Timestamp:       timeToDateTime(tt.Timestamp),
+			Notes:           tt.Notes,
+		})
+	}
+
+	return res
+}
+
+// ReverseTransfer is reserved to tellers and admins, a customer can not take
+// money back from the account it was sent to
+func (a *GrpcAdapter) ReverseTransfer(ctx context.Context, req *bank_proto.ReverseTransferRequest) (*bank_proto.TransferReversal, error) {
+	if err := a.authorize(ctx, auth.ActionReverseTransfer, ""); err != nil {
+		return nil, err
+	}
+
+	transferUuid, err := uuid.Parse(req.TransferUuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "transfer_uuid is not a valid UUID")
+	}
+
+	reversal, err := a.bankService.ReverseTransfer(bank.TransferReversal{
+		TransferUuid: transferUuid,
+		Amount:       req.Amount,
+		Reason:       req.Reason,
+		Origin:       originFromContext(ctx),
+	})
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoTransferReversal(reversal), nil
+}
+
+func (a *GrpcAdapter) GetTransfer(ctx context.Context, req *bank_proto.GetTransferRequest) (*bank_proto.Transfer, error) {
+	if err := a.authorize(ctx, auth.ActionReadAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	transferUuid, err := uuid.Parse(req.TransferUuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "transfer_uuid is not a valid UUID")
+	}
+
+	t, err := a.bankService.GetTransfer(req.AccountNumber, transferUuid)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	return toProtoTransfer(t), nil
+}
+
+// ListTransfers is authorized against the first account the request filters
+// on. Filters combine, so every transfer returned involves that account.
+func (a *GrpcAdapter) ListTransfers(ctx context.Context, req *bank_proto.ListTransfersRequest) (*bank_proto.TransferList, error) {
+	acct := req.AccountNumber
+	if acct == "" {
+		acct = req.FromAccountNumber
+	}
+	if acct == "" {
+		acct = req.ToAccountNumber
+	}
+
+	if err := a.authorize(ctx, auth.ActionReadAccount, acct); err != nil {
+		return nil, err
+	}
+
+	f := bank.TransferFilter{
+		AccountNumber:     req.AccountNumber,
+		FromAccountNumber: req.FromAccountNumber,
+		ToAccountNumber:   req.ToAccountNumber,
+		Status:            toTransferStatus(req.State),
+		Currency:          req.Currency,
+		MinAmount:         req.MinAmount,
+		MaxAmount:         req.MaxAmount,
+		PageToken:         req.PageToken,
+		Limit:             int(req.Limit),
+	}
+
+	if req.FromDate != nil {
+		f.From = dateToTime(req.FromDate)
+	}
+
+	if req.ToDate != nil {
+		f.To = dateToTime(req.ToDate).AddDate(0, 0, 1)
+	}
+
+	transfers, nextPageToken, err := a.bankService.ListTransfers(f)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	res := &bank_proto.TransferList{
+		Transfers:     make([]*bank_proto.Transfer, 0, len(transfers)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, t := range transfers {
+		res.Transfers = append(res.Transfers, toProtoTransfer(t))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,227 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toTransferBatchFormat(f bank_proto.TransferBatchFormat) string {
+	switch f {
+	case bank_proto.TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV:
+		return bank.TransferBatchFormatCsv
+	case bank_proto.TransferBatchFormat_TRANSFER_BATCH_FORMAT_JSON:
+		return bank.TransferBatchFormatJson
+	default:
+		return ""
+	}
+}
+
+func toProtoTransferBatchFormat(f string) bank_proto.TransferBatchFormat {
+	switch f {
+	case bank.TransferBatchFormatCsv:
+		return bank_proto.TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV
+	case bank.TransferBatchFormatJson:
+		return bank_proto.TransferBatchFormat_TRANSFER_BATCH_FORMAT_JSON
+	default:
+		return bank_proto.TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED
+	}
+}
+
+func toTransferBatchMode(m bank_proto.TransferBatchMode) string {
+	switch m {
+	case bank_proto.TransferBatchMode_TRANSFER_BATCH_MODE_ALL_OR_NOTHING:
+		return bank.TransferBatchModeAllOrNothing
+	case bank_proto.TransferBatchMode_TRANSFER_BATCH_MODE_BEST_EFFORT:
+		return bank.TransferBatchModeBestEffort
+	default:
+		return ""
+	}
+}
+
+func toProtoTransferBatchMode(m string) bank_proto.TransferBatchMode {
+	switch m {
+	case bank.TransferBatchModeAllOrNothing:
+		return bank_proto.TransferBatchMode_TRANSFER_BATCH_MODE_ALL_OR_NOTHING
+	case bank.TransferBatchModeBestEffort:
+		return bank_proto.TransferBatchMode_TRANSFER_BATCH_MODE_BEST_EFFORT
+	default:
+		return bank_proto.TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED
+	}
+}
+
+func toProtoTransferBatchStatus(s string) bank_proto.TransferBatchStatus {
+	switch s {
+	case bank.TransferBatchStatusProcessing:
+		return bank_proto.TransferBatchStatus_TRANSFER_BATCH_STATUS_PROCESSING
+	case bank.TransferBatchStatusRejected:
+		return bank_proto.TransferBatchStatus_TRANSFER_BATCH_STATUS_REJECTED
+	case bank.TransferBatchStatusCompleted:
+		return bank_proto.TransferBatchStatus_TRANSFER_BATCH_STATUS_COMPLETED
+	case bank.TransferBatchStatusPartiallyCompleted:
+		return bank_proto.TransferBatchStatus_TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED
+	case bank.TransferBatchStatusRolledBack:
+		return bank_proto.TransferBatchStatus_TRANSFER_BATCH_STATUS_ROLLED_BACK
+	case bank.TransferBatchStatusFailed:
+		return bank_proto.TransferBatchStatus_TRANSFER_BATCH_STATUS_FAILED
+	default:
+		return bank_proto.TransferBatchStatus_TRANSFER_BATCH_STATUS_UNSPECIFIED
+	}
+}
+
+func toProtoTransferBatchReport(batch bank.TransferBatch) *bank_proto.TransferBatchReport {
+	res := &bank_proto.TransferBatchReport{
+		BatchUuid:      batch.BatchUuid.String(),
+		FileName:       batch.FileName,
+		Format:         toProtoTransferBatchFormat(batch.Format),
+		Mode:           toProtoTransferBatchMode(batch.Mode),
+		Status:         toProtoTransferBatchStatus(batch.Status),
+		LineCount:      int32(len(batch.Lines)),
+		SucceededCount: int32(batch.SucceededCount),
+		FailedCount:    int32(batch.FailedCount),
+		CreatedBy:      batch.CreatedBy,
+		CreatedAt:      optionalDateTime(batch.CreatedAt),
+		CompletedAt:    optionalDateTime(batch.CompletedAt),
+		Lines:          make([]*bank_proto.TransferBatchLine, 0, len(batch.Lines)),
+	}
+
+	for _, l := range batch.Lines {
+		line := &bank_proto.TransferBatchLine{
+			LineNumber:        int32(l.LineNumber),
+			FromAccountNumber: l.FromAccountNumber,
+			ToAccountNumber:   l.ToAccountNumber,
+			Currency:          l.Currency,
+			Amount:            l.Amount,
+			Reference:         l.Reference,
+			Status:            l.Status,
+			Error:             l.Error,
+		}
+
+		if l.TransferUuid != uuid.Nil {
+			line.TransferUuid = l.TransferUuid.String()
+		}
+
+		res.Lines = append(res.Lines, line)
+	}
+
+	return res
+}
+
+// UploadTransferBatch receives a batch file in chunks. Nothing is
+// transferred before the whole file is received and every line validated.
+// The caller needs the transfer permission on every source account of the
+// file.
+func (a *GrpcAdapter) UploadTransferBatch(stream bank_proto.TransferBatchService_UploadTransferBatchServer) error {
+	ctx := stream.Context()
+
+	var header *bank_proto.TransferBatchChunk
+	var content bytes.Buffer
+
+	for {
+		chunk, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			log.Println("Error while reading from client :", err)
+			return err
+		}
+
+		if header == nil {
+			header = chunk
+		}
+
+		if content.Len()+len(chunk.Content) > bank.MaxTransferBatchSize {
+			return toGrpcStatus(fmt.Errorf("%w : more than %v bytes", bank.ErrTransferBatchTooLarge, bank.MaxTransferBatchSize))
+		}
+
+		content.Write(chunk.Content)
+	}
+
+	if header == nil {
+		return toGrpcStatus(bank.ErrTransferBatchEmpty)
+	}
+
+	format := toTransferBatchFormat(header.Format)
+	lines, err := a.bankService.ParseTransferBatch(format, content.Bytes())
+
+	if err != nil {
+		return toGrpcStatus(err)
+	}
+
+	authorized := map[string]bool{}
+
+	for _, l := range lines {
+		if l.FromAccountNumber == "" || authorized[l.FromAccountNumber] {
+			continue
+		}
+
+		if err := a.authorize(ctx, auth.ActionTransfer, l.FromAccountNumber); err != nil {
+			return err
+		}
+
+		authorized[l.FromAccountNumber] = true
+	}
+
+	batch, err := a.bankService.SubmitTransferBatch(bank.TransferBatch{
+		FileName: header.FileName,
+		Format:   format,
+		Mode:     toTransferBatchMode(header.Mode),
+		Lines:    lines,
+		Origin:   originFromContext(ctx),
+	})
+
+	if err != nil && batch.BatchUuid == uuid.Nil {
+		return toGrpcStatus(err)
+	}
+
+	return stream.SendAndClose(toProtoTransferBatchReport(batch))
+}
+
+// GetTransferBatchReport returns a batch to the principal that submitted
+// it, or to a teller or admin
+func (a *GrpcAdapter) GetTransferBatchReport(ctx context.Context, req *bank_proto.TransferBatchReportRequest) (*bank_proto.TransferBatchReport, error) {
+	batchUuid, err := uuid.Parse(req.BatchUuid)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "batch_uuid is not a valid UUID")
+	}
+
+	batch, err := a.bankService.GetTransferBatch(batchUuid)
+
+	if err != nil {
+		return nil, toGrpcStatus(err)
+	}
+
+	if p, ok := auth.FromContext(ctx); !ok || p.Subject != batch.CreatedBy {
+		if err := a.authorize(ctx, auth.ActionTransfer, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	res := toProtoTransferBatchReport(batch)
+
+	if req.ReportFormat != bank_proto.TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED {
+		content, err := a.bankService.ExportTransferBatchReport(batch, toTransferBatchFormat(req.ReportFormat))
+
+		if err != nil {
+			return nil, toGrpcStatus(err)
+		}
+
+		res.ReportFormat = req.ReportFormat
+		res.Content = content
+	}
+
+	return res, nil
+}
@@ -0,0 +1,248 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strconv"
+
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"google.golang.org/grpc/metadata"
+)
+
+// defaultTransferConcurrency is how many transfers of a stream run at the
+// same time unless the server is configured otherwise. Only the transfers
+// touching the same account keep the order they were sent in, the others
+// overlap and are answered as soon as they are done.
+const defaultTransferConcurrency = 8
+
+// transferOrderHeader lets a TransferMultiple client that needs every
+// response in request order ask for it with transferOrderStream, its
+// transfers then run one by one
+const transferOrderHeader = "x-transfer-order"
+
+const transferOrderStream = "stream"
+
+func transferOrderedByStream(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	values := md.Get(transferOrderHeader)
+
+	return len(values) > 0 && values[0] == transferOrderStream
+}
+
+// maxTransferLanes bounds the accounts a stream remembers the last transfer
+// of before finished ones are forgotten
+const maxTransferLanes = 1024
+
+type receivedTransfer struct {
+	req *bank_proto.TransferRequest
+	err error
+}
+
+type transferResult struct {
+	res *bank_proto.TransferResponse
+	err error
+}
+
+// transferStream runs the transfers of one TransferMultiple stream on up to
+// concurrency goroutines. A transfer starts only once every earlier transfer
+// of the same stream touching one of its accounts is done, so transfers of
+// an account keep the order they were sent in while unrelated ones overlap.
+type transferStream struct {
+	a               *GrpcAdapter
+	stream          bank_proto.BankService_TransferMultipleServer
+	continueOnError bool
+	// lanes holds for each account the done channel of its latest transfer
+	lanes   map[string]chan struct{}
+	results chan transferResult
+	stop    chan struct{}
+}
+
+// receive reads the stream until it ends. It is the only caller of RecvMsg
+// and blocks while the handler has no room for another transfer, which
+// leaves further messages to gRPC flow control.
+func (s *transferStream) receive(ctx context.Context, incoming chan<- receivedTransfer) {
+	defer close(incoming)
+
+	for seq := 1; ; seq++ {
+		req := &bank_proto.TransferRequest{}
+		err := s.stream.RecvMsg(req)
+
+		if err == io.EOF {
+			return
+		}
+
+		var rejected *rejectedMessageError
+
+		if err != nil && !(s.continueOnError && errors.As(err, &rejected)) {
+			select {
+			case incoming <- receivedTransfer{err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		if req.CorrelationId == "" {
+			req.CorrelationId = strconv.Itoa(seq)
+		}
+
+		select {
+		case incoming <- receivedTransfer{req: req, err: err}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatch starts the transfer of a received message after the transfers it
+// must follow
+func (s *transferStream) dispatch(ctx context.Context, m receivedTransfer) {
+	if len(s.lanes) >= maxTransferLanes {
+		for acct, done := range s.lanes {
+			select {
+			case <-done:
+				delete(s.lanes, acct)
+			default:
+			}
+		}
+	}
+
+	var after []chan struct{}
+	done := make(chan struct{})
+
+	for _, acct := range []string{m.req.FromAccountNumber, m.req.ToAccountNumber} {
+		if prev, ok := s.lanes[acct]; ok {
+			after = append(after, prev)
+		}
+		s.lanes[acct] = done
+	}
+
+	go s.run(ctx, m, after, done)
+}
+
+func (s *transferStream) run(ctx context.Context, m receivedTransfer, after []chan struct{}, done chan struct{}) {
+	// the result is queued before done is closed so that responses of the
+	// same account are sent in order
+	defer close(done)
+
+	for _, prev := range after {
+		<-prev
+	}
+
+	select {
+	case <-s.stop:
+		s.results <- transferResult{}
+		return
+	default:
+	}
+
+	r := transferResult{err: m.err}
+
+	if r.err == nil {
+		r.res, r.err = s.a.transfer(ctx, m.req)
+	}
+
+	if r.err != nil && s.continueOnError {
+		log.Printf("Transfer %v failed : %v\n", m.req.CorrelationId, r.err)
+		r = transferResult{res: failedTransferResponse(m.req, r.err)}
+	}
+
+	s.results <- r
+}
+
+// TransferMultiple processes the transfers of the stream concurrently and
+// answers each one as soon as it is done, see transferStream. A client asking
+// for stream order with transferOrderHeader gets its transfers run one by one
+// and answered in order. Responses carry the correlation id of their
+// request, requests without one get their position on the stream, starting
+// at 1. Unless per-message errors are enabled the first failure ends the
+// stream once the transfers already running are done.
+func (a *GrpcAdapter) TransferMultiple(stream bank_proto.BankService_TransferMultipleServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	concurrency := a.transferConcurrency
+	if concurrency < 1 {
+		concurrency = defaultTransferConcurrency
+	}
+
+	if transferOrderedByStream(ctx) {
+		concurrency = 1
+	}
+
+	s := &transferStream{
+		a:               a,
+		stream:          stream,
+		continueOnError: continueOnTransferError(ctx),
+		lanes:           map[string]chan struct{}{},
+		results:         make(chan transferResult, concurrency),
+		stop:            make(chan struct{}),
+	}
+
+	incoming := make(chan receivedTransfer)
+	go s.receive(ctx, incoming)
+
+	var firstErr error
+	inFlight := 0
+	receiving := true
+
+	// after a failure no new message is taken, only the transfers already
+	// running are waited for
+	for (receiving && firstErr == nil) || inFlight > 0 {
+		// no new message is taken while every slot is busy
+		next := incoming
+		if !receiving || inFlight >= concurrency || firstErr != nil {
+			next = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Client cancelled stream")
+			return nil
+		case m, ok := <-next:
+			if !ok {
+				receiving = false
+				continue
+			}
+
+			if m.req == nil {
+				log.Println("Error while reading from client :", m.err)
+				firstErr = m.err
+				close(s.stop)
+				continue
+			}
+
+			inFlight++
+			s.dispatch(ctx, m)
+		case r := <-s.results:
+			inFlight--
+
+			if r.err != nil {
+				if firstErr == nil {
+					firstErr = r.err
+					close(s.stop)
+				}
+				continue
+			}
+
+			if r.res == nil {
+				continue
+			}
+
+			if err := stream.Send(r.res); err != nil {
+				log.Println("Error while sending response to client :", err)
+				if firstErr == nil {
+					firstErr = err
+					close(s.stop)
+				}
+			}
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,370 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// transferService runs transfers that take Amount milliseconds and fail
+// when the amount is negative, any other call panics
+type transferService struct {
+	port.BankServicePort
+	mu      sync.Mutex
+	started []string
+	// block holds every transfer until it is closed, when set
+	block chan struct{}
+}
+
+func (s *transferService) Transfer(tt bank.TrasferTransaction) (uuid.UUID, bool, error) {
+	s.mu.Lock()
+	s.started = append(s.started, tt.FromAccountNumber+">"+tt.ToAccountNumber)
+	s.mu.Unlock()
+
+	if s.block != nil {
+		<-s.block
+	}
+
+	if tt.Amount < 0 {
+		return uuid.Nil, false, &bank.InsufficientFundsError{AccountNumber: tt.FromAccountNumber, Requested: -tt.Amount}
+	}
+
+	time.Sleep(time.Duration(tt.Amount) * time.Millisecond)
+
+	return uuid.New(), true, nil
+}
+
+func (s *transferService) GetTransferFees(transferUuid uuid.UUID) ([]bank.Fee, error) {
+	return nil, nil
+}
+
+func (s *transferService) startedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.started)
+}
+
+// transferMultipleStream feeds requests to TransferMultiple and records its
+// responses. Once the requests run out it ends the stream, or blocks until
+// the stream is cancelled when open is set.
+type transferMultipleStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*bank_proto.TransferRequest
+	open     bool
+	mu       sync.Mutex
+	sent     []*bank_proto.TransferResponse
+}
+
+func (s *transferMultipleStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *transferMultipleStream) RecvMsg(m any) error {
+	s.mu.Lock()
+
+	if len(s.requests) == 0 {
+		s.mu.Unlock()
+
+		if s.open {
+			<-s.ctx.Done()
+			return s.ctx.Err()
+		}
+
+		return io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	s.mu.Unlock()
+
+	*m.(*bank_proto.TransferRequest) = bank_proto.TransferRequest{
+		FromAccountNumber: req.FromAccountNumber,
+		ToAccountNumber:   req.ToAccountNumber,
+		Currency:          req.Currency,
+		Amount:            req.Amount,
+		CorrelationId:     req.CorrelationId,
+	}
+
+	return nil
+}
+
+func (s *transferMultipleStream) Recv() (*bank_proto.TransferRequest, error) {
+	req := &bank_proto.TransferRequest{}
+	return req, s.RecvMsg(req)
+}
+
+func (s *transferMultipleStream) Send(res *bank_proto.TransferResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = append(s.sent, res)
+
+	return nil
+}
+
+func (s *transferMultipleStream) correlationIds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []string
+	for _, res := range s.sent {
+		ids = append(ids, res.CorrelationId)
+	}
+
+	return ids
+}
+
+func transferRequest(id string, from string, to string, ms float64) *bank_proto.TransferRequest {
+	return &bank_proto.TransferRequest{
+		FromAccountNumber: from,
+		ToAccountNumber:   to,
+		Currency:          "USD",
+		Amount:            ms,
+		CorrelationId:     id,
+	}
+}
+
+func transferStreamContext(headers ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(headers...))
+}
+
+func indexOf(ids []string, id string) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func TestTransferMultipleOrder(t *testing.T) {
+	// the slow transfers come first, run concurrently they finish last
+	requests := []*bank_proto.TransferRequest{
+		transferRequest("a1", "A", "B", 40),
+		transferRequest("c1", "C", "D", 30),
+		transferRequest("a2", "B", "A", 1),
+		transferRequest("e1", "E", "F", 1),
+		transferRequest("c2", "D", "C", 1),
+	}
+
+	tests := []struct {
+		name        string
+		concurrency int
+		ctx         context.Context
+		// inOrder requires every response in request order, otherwise only
+		// the responses of the same account must be
+		inOrder bool
+	}{
+		{"default", 0, transferStreamContext(), false},
+		{"error mode header only", 0, transferStreamContext(transferErrorModeHeader, transferErrorModeContinue), false},
+		{"stream order header", 0, transferStreamContext(transferOrderHeader, transferOrderStream), true},
+		{"stream order header despite configured concurrency", 4, transferStreamContext(transferOrderHeader, transferOrderStream), true},
+		{"configured concurrency", 4, transferStreamContext(), false},
+		{"configured one by one", 1, transferStreamContext(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewGrpcAdapter(&transferService{}, 0, WithoutAuth(), WithTransferConcurrency(tt.concurrency))
+			stream := &transferMultipleStream{ctx: tt.ctx, requests: requests}
+
+			if err := a.TransferMultiple(stream); err != nil {
+				t.Fatalf("TransferMultiple() = %v", err)
+			}
+
+			ids := stream.correlationIds()
+
+			if len(ids) != len(requests) {
+				t.Fatalf("responses = %v, want %v of them", ids, len(requests))
+			}
+
+			if tt.inOrder {
+				for i, req := range requests {
+					if ids[i] != req.CorrelationId {
+						t.Fatalf("responses = %v, want them in request order", ids)
+					}
+				}
+				return
+			}
+
+			for _, pair := range [][2]string{{"a1", "a2"}, {"c1", "c2"}} {
+				if indexOf(ids, pair[0]) > indexOf(ids, pair[1]) {
+					t.Errorf("responses = %v, want %v before %v", ids, pair[0], pair[1])
+				}
+			}
+
+			if indexOf(ids, "e1") > indexOf(ids, "a1") {
+				t.Errorf("responses = %v, want the unrelated e1 before the slow a1", ids)
+			}
+		})
+	}
+}
+
+func TestTransferMultipleFailFast(t *testing.T) {
+	requests := []*bank_proto.TransferRequest{
+		transferRequest("1", "A", "B", 1),
+		transferRequest("2", "C", "D", -1),
+		transferRequest("3", "E", "F", 1),
+		transferRequest("4", "G", "H", 1),
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+		// wantIds are the responses in order, nil when the transfers overlap
+		// and any response but the failed one may be sent
+		wantIds     []string
+		wantStarted int
+	}{
+		{"first failure ends the stream", transferStreamContext(transferOrderHeader, transferOrderStream), codes.FailedPrecondition, []string{"1"}, 2},
+		{"first failure ends the concurrent stream", transferStreamContext(), codes.FailedPrecondition, nil, 0},
+		{"failures reported per message", transferStreamContext(transferOrderHeader, transferOrderStream, transferErrorModeHeader, transferErrorModeContinue), codes.OK, []string{"1", "2", "3", "4"}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &transferService{}
+			a := NewGrpcAdapter(svc, 0, WithoutAuth())
+			stream := &transferMultipleStream{ctx: tt.ctx, requests: requests}
+
+			err := a.TransferMultiple(stream)
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("TransferMultiple() = %v, want %v", err, tt.wantCode)
+			}
+
+			ids := stream.correlationIds()
+
+			if tt.wantIds == nil {
+				if indexOf(ids, "2") >= 0 {
+					t.Errorf("responses = %v, want none for the failed transfer", ids)
+				}
+				return
+			}
+
+			if len(ids) != len(tt.wantIds) {
+				t.Fatalf("responses = %v, want %v", ids, tt.wantIds)
+			}
+
+			for i := range ids {
+				if ids[i] != tt.wantIds[i] {
+					t.Fatalf("responses = %v, want %v", ids, tt.wantIds)
+				}
+			}
+
+			if n := svc.startedCount(); n != tt.wantStarted {
+				t.Errorf("transfers started = %v, want %v", n, tt.wantStarted)
+			}
+		})
+	}
+}
+
+func TestTransferMultipleCancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+	}{
+		{"concurrent", nil},
+		{"stream order", []string{transferOrderHeader, transferOrderStream}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &transferService{block: make(chan struct{})}
+			defer close(svc.block)
+
+			ctx, cancel := context.WithCancel(transferStreamContext(tt.headers...))
+			a := NewGrpcAdapter(svc, 0, WithoutAuth())
+			stream := &transferMultipleStream{
+				ctx:      ctx,
+				requests: []*bank_proto.TransferRequest{transferRequest("1", "A", "B", 1), transferRequest("2", "C", "D", 1)},
+				open:     true,
+			}
+
+			done := make(chan error, 1)
+			go func() { done <- a.TransferMultiple(stream) }()
+
+			// wait for a transfer to be running before the client goes away
+			for svc.startedCount() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			cancel()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("TransferMultiple() = %v, want nil", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("TransferMultiple() did not return after the stream was cancelled")
+			}
+
+			if ids := stream.correlationIds(); len(ids) != 0 {
+				t.Errorf("responses = %v, want none", ids)
+			}
+		})
+	}
+}
+
+func TestTransferMultipleErrorMode(t *testing.T) {
+	requests := []*bank_proto.TransferRequest{
+		transferRequest("1", "A", "B", 1),
+		transferRequest("2", "C", "D", -5),
+		transferRequest("3", "E", "F", 1),
+	}
+
+	a := NewGrpcAdapter(&transferService{}, 0, WithoutAuth())
+	stream := &transferMultipleStream{
+		ctx:      transferStreamContext(transferErrorModeHeader, transferErrorModeContinue),
+		requests: requests,
+	}
+
+	if err := a.TransferMultiple(stream); err != nil {
+		t.Fatalf("TransferMultiple() = %v, want nil", err)
+	}
+
+	if ids := stream.correlationIds(); len(ids) != len(requests) {
+		t.Fatalf("responses = %v, want one for each of the %v requests", ids, len(requests))
+	}
+
+	for _, res := range stream.sent {
+		if res.CorrelationId != "2" {
+			if res.Status != bank_proto.TransferStatus_TRANSFER_STATUS_SUCCESS || res.Error != nil {
+				t.Errorf("response %v = %v, %v, want success", res.CorrelationId, res.Status, res.Error)
+			}
+			continue
+		}
+
+		if res.Status != bank_proto.TransferStatus_TRANSFER_STATUS_FAIL {
+			t.Errorf("response 2 status = %v, want %v", res.Status, bank_proto.TransferStatus_TRANSFER_STATUS_FAIL)
+		}
+
+		if res.Error == nil {
+			t.Fatal("response 2 error = nil, want the failure")
+		}
+
+		if res.Error.Code != codes.FailedPrecondition.String() || res.Error.Reason != bank.ReasonInsufficientFunds {
+			t.Errorf("response 2 error = %v %v, want %v %v", res.Error.Code, res.Error.Reason, codes.FailedPrecondition, bank.ReasonInsufficientFunds)
+		}
+
+		if res.FromAccountNumber != "C" || res.Amount != -5 {
+			t.Errorf("response 2 = %v %v, want the request's C -5", res.FromAccountNumber, res.Amount)
+		}
+	}
+}
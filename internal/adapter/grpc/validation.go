@@ -0,0 +1,499 @@
+package grpc
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/grpc/codes"
+)
+
+var accountNumberPattern = regexp.MustCompile(`^[0-9]{1,20}$`)
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// rule is a single declarative check on a request field. violated returns a
+// non-empty description when the request breaks the rule.
+type rule[T any] struct {
+	field    string
+	violated func(req T) string
+}
+
+func check[T any](req T, rules []rule[T]) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	for _, r := range rules {
+		if desc := r.violated(req); desc != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       r.field,
+				Description: desc,
+			})
+		}
+	}
+
+	return violations
+}
+
+func invalidAccountNumber(acct string) string {
+	if acct == "" {
+		return "account number is required"
+	}
+
+	if !accountNumberPattern.MatchString(acct) {
+		return "account number must be 1 to 20 digits"
+	}
+
+	return ""
+}
+
+func invalidCurrency(cur string) string {
+	if cur == "" {
+		return "currency is required"
+	}
+
+	if !currencyPattern.MatchString(cur) {
+		return "currency must be a 3 letter upper case ISO 4217 code"
+	}
+
+	if _, err := bank.Currencies.FindEnabled(cur); err != nil {
+		return fmt.Sprintf("currency %v : %v", cur, err)
+	}
+
+	return ""
+}
+
+func invalidAmount(amount float64) string {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return "amount must be a finite number"
+	}
+
+	if amount <= 0 {
+		return "amount must be greater than zero"
+	}
+
+	return ""
+}
+
+func invalidTransactionType(t bank_proto.TransactionType) string {
+	switch t {
+	case bank_proto.TransactionType_TRANSACION_TYPE_IN, bank_proto.TransactionType_TRANSACION_TYPE_OUT:
+		return ""
+	default:
+		return "transaction type must be IN or OUT"
+	}
+}
+
+func invalidDate(d *date.Date) string {
+	if d == nil {
+		return "date is required"
+	}
+
+	t := time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, time.UTC)
+	if d.Year < 1 || t.Year() != int(d.Year) || t.Month() != time.Month(d.Month) || t.Day() != int(d.Day) {
+		return "date is not a valid calendar date"
+	}
+
+	return ""
+}
+
+// invalidDateTime accepts a nil timestamp, handlers fall back to the current time
+func invalidDateTime(dt *datetime.DateTime) string {
+	if dt == nil {
+		return ""
+	}
+
+	if desc := invalidDate(&date.Date{Year: dt.Year, Month: dt.Month, Day: dt.Day}); desc != "" {
+		return desc
+	}
+
+	if dt.Hours < 0 || dt.Hours > 23 || dt.Minutes < 0 || dt.Minutes > 59 ||
+		dt.Seconds < 0 || dt.Seconds > 59 || dt.Nanos < 0 || dt.Nanos > 999999999 {
+		return "time of day is not valid"
+	}
+
+	if tz := dt.GetTimeZone(); tz != nil && tz.Id != "" {
+		if _, err := time.LoadLocation(tz.Id); err != nil {
+			return "time zone is not valid"
+		}
+	}
+
+	return ""
+}
+
+func invalidTransactionMetadata(m *bank_proto.TransactionMetadata) string {
+	if m == nil {
+		return ""
+	}
+
+	if err := toTransactionMetadata(m).Normalize().Validate(); err != nil {
+		return err.Error()
+	}
+
+	return ""
+}
+
+// invalidPeriod only reports an ordering problem once both dates are valid,
+// the dates themselves are reported by their own rules
+func invalidPeriod(from *date.Date, to *date.Date) string {
+	if invalidDate(from) != "" || invalidDate(to) != "" {
+		return ""
+	}
+
+	if dateToTime(to).Before(dateToTime(from)) {
+		return "to_date must not be before from_date"
+	}
+
+	return ""
+}
+
+var currentBalanceRequestRules = []rule[*bank_proto.CurrentBalanceRequest]{
+	{"account_number", func(r *bank_proto.CurrentBalanceRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+}
+
+var exchangeRateRequestRules = []rule[*bank_proto.ExchangeRateRequest]{
+	{"from_currency", func(r *bank_proto.ExchangeRateRequest) string { return invalidCurrency(r.FromCurrency) }},
+	{"to_currency", func(r *bank_proto.ExchangeRateRequest) string { return invalidCurrency(r.ToCurrency) }},
+}
+
+var transactionRules = []rule[*bank_proto.Transaction]{
+	{"account_number", func(r *bank_proto.Transaction) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"type", func(r *bank_proto.Transaction) string { return invalidTransactionType(r.Type) }},
+	{"amount", func(r *bank_proto.Transaction) string { return invalidAmount(r.Amount) }},
+	{"timestamp", func(r *bank_proto.Transaction) string { return invalidDateTime(r.Timestamp) }},
+	{"metadata", func(r *bank_proto.Transaction) string { return invalidTransactionMetadata(r.Metadata) }},
+}
+
+var listTransactionsRequestRules = []rule[*bank_proto.ListTransactionsRequest]{
+	{"account_number", func(r *bank_proto.ListTransactionsRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"from_date", func(r *bank_proto.ListTransactionsRequest) string {
+		if r.FromDate == nil {
+			return ""
+		}
+		return invalidDate(r.FromDate)
+	}},
+	{"to_date", func(r *bank_proto.ListTransactionsRequest) string {
+		if r.ToDate == nil {
+			return ""
+		}
+		return invalidDate(r.ToDate)
+	}},
+	{"to_date", func(r *bank_proto.ListTransactionsRequest) string {
+		if r.FromDate == nil || r.ToDate == nil {
+			return ""
+		}
+		return invalidPeriod(r.FromDate, r.ToDate)
+	}},
+	{"limit", func(r *bank_proto.ListTransactionsRequest) string {
+		if r.Limit < 0 {
+			return "limit must not be negative"
+		}
+		return ""
+	}},
+}
+
+var transferRequestRules = []rule[*bank_proto.TransferRequest]{
+	{"from_account_number", func(r *bank_proto.TransferRequest) string { return invalidAccountNumber(r.FromAccountNumber) }},
+	{"to_account_number", func(r *bank_proto.TransferRequest) string { return invalidAccountNumber(r.ToAccountNumber) }},
+	{"to_account_number", func(r *bank_proto.TransferRequest) string {
+		if r.FromAccountNumber != "" && r.FromAccountNumber == r.ToAccountNumber {
+			return "destination account must be different from source account"
+		}
+		return ""
+	}},
+	{"currency", func(r *bank_proto.TransferRequest) string { return invalidCurrency(r.Currency) }},
+	{"amount", func(r *bank_proto.TransferRequest) string { return invalidAmount(r.Amount) }},
+}
+
+var statementRequestRules = []rule[*bank_proto.StatementRequest]{
+	{"account_number", func(r *bank_proto.StatementRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"from_date", func(r *bank_proto.StatementRequest) string { return invalidDate(r.FromDate) }},
+	{"to_date", func(r *bank_proto.StatementRequest) string { return invalidDate(r.ToDate) }},
+	{"to_date", func(r *bank_proto.StatementRequest) string { return invalidPeriod(r.FromDate, r.ToDate) }},
+	{"format", func(r *bank_proto.StatementRequest) string {
+		if _, ok := bank_proto.StatementFormat_name[int32(r.Format)]; !ok {
+			return "unknown statement format"
+		}
+		return ""
+	}},
+}
+
+var transactionSummaryRequestRules = []rule[*bank_proto.TransactionSummaryRequest]{
+	{"account_number", func(r *bank_proto.TransactionSummaryRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"period", func(r *bank_proto.TransactionSummaryRequest) string {
+		if _, ok := bank_proto.SummaryPeriod_name[int32(r.Period)]; !ok {
+			return "unknown summary period"
+		}
+		return ""
+	}},
+	{"from_date", func(r *bank_proto.TransactionSummaryRequest) string { return invalidDate(r.FromDate) }},
+	{"to_date", func(r *bank_proto.TransactionSummaryRequest) string { return invalidDate(r.ToDate) }},
+	{"to_date", func(r *bank_proto.TransactionSummaryRequest) string { return invalidPeriod(r.FromDate, r.ToDate) }},
+}
+
+var auditEventRequestRules = []rule[*bank_proto.AuditEventRequest]{
+	{"account_number", func(r *bank_proto.AuditEventRequest) string {
+		if r.AccountNumber == "" {
+			return ""
+		}
+		return invalidAccountNumber(r.AccountNumber)
+	}},
+	{"from_date", func(r *bank_proto.AuditEventRequest) string {
+		if r.FromDate == nil {
+			return ""
+		}
+		return invalidDate(r.FromDate)
+	}},
+	{"to_date", func(r *bank_proto.AuditEventRequest) string {
+		if r.ToDate == nil {
+			return ""
+		}
+		return invalidDate(r.ToDate)
+	}},
+	{"to_date", func(r *bank_proto.AuditEventRequest) string {
+		if r.FromDate == nil || r.ToDate == nil {
+			return ""
+		}
+		return invalidPeriod(r.FromDate, r.ToDate)
+	}},
+	{"after_sequence", func(r *bank_proto.AuditEventRequest) string {
+		if r.AfterSequence < 0 {
+			return "after_sequence must not be negative"
+		}
+		return ""
+	}},
+	{"limit", func(r *bank_proto.AuditEventRequest) string {
+		if r.Limit < 0 {
+			return "limit must not be negative"
+		}
+		return ""
+	}},
+}
+
+func invalidHoldUuid(holdUuid string) string {
+	if holdUuid == "" {
+		return "hold uuid is required"
+	}
+
+	if _, err := uuid.Parse(holdUuid); err != nil {
+		return "hold uuid is not a valid UUID"
+	}
+
+	return ""
+}
+
+var placeHoldRequestRules = []rule[*bank_proto.PlaceHoldRequest]{
+	{"account_number", func(r *bank_proto.PlaceHoldRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"currency", func(r *bank_proto.PlaceHoldRequest) string { return invalidCurrency(r.Currency) }},
+	{"amount", func(r *bank_proto.PlaceHoldRequest) string { return invalidAmount(r.Amount) }},
+	{"expires_at", func(r *bank_proto.PlaceHoldRequest) string { return invalidDateTime(r.ExpiresAt) }},
+}
+
+var captureHoldRequestRules = []rule[*bank_proto.CaptureHoldRequest]{
+	{"account_number", func(r *bank_proto.CaptureHoldRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"hold_uuid", func(r *bank_proto.CaptureHoldRequest) string { return invalidHoldUuid(r.HoldUuid) }},
+	{"amount", func(r *bank_proto.CaptureHoldRequest) string {
+		if r.Amount == 0 {
+			return ""
+		}
+		return invalidAmount(r.Amount)
+	}},
+}
+
+var releaseHoldRequestRules = []rule[*bank_proto.ReleaseHoldRequest]{
+	{"account_number", func(r *bank_proto.ReleaseHoldRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"hold_uuid", func(r *bank_proto.ReleaseHoldRequest) string { return invalidHoldUuid(r.HoldUuid) }},
+}
+
+func invalidScheduleUuid(scheduleUuid string) string {
+	if scheduleUuid == "" {
+		return "schedule uuid is required"
+	}
+
+	if _, err := uuid.Parse(scheduleUuid); err != nil {
+		return "schedule uuid is not a valid UUID"
+	}
+
+	return ""
+}
+
+var scheduledTransferRequestRules = []rule[*bank_proto.ScheduledTransferRequest]{
+	{"from_account_number", func(r *bank_proto.ScheduledTransferRequest) string { return invalidAccountNumber(r.FromAccountNumber) }},
+	{"to_account_number", func(r *bank_proto.ScheduledTransferRequest) string { return invalidAccountNumber(r.ToAccountNumber) }},
+	{"to_account_number", func(r *bank_proto.ScheduledTransferRequest) string {
+		if r.FromAccountNumber != "" && r.FromAccountNumber == r.ToAccountNumber {
+			return "destination account must be different from source account"
+		}
+		return ""
+	}},
+	{"currency", func(r *bank_proto.ScheduledTransferRequest) string { return invalidCurrency(r.Currency) }},
+	{"amount", func(r *bank_proto.ScheduledTransferRequest) string { return invalidAmount(r.Amount) }},
+	{"frequency", func(r *bank_proto.ScheduledTransferRequest) string {
+		if toScheduleFrequency(r.Frequency) == "" {
+			return "frequency must be ONCE, DAILY, WEEKLY or MONTHLY"
+		}
+		return ""
+	}},
+	{"interval", func(r *bank_proto.ScheduledTransferRequest) string {
+		if r.Interval < 0 {
+			return "interval must not be negative"
+		}
+		return ""
+	}},
+	{"start_at", func(r *bank_proto.ScheduledTransferRequest) string { return invalidDateTime(r.StartAt) }},
+	{"end_at", func(r *bank_proto.ScheduledTransferRequest) string { return invalidDateTime(r.EndAt) }},
+}
+
+var scheduledTransferLookupRules = []rule[*bank_proto.ScheduledTransferLookup]{
+	{"account_number", func(r *bank_proto.ScheduledTransferLookup) string { return invalidAccountNumber(r.AccountNumber) }},
+	{"schedule_uuid", func(r *bank_proto.ScheduledTransferLookup) string { return invalidScheduleUuid(r.ScheduleUuid) }},
+}
+
+var scheduledTransferListRequestRules = []rule[*bank_proto.ScheduledTransferListRequest]{
+	{"account_number", func(r *bank_proto.ScheduledTransferListRequest) string { return invalidAccountNumber(r.AccountNumber) }},
+}
+
+func invalidTransferUuid(transferUuid string) string {
+	if transferUuid == "" {
+		return "transfer uuid is required"
+	}
+
+	if _, err := uuid.Parse(transferUuid); err != nil {
+		return "transfer uuid is not a valid UUID"
+	}
+
+	return ""
+}
+
+var reverseTransferRequestRules = []rule[*bank_proto.ReverseTransferRequest]{
+	{"transfer_uuid", func(r *bank_proto.ReverseTransferRequest) string { return invalidTransferUuid(r.TransferUuid) }},
+	{"amount", func(r *bank_proto.ReverseTransferRequest) string {
+		if r.Amount == 0 {
+			return ""
+		}
+		return invalidAmount(r.Amount)
+	}},
+}
+
+var getTransferRequestRules = []rule[*bank_proto.GetTransferRequest]{
+	{"transfer_uuid", func(r *bank_proto.GetTransferRequest) string { return invalidTransferUuid(r.TransferUuid) }},
+	{"account_number", func(r *bank_proto.GetTransferRequest) string { return optionalAccountNumber(r.AccountNumber) }},
+}
+
+// optionalAccountNumber accepts an empty account number in filters
+func optionalAccountNumber(acct string) string {
+	if acct == "" {
+		return ""
+	}
+	return invalidAccountNumber(acct)
+}
+
+var listTransfersRequestRules = []rule[*bank_proto.ListTransfersRequest]{
+	{"account_number", func(r *bank_proto.ListTransfersRequest) string { return optionalAccountNumber(r.AccountNumber) }},
+	{"from_account_number", func(r *bank_proto.ListTransfersRequest) string { return optionalAccountNumber(r.FromAccountNumber) }},
+	{"to_account_number", func(r *bank_proto.ListTransfersRequest) string { return optionalAccountNumber(r.ToAccountNumber) }},
+	{"currency", func(r *bank_proto.ListTransfersRequest) string {
+		if r.Currency == "" {
+			return ""
+		}
+		return invalidCurrency(r.Currency)
+	}},
+	{"min_amount", func(r *bank_proto.ListTransfersRequest) string {
+		if r.MinAmount < 0 {
+			return "min_amount must not be negative"
+		}
+		return ""
+	}},
+	{"max_amount", func(r *bank_proto.ListTransfersRequest) string {
+		if r.MaxAmount < 0 {
+			return "max_amount must not be negative"
+		}
+		if r.MaxAmount > 0 && r.MaxAmount < r.MinAmount {
+			return "max_amount must not be less than min_amount"
+		}
+		return ""
+	}},
+	{"from_date", func(r *bank_proto.ListTransfersRequest) string {
+		if r.FromDate == nil {
+			return ""
+		}
+		return invalidDate(r.FromDate)
+	}},
+	{"to_date", func(r *bank_proto.ListTransfersRequest) string {
+		if r.ToDate == nil {
+			return ""
+		}
+		return invalidDate(r.ToDate)
+	}},
+	{"to_date", func(r *bank_proto.ListTransfersRequest) string {
+		if r.FromDate == nil || r.ToDate == nil {
+			return ""
+		}
+		return invalidPeriod(r.FromDate, r.ToDate)
+	}},
+	{"limit", func(r *bank_proto.ListTransfersRequest) string {
+		if r.Limit < 0 {
+			return "limit must not be negative"
+		}
+		return ""
+	}},
+}
+
+// validateRequest checks every rule registered for the request type and
+// reports all violations at once. Request types without rules are accepted.
+func validateRequest(req interface{}) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	switch r := req.(type) {
+	case *bank_proto.CurrentBalanceRequest:
+		violations = check(r, currentBalanceRequestRules)
+	case *bank_proto.ExchangeRateRequest:
+		violations = check(r, exchangeRateRequestRules)
+	case *bank_proto.Transaction:
+		violations = check(r, transactionRules)
+	case *bank_proto.TransferRequest:
+		violations = check(r, transferRequestRules)
+	case *bank_proto.StatementRequest:
+		violations = check(r, statementRequestRules)
+	case *bank_proto.TransactionSummaryRequest:
+		violations = check(r, transactionSummaryRequestRules)
+	case *bank_proto.AuditEventRequest:
+		violations = check(r, auditEventRequestRules)
+	case *bank_proto.PlaceHoldRequest:
+		violations = check(r, placeHoldRequestRules)
+	case *bank_proto.CaptureHoldRequest:
+		violations = check(r, captureHoldRequestRules)
+	case *bank_proto.ReleaseHoldRequest:
+		violations = check(r, releaseHoldRequestRules)
+	case *bank_proto.ScheduledTransferRequest:
+		violations = check(r, scheduledTransferRequestRules)
+	case *bank_proto.ScheduledTransferLookup:
+		violations = check(r, scheduledTransferLookupRules)
+	case *bank_proto.ScheduledTransferListRequest:
+		violations = check(r, scheduledTransferListRequestRules)
+	case *bank_proto.ReverseTransferRequest:
+		violations = check(r, reverseTransferRequestRules)
+	case *bank_proto.GetTransferRequest:
+		violations = check(r, getTransferRequestRules)
+	case *bank_proto.ListTransfersRequest:
+		violations = check(r, listTransfersRequestRules)
+	case *bank_proto.ListTransactionsRequest:
+		violations = check(r, listTransactionsRequestRules)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return newStatus(codes.InvalidArgument, "invalid request",
+		errorInfo(bank.ReasonInvalidArgument, nil),
+		&errdetails.BadRequest{
+			FieldViolations: violations,
+		},
+	)
+}
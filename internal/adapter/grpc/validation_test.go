@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	bank_proto "github.com/abhilashdk2016/my-grpc-go-server/protogen/go/bank-proto"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// violatedFields returns the fields of the BadRequest detail of err in order
+func violatedFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	s := status.Convert(err)
+	if s.Code() != codes.InvalidArgument {
+		t.Fatalf("validateRequest() code = %v, want %v", s.Code(), codes.InvalidArgument)
+	}
+
+	var fields []string
+	for _, d := range s.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		t.Fatalf("validateRequest() = %v without field violations", err)
+	}
+
+	return fields
+}
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		want []string
+	}{
+		{"valid balance request", &bank_proto.CurrentBalanceRequest{AccountNumber: "7835697001"}, nil},
+		{"missing account number", &bank_proto.CurrentBalanceRequest{}, []string{"account_number"}},
+		{"account number with letters", &bank_proto.CurrentBalanceRequest{AccountNumber: "78356970A1"}, []string{"account_number"}},
+		{"account number too long", &bank_proto.CurrentBalanceRequest{AccountNumber: "123456789012345678901"}, []string{"account_number"}},
+		{"valid exchange rate request", &bank_proto.ExchangeRateRequest{FromCurrency: "USD", ToCurrency: "EUR"}, nil},
+		{"lower case and disabled currency", &bank_proto.ExchangeRateRequest{FromCurrency: "usd", ToCurrency: "KWD"}, []string{"from_currency", "to_currency"}},
+		{"valid transaction", &bank_proto.Transaction{
+			AccountNumber: "7835697001",
+			Type:          bank_proto.TransactionType_TRANSACION_TYPE_IN,
+			Amount:        10,
+		}, nil},
+		{"every transaction field invalid", &bank_proto.Transaction{
+			Type:      bank_proto.TransactionType_TRANSACION_TYPE_UNSPECIFIED,
+			Amount:    math.NaN(),
+			Timestamp: &datetime.DateTime{Year: 2024, Month: 2, Day: 30},
+		}, []string{"account_number", "type", "amount", "timestamp"}},
+		{"transaction time of day", &bank_proto.Transaction{
+			AccountNumber: "7835697001",
+			Type:          bank_proto.TransactionType_TRANSACION_TYPE_OUT,
+			Amount:        10,
+			Timestamp:     &datetime.DateTime{Year: 2024, Month: 2, Day: 29, Hours: 24},
+		}, []string{"timestamp"}},
+		{"transaction time zone", &bank_proto.Transaction{
+			AccountNumber: "7835697001",
+			Type:          bank_proto.TransactionType_TRANSACION_TYPE_OUT,
+			Amount:        10,
+			Timestamp: &datetime.DateTime{Year: 2024, Month: 2, Day: 29,
+				TimeOffset: &datetime.DateTime_TimeZone{TimeZone: &datetime.TimeZone{Id: "Mars/Olympus"}}},
+		}, []string{"timestamp"}},
+		{"valid transfer", &bank_proto.TransferRequest{FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: 1}, nil},
+		{"transfer to the same account", &bank_proto.TransferRequest{FromAccountNumber: "7835697001", ToAccountNumber: "7835697001", Currency: "USD", Amount: 1}, []string{"to_account_number"}},
+		{"transfer of nothing", &bank_proto.TransferRequest{FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD"}, []string{"amount"}},
+		{"negative transfer", &bank_proto.TransferRequest{FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: -5}, []string{"amount"}},
+		{"infinite transfer", &bank_proto.TransferRequest{FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: math.Inf(1)}, []string{"amount"}},
+		{"statement period reversed", &bank_proto.StatementRequest{
+			AccountNumber: "7835697001",
+			FromDate:      &date.Date{Year: 2024, Month: 3, Day: 31},
+			ToDate:        &date.Date{Year: 2024, Month: 3, Day: 1},
+		}, []string{"to_date"}},
+		{"statement without dates", &bank_proto.StatementRequest{AccountNumber: "7835697001"}, []string{"from_date", "to_date"}},
+		{"list transactions without dates", &bank_proto.ListTransactionsRequest{AccountNumber: "7835697001"}, nil},
+		{"list transactions negative limit", &bank_proto.ListTransactionsRequest{AccountNumber: "7835697001", Limit: -1}, []string{"limit"}},
+		{"audit events without filters", &bank_proto.AuditEventRequest{}, nil},
+		{"capture whole hold", &bank_proto.CaptureHoldRequest{AccountNumber: "7835697001", HoldUuid: uuid.NewString()}, nil},
+		{"capture with bad hold uuid", &bank_proto.CaptureHoldRequest{AccountNumber: "7835697001", HoldUuid: "hold-1", Amount: -1}, []string{"hold_uuid", "amount"}},
+		{"reverse whole transfer", &bank_proto.ReverseTransferRequest{TransferUuid: uuid.NewString()}, nil},
+		{"reverse without transfer uuid", &bank_proto.ReverseTransferRequest{}, []string{"transfer_uuid"}},
+		{"list transfers amount range", &bank_proto.ListTransfersRequest{MinAmount: 100, MaxAmount: 10}, []string{"max_amount"}},
+		{"list transfers open ended amount", &bank_proto.ListTransfersRequest{MinAmount: 100}, nil},
+		{"request without rules", &bank_proto.ExchangeRateResponse{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := violatedFields(t, validateRequest(tt.req)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateRequest() violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidDate(t *testing.T) {
+	tests := []struct {
+		name  string
+		date  *date.Date
+		valid bool
+	}{
+		{"leap day", &date.Date{Year: 2024, Month: 2, Day: 29}, true},
+		{"leap day of a common year", &date.Date{Year: 2023, Month: 2, Day: 29}, false},
+		{"thirteenth month", &date.Date{Year: 2024, Month: 13, Day: 1}, false},
+		{"day zero", &date.Date{Year: 2024, Month: 1, Day: 0}, false},
+		{"year zero", &date.Date{Year: 0, Month: 1, Day: 1}, false},
+		{"missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := invalidDate(tt.date); (got == "") != tt.valid {
+				t.Errorf("invalidDate() = %q, want valid %v", got, tt.valid)
+			}
+		})
+	}
+}
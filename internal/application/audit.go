@@ -0,0 +1,151 @@
+package application
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAuditEventLimit = 100
+	maxAuditEventLimit     = 1000
+	auditVerifyBatchSize   = 1000
+)
+
+func toAuditEvent(e database.AuditEventOrm) dbank.AuditEvent {
+	return dbank.AuditEvent{
+		Sequence:      e.Sequence,
+		EventUuid:     e.EventUuid,
+		Timestamp:     e.EventTimestamp,
+		Actor:         e.Actor,
+		Action:        e.Action,
+		AccountNumber: e.AccountNumber,
+		Amount:        e.Amount,
+		Currency:      e.Currency,
+		RequestId:     e.RequestId,
+		ClientAddress: e.ClientAddress,
+		Outcome:       e.Outcome,
+		Details:       e.Details,
+		PrevHash:      e.PrevHash,
+		Hash:          e.Hash,
+	}
+}
+
+// recordAudit appends an audit event for an action that already happened.
+// A failure to write the event is logged but does not undo the action.
+func (b *BankService) recordAudit(origin dbank.Origin, action string, acct string, amount float64, currency string, details string, actionErr error) {
+	now := time.Now()
+	outcome := dbank.AuditOutcomeSuccess
+
+	var permissionDenied *dbank.PermissionDeniedError
+
+	if errors.As(actionErr, &permissionDenied) {
+		outcome = dbank.AuditOutcomeDenied
+	} else if actionErr != nil {
+		outcome = dbank.AuditOutcomeFailure
+	}
+
+	if actionErr != nil {
+		details = details + " : " + actionErr.Error()
+	}
+
+	actor := origin.Actor
+	if actor == "" {
+		actor = dbank.SystemActor
+	}
+
+	eventOrm := database.AuditEventOrm{
+		EventUuid:      uuid.New(),
+		EventTimestamp: now,
+		Actor:          actor,
+		Action:         action,
+		AccountNumber:  acct,
+		Amount:         dbank.AuditAmount(amount, currency),
+		Currency:       currency,
+		RequestId:      origin.RequestId,
+		ClientAddress:  origin.ClientAddress,
+		Outcome:        outcome,
+		Details:        details,
+		CreatedAt:      now,
+	}
+
+	_, err := b.db.AppendAuditEvent(eventOrm, func(e database.AuditEventOrm) string {
+		return toAuditEvent(e).ComputeHash()
+	})
+
+	if err != nil {
+		log.Printf("Can't record audit event %v for %v by %v : %v\n", action, acct, actor, err)
+	}
+}
+
+func (b *BankService) ListAuditEvents(f dbank.AuditEventFilter) ([]dbank.AuditEvent, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultAuditEventLimit
+	} else if limit > maxAuditEventLimit {
+		limit = maxAuditEventLimit
+	}
+
+	eventOrms, err := b.db.FindAuditEvents(f.Actor, f.Action, f.AccountNumber, f.From, f.To, f.AfterSequence, limit)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("audit event lookup", err)
+	}
+
+	res := make([]dbank.AuditEvent, 0, len(eventOrms))
+	for _, e := range eventOrms {
+		res = append(res, toAuditEvent(e))
+	}
+
+	return res, nil
+}
+
+// VerifyAuditChain walks the whole audit log in sequence order and checks
+// that no event was altered, removed or inserted
+func (b *BankService) VerifyAuditChain() (dbank.AuditChainVerification, error) {
+	var res dbank.AuditChainVerification
+	var prev dbank.AuditEvent
+
+	for {
+		eventOrms, err := b.db.FindAuditEvents("", "", "", time.Time{}, time.Time{}, prev.Sequence, auditVerifyBatchSize)
+
+		if err != nil {
+			return res, dbank.NewUnavailableError("audit chain verification", err)
+		}
+
+		for _, eventOrm := range eventOrms {
+			e := toAuditEvent(eventOrm)
+			res.EventsChecked++
+
+			switch {
+			case e.Sequence != prev.Sequence+1:
+				res.BrokenSequence = prev.Sequence + 1
+				res.Reason = "event missing from the chain"
+			case e.PrevHash != prev.Hash:
+				res.BrokenSequence = e.Sequence
+				res.Reason = "previous hash does not match the previous event"
+			case e.Hash != e.ComputeHash():
+				res.BrokenSequence = e.Sequence
+				res.Reason = "event content does not match its hash"
+			}
+
+			if res.Reason != "" {
+				return res, nil
+			}
+
+			prev = e
+		}
+
+		if len(eventOrms) < auditVerifyBatchSize {
+			break
+		}
+	}
+
+	res.Verified = true
+
+	return res, nil
+}
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// auditDb keeps the audit log in memory and stores amounts the way the
+// NUMERIC(18,3) column does, any other call panics
+type auditDb struct {
+	port.BankDatabasePort
+	events []database.AuditEventOrm
+}
+
+func (d *auditDb) AppendAuditEvent(e database.AuditEventOrm, hashFn func(e database.AuditEventOrm) string) (database.AuditEventOrm, error) {
+	e.Sequence = int64(len(d.events)) + 1
+	if len(d.events) > 0 {
+		e.PrevHash = d.events[len(d.events)-1].Hash
+	}
+	e.EventTimestamp = e.EventTimestamp.Truncate(time.Microsecond)
+	e.Hash = hashFn(e)
+
+	e.Amount = numeric3(e.Amount)
+	d.events = append(d.events, e)
+
+	return e, nil
+}
+
+// numeric3 rounds like Postgres casting the float parameter to NUMERIC(18,3) :
+// the shortest decimal form of the float, rounded half away from zero
+func numeric3(amount float64) float64 {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	r.Mul(r, big.NewRat(1000, 1))
+
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+
+	f, _ := new(big.Rat).SetFrac(q, big.NewInt(1000)).Float64()
+
+	return f
+}
+
+func (d *auditDb) FindAuditEvents(actor string, action string, acct string, from time.Time, to time.Time, afterSequence int64, limit int) ([]database.AuditEventOrm, error) {
+	var res []database.AuditEventOrm
+
+	for _, e := range d.events {
+		if e.Sequence > afterSequence && len(res) < limit {
+			res = append(res, e)
+		}
+	}
+
+	return res, nil
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		tamper     func(events []database.AuditEventOrm) []database.AuditEventOrm
+		wantBroken int64
+	}{
+		{"untouched", func(events []database.AuditEventOrm) []database.AuditEventOrm { return events }, 0},
+		{"amount altered", func(events []database.AuditEventOrm) []database.AuditEventOrm {
+			events[1].Amount = 1
+			return events
+		}, 2},
+		{"event removed", func(events []database.AuditEventOrm) []database.AuditEventOrm {
+			return append(events[:1], events[2:]...)
+		}, 2},
+		{"event rehashed", func(events []database.AuditEventOrm) []database.AuditEventOrm {
+			events[1].Details = "rewritten"
+			events[1].Hash = toAuditEvent(events[1]).ComputeHash()
+			return events
+		}, 3},
+		{"event inserted", func(events []database.AuditEventOrm) []database.AuditEventOrm {
+			forged := events[1]
+			forged.EventUuid = uuid.New()
+			return append(events[:2], append([]database.AuditEventOrm{forged}, events[2:]...)...)
+		}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &auditDb{}
+			b := NewBankService(db)
+
+			// amounts a float64 holds inexactly, which the database and %.3f
+			// round differently
+			b.recordAudit(dbank.Origin{Actor: "teller-1"}, dbank.AuditActionTransfer, "7835697001", 0.1+0.2, "USD", "to 7835697002", nil)
+			b.recordAudit(dbank.Origin{Actor: "teller-1"}, dbank.AuditActionTransfer, "7835697001", 1.0005, "BHD", "to 7835697002", nil)
+			b.recordAudit(dbank.Origin{}, dbank.AuditActionCreateExchangeRate, "", 83.12345, "", "USD to INR", nil)
+			b.recordAudit(dbank.Origin{Actor: "teller-2"}, dbank.AuditActionReverseTransfer, "", 5, "JPY", "", nil)
+
+			db.events = tt.tamper(db.events)
+
+			res, err := b.VerifyAuditChain()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res.Verified != (tt.wantBroken == 0) || res.BrokenSequence != tt.wantBroken {
+				t.Errorf("verification = %+v, want broken at %v", res, tt.wantBroken)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"errors"
+	"log"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+)
+
+// Authorize checks that the principal may perform action on the account.
+// Tellers and admins may act on every account, customers only on accounts
+// they own or are delegated on. The role registered for the subject is
+// authoritative, the roles carried by its credentials only count for
+// subjects that are not registered. Every decision is logged.
+func (b *BankService) Authorize(origin dbank.Origin, p auth.Principal, action string, acct string) error {
+	roles := p.Roles
+
+	principalOrm, err := b.db.GetBankPrincipal(p.Subject)
+
+	if err == nil {
+		roles = []string{principalOrm.Role}
+	} else if !errors.Is(err, database.ErrRecordNotFound) {
+		logAuthorizationDecision(p, action, acct, false, "principal lookup failed")
+		return dbank.NewUnavailableError("principal lookup", err)
+	}
+
+	for _, r := range roles {
+		if r == auth.RoleAdmin || r == auth.RoleTeller {
+			logAuthorizationDecision(p, action, acct, true, "role "+r)
+			return nil
+		}
+	}
+
+	// actions not tied to a single account are reserved to tellers and admins
+	if acct == "" {
+		logAuthorizationDecision(p, action, acct, false, "action requires teller or admin role")
+
+		deniedErr := &dbank.PermissionDeniedError{
+			Subject: p.Subject,
+			Action:  action,
+		}
+		b.recordAudit(origin, dbank.AuditActionAuthorization, acct, 0, "", action, deniedErr)
+
+		return deniedErr
+	}
+
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		logAuthorizationDecision(p, action, acct, false, "account lookup failed")
+		return accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	accessOrm, err := b.db.GetBankAccountAccess(bankAccountOrm.AccountUuid, p.Subject)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		logAuthorizationDecision(p, action, acct, false, "no access to account")
+
+		deniedErr := &dbank.PermissionDeniedError{
+			Subject:       p.Subject,
+			Action:        action,
+			AccountNumber: acct,
+		}
+		b.recordAudit(origin, dbank.AuditActionAuthorization, acct, 0, "", action, deniedErr)
+
+		return deniedErr
+	} else if err != nil {
+		logAuthorizationDecision(p, action, acct, false, "access lookup failed")
+		return dbank.NewUnavailableError("account access lookup", err)
+	}
+
+	logAuthorizationDecision(p, action, acct, true, "account "+accessOrm.AccessType)
+
+	return nil
+}
+
+func logAuthorizationDecision(p auth.Principal, action string, acct string, allowed bool, reason string) {
+	decision := "DENY"
+	if allowed {
+		decision = "ALLOW"
+	}
+
+	log.Printf("Authorization %v : subject=%v method=%v action=%v account=%v reason=%v\n",
+		decision, p.Subject, p.Method, action, acct, reason)
+}
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// authorizationDb serves the lookups of Authorize, any other call panics
+type authorizationDb struct {
+	port.BankDatabasePort
+	principals map[string]string
+	accounts   map[string]uuid.UUID
+	access     map[string]string
+}
+
+func (d *authorizationDb) GetBankPrincipal(subject string) (database.BankPrincipalOrm, error) {
+	role, ok := d.principals[subject]
+	if !ok {
+		return database.BankPrincipalOrm{}, database.ErrRecordNotFound
+	}
+
+	return database.BankPrincipalOrm{Subject: subject, Role: role}, nil
+}
+
+func (d *authorizationDb) GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error) {
+	accountUuid, ok := d.accounts[acct]
+	if !ok {
+		return database.BankAccountOrm{}, database.ErrRecordNotFound
+	}
+
+	return database.BankAccountOrm{AccountUuid: accountUuid, AccountNumber: acct}, nil
+}
+
+func (d *authorizationDb) GetBankAccountAccess(accountUuid uuid.UUID, subject string) (database.BankAccountAccessOrm, error) {
+	accessType, ok := d.access[accountUuid.String()+"/"+subject]
+	if !ok {
+		return database.BankAccountAccessOrm{}, database.ErrRecordNotFound
+	}
+
+	return database.BankAccountAccessOrm{AccountUuid: accountUuid, Subject: subject, AccessType: accessType}, nil
+}
+
+func (d *authorizationDb) AppendAuditEvent(e database.AuditEventOrm, hashFn func(e database.AuditEventOrm) string) (database.AuditEventOrm, error) {
+	return e, nil
+}
+
+func TestAuthorize(t *testing.T) {
+	acctUuid := uuid.New()
+
+	db := &authorizationDb{
+		principals: map[string]string{
+			"teller-1":   auth.RoleTeller,
+			"customer-1": auth.RoleCustomer,
+		},
+		accounts: map[string]uuid.UUID{"7835697001": acctUuid},
+		access:   map[string]string{acctUuid.String() + "/customer-1": auth.AccessOwner},
+	}
+
+	b := NewBankService(db)
+
+	tests := []struct {
+		name    string
+		subject string
+		roles   []string
+		action  string
+		acct    string
+		wantErr bool
+	}{
+		{"registered teller on any account", "teller-1", nil, auth.ActionTransfer, "7835697001", false},
+		{"registered teller without account", "teller-1", nil, auth.ActionReverseTransfer, "", false},
+		{"owner on own account", "customer-1", nil, auth.ActionTransfer, "7835697001", false},
+		{"owner without account", "customer-1", nil, auth.ActionReverseTransfer, "", true},
+		{"registered customer claiming admin in token", "customer-1", []string{auth.RoleAdmin}, auth.ActionReverseTransfer, "", true},
+		{"unregistered subject with admin token role", "service-1", []string{auth.RoleAdmin}, auth.ActionReverseTransfer, "", false},
+		{"unregistered subject without role", "stranger", nil, auth.ActionReadAccount, "7835697001", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := auth.Principal{Subject: tt.subject, Method: auth.MethodJwt, Roles: tt.roles}
+
+			err := b.Authorize(dbank.Origin{}, p, tt.action, tt.acct)
+
+			if tt.wantErr {
+				var denied *dbank.PermissionDeniedError
+				if !errors.As(err, &denied) {
+					t.Errorf("Authorize() = %v, want permission denied", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Authorize() = %v, want allowed", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeRegisteredRoleOverridesToken(t *testing.T) {
+	ownUuid, otherUuid := uuid.New(), uuid.New()
+
+	db := &authorizationDb{
+		principals: map[string]string{
+			"teller-1":   auth.RoleTeller,
+			"customer-1": auth.RoleCustomer,
+		},
+		accounts: map[string]uuid.UUID{"7835697001": ownUuid, "7835697002": otherUuid},
+		access:   map[string]string{ownUuid.String() + "/customer-1": auth.AccessOwner},
+	}
+
+	b := NewBankService(db)
+
+	tests := []struct {
+		name    string
+		subject string
+		roles   []string
+		acct    string
+		allowed bool
+	}{
+		{"customer claiming admin on own account", "customer-1", []string{auth.RoleAdmin}, "7835697001", true},
+		{"customer claiming admin on another account", "customer-1", []string{auth.RoleAdmin}, "7835697002", false},
+		{"customer claiming teller on another account", "customer-1", []string{auth.RoleTeller, auth.RoleCustomer}, "7835697002", false},
+		{"teller with a customer token", "teller-1", []string{auth.RoleCustomer}, "7835697002", true},
+		{"unregistered subject with the same admin token", "service-1", []string{auth.RoleAdmin}, "7835697002", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := auth.Principal{Subject: tt.subject, Method: auth.MethodJwt, Roles: tt.roles}
+
+			err := b.Authorize(dbank.Origin{}, p, auth.ActionTransfer, tt.acct)
+
+			var denied *dbank.PermissionDeniedError
+
+			if tt.allowed && err != nil {
+				t.Errorf("Authorize() = %v, want allowed", err)
+			}
+
+			if !tt.allowed && (!errors.As(err, &denied) || denied.AccountNumber != tt.acct) {
+				t.Errorf("Authorize() = %v, want permission denied on %v", err, tt.acct)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -21,17 +22,183 @@ func NewBankService(dbPort port.BankDatabasePort) *BankService {
 	}
 }
 
+// accountLookupError tells a missing account apart from a database failure.
+// sentinel identifies which account of the operation could not be found.
+func accountLookupError(acct string, err error, sentinel error) error {
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return dbank.NewAccountNotFoundError(acct, sentinel)
+	}
+
+	return dbank.NewUnavailableError("account lookup", err)
+}
+
 func (b *BankService) FindCurrentBalance(acct string) (float64, error) {
 	bankAccount, err := b.db.GetBankAccountByAccountNumber(acct)
 	if err != nil {
 		log.Println("Error in FindCurrentBalance :", err)
-		return 0, err
+		return 0, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	if cur, err := dbank.Currencies.Find(bankAccount.Currency); err == nil {
+		return cur.Round(bankAccount.CurrentBalance), nil
 	}
 
 	return bankAccount.CurrentBalance, nil
 }
 
+// availableBalance is what can be taken out of the account at ts, the ledger
+// balance plus the overdraft facility less the funds reserved by holds. It
+// also returns the held amount.
+func (b *BankService) availableBalance(bankAccountOrm database.BankAccountOrm, ts time.Time) (float64, float64, error) {
+	held, err := b.db.GetBankHoldsActiveAmount(bankAccountOrm.AccountUuid, ts)
+
+	if err != nil {
+		return 0, 0, dbank.NewUnavailableError("hold lookup", err)
+	}
+
+	return bankAccountOrm.CurrentBalance + bankAccountOrm.OverdraftLimit - held, held, nil
+}
+
+// checkDebit checks the account can pay debit out of its available balance
+// and still keep the minimum balance of its product. cause is wrapped by the
+// insufficient funds error. The check is only final when the account is
+// locked by the transaction posting the debit.
+func (b *BankService) checkDebit(products map[uuid.UUID]dbank.AccountProduct, bankAccountOrm database.BankAccountOrm, debit float64, ts time.Time, cause error) error {
+	available, held, err := b.availableBalance(bankAccountOrm, ts)
+
+	if err != nil {
+		return err
+	}
+
+	if available < debit {
+		return &dbank.InsufficientFundsError{
+			AccountNumber: bankAccountOrm.AccountNumber,
+			Available:     available,
+			Requested:     debit,
+			Err:           cause,
+		}
+	}
+
+	return b.checkProductMinBalance(products, bankAccountOrm, held, debit)
+}
+
+// portTransactor is a database whose transactions hand fn a database port
+// rather than a database adapter, e.g. an in memory database
+type portTransactor interface {
+	PortTransaction(fn func(tx port.BankDatabasePort) error) error
+}
+
+// inTransaction runs fn with a service whose database calls all belong to
+// one database transaction, rolled back when fn returns an error
+func (b *BankService) inTransaction(fn func(tb *BankService) error) error {
+	if t, ok := b.db.(portTransactor); ok {
+		return t.PortTransaction(func(tx port.BankDatabasePort) error {
+			return fn(&BankService{db: tx})
+		})
+	}
+
+	return b.db.Transaction(func(tx *database.DatabaseAdapter) error {
+		return fn(&BankService{db: tx})
+	})
+}
+
+// lockAccounts reads the accounts again and locks them until the end of the
+// transaction of b, in the order given. Balances read before the lock may
+// already be stale.
+func (b *BankService) lockAccounts(accountOrms ...database.BankAccountOrm) ([]database.BankAccountOrm, error) {
+	accountUuids := make([]uuid.UUID, 0, len(accountOrms))
+	for _, a := range accountOrms {
+		accountUuids = append(accountUuids, a.AccountUuid)
+	}
+
+	lockedOrms, err := b.db.LockBankAccounts(accountUuids)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("account lock", err)
+	}
+
+	byUuid := make(map[uuid.UUID]database.BankAccountOrm, len(lockedOrms))
+	for _, a := range lockedOrms {
+		byUuid[a.AccountUuid] = a
+	}
+
+	res := make([]database.BankAccountOrm, 0, len(accountOrms))
+
+	for _, a := range accountOrms {
+		locked, ok := byUuid[a.AccountUuid]
+
+		if !ok {
+			return nil, dbank.NewAccountNotFoundError(a.AccountNumber, dbank.ErrAccountNotFound)
+		}
+
+		res = append(res, locked)
+	}
+
+	return res, nil
+}
+
+func (b *BankService) FindAccountBalance(acct string) (dbank.AccountBalance, error) {
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+	if err != nil {
+		log.Println("Error in FindAccountBalance :", err)
+		return dbank.AccountBalance{}, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	available, held, err := b.availableBalance(bankAccountOrm, time.Now())
+
+	if err != nil {
+		return dbank.AccountBalance{}, err
+	}
+
+	round := func(amount float64) float64 { return amount }
+
+	if cur, err := dbank.Currencies.Find(bankAccountOrm.Currency); err == nil {
+		round = cur.Round
+	}
+
+	product, _, err := b.productOf(map[uuid.UUID]dbank.AccountProduct{}, bankAccountOrm)
+
+	if err != nil {
+		return dbank.AccountBalance{}, err
+	}
+
+	return dbank.AccountBalance{
+		AccountNumber:    bankAccountOrm.AccountNumber,
+		Currency:         bankAccountOrm.Currency,
+		LedgerBalance:    round(bankAccountOrm.CurrentBalance),
+		AvailableBalance: round(available),
+		HeldAmount:       round(held),
+		Overdraft:        overdraftOf(bankAccountOrm),
+		ProductCode:      product.ProductCode,
+		ProductType:      product.ProductType,
+		MinBalance:       product.MinBalance,
+	}, nil
+}
+
 func (b *BankService) CreateExchangeRate(r dbank.ExchangeRate) (uuid.UUID, error) {
+	savedUuid, err := b.createExchangeRate(r)
+	b.recordAudit(dbank.Origin{Actor: dbank.SystemActor}, dbank.AuditActionCreateExchangeRate, "", r.Rate, r.FromCurrency,
+		fmt.Sprintf("%v to %v at %v valid from %v to %v", r.FromCurrency, r.ToCurrency, r.Rate, r.ValidFromTimestamp.Format(time.RFC3339), r.ValidToTimestamp.Format(time.RFC3339)), err)
+
+	return savedUuid, err
+}
+
+// GenerateExchangeRate stores a rate of the server's own rate feed. Generated
+// rates are not audited, the feed publishes one every few seconds and each
+// audit event is appended under the lock of the single audit chain.
+func (b *BankService) GenerateExchangeRate(r dbank.ExchangeRate) (uuid.UUID, error) {
+	return b.createExchangeRate(r)
+}
+
+func (b *BankService) createExchangeRate(r dbank.ExchangeRate) (uuid.UUID, error) {
+	if _, err := dbank.Currencies.FindEnabled(r.FromCurrency); err != nil {
+		return uuid.Nil, fmt.Errorf("from currency %v : %w", r.FromCurrency, err)
+	}
+
+	if _, err := dbank.Currencies.FindEnabled(r.ToCurrency); err != nil {
+		return uuid.Nil, fmt.Errorf("to currency %v : %w", r.ToCurrency, err)
+	}
+
 	newUuid := uuid.New()
 	now := time.Now()
 
@@ -46,14 +213,35 @@ func (b *BankService) CreateExchangeRate(r dbank.ExchangeRate) (uuid.UUID, error
 		UpdatedAt:          now,
 	}
 
-	return b.db.CreateExchangeRate(exchangeRateOrm)
+	savedUuid, err := b.db.CreateExchangeRate(exchangeRateOrm)
+
+	if err != nil {
+		return uuid.Nil, dbank.NewUnavailableError("exchange rate creation", err)
+	}
+
+	return savedUuid, nil
 }
 
 func (b *BankService) FindExchangeRate(fromCur string, toCur string, ts time.Time) (float64, error) {
+	if _, err := dbank.Currencies.FindEnabled(fromCur); err != nil {
+		return 0, fmt.Errorf("from currency %v : %w", fromCur, err)
+	}
+
+	if _, err := dbank.Currencies.FindEnabled(toCur); err != nil {
+		return 0, fmt.Errorf("to currency %v : %w", toCur, err)
+	}
+
 	exchangeRate, err := b.db.GetExchangeRateAtTimestamp(fromCur, toCur, ts)
 
-	if err != nil {
-		return 0, err
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return 0, &dbank.NotFoundError{
+			Reason:   dbank.ReasonExchangeRateNotFound,
+			Resource: dbank.ResourceExchangeRate,
+			Key:      fromCur + "/" + toCur,
+			Err:      dbank.ErrExchangeRateNotFound,
+		}
+	} else if err != nil {
+		return 0, dbank.NewUnavailableError("exchange rate lookup", err)
 	}
 
 	return float64(exchangeRate.Rate), nil
@@ -70,11 +258,60 @@ func (b *BankService) CalculateTransactionSummary(tcur *dbank.TransactionSummary
 	}
 
 	tcur.SumTotal = tcur.SumIn - tcur.SumOut
+	tcur.Count++
+
+	if trans.Timestamp.After(tcur.SummaryOnDate) {
+		tcur.SummaryOnDate = trans.Timestamp
+	}
+
+	return nil
+}
+
+// transactionTimestamp returns when a transaction is dated, the client
+// timestamp if there is one and it is within the back-dating limits
+func transactionTimestamp(ts time.Time, now time.Time) (time.Time, error) {
+	if ts.IsZero() {
+		return now, nil
+	}
+
+	if ts.Before(now.Add(-dbank.MaxTransactionBackdate)) {
+		return time.Time{}, fmt.Errorf("%w : %v is more than %v ago", dbank.ErrTransactionTooOld, ts.UTC().Format(time.RFC3339), dbank.MaxTransactionBackdate)
+	}
+
+	if ts.After(now.Add(dbank.MaxTransactionClockSkew)) {
+		return time.Time{}, fmt.Errorf("%w : %v", dbank.ErrTransactionInFuture, ts.UTC().Format(time.RFC3339))
+	}
+
+	return ts, nil
+}
+
+// checkAccruedBackdate refuses a transaction dated on a day whose interest
+// the account already accrued, as the accrual would no longer match the
+// balance of that day
+func (b *BankService) checkAccruedBackdate(bankAccountOrm database.BankAccountOrm, ts time.Time) error {
+	latestOrm, err := b.db.GetLatestBankInterestAccrual(bankAccountOrm.AccountUuid)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return dbank.NewUnavailableError("interest accrual lookup", err)
+	}
+
+	if accrued := startOfDay(latestOrm.AccrualDate).AddDate(0, 0, 1); ts.Before(accrued) {
+		return fmt.Errorf("%w : interest of %v is accrued before %v", dbank.ErrTransactionTooOld, bankAccountOrm.AccountNumber, accrued.Format(time.RFC3339))
+	}
 
 	return nil
 }
 
 func (b *BankService) CreateTransaction(acct string, t dbank.Transaction) (uuid.UUID, error) {
+	savedUuid, err := b.createTransaction(acct, t)
+	b.recordAudit(t.Origin, dbank.AuditActionCreateTransaction, acct, t.Amount, "", t.TransactionType+" "+t.Notes, err)
+
+	return savedUuid, err
+}
+
+func (b *BankService) createTransaction(acct string, t dbank.Transaction) (uuid.UUID, error) {
 	newuuid := uuid.New()
 	now := time.Now()
 
@@ -82,50 +319,202 @@ func (b *BankService) CreateTransaction(acct string, t dbank.Transaction) (uuid.
 
 	if err != nil {
 		log.Printf("Can't create transaction for %v : %v\n", acct, err)
-		return uuid.Nil, fmt.Errorf("can't find account number %v : %v", acct, err.Error())
+		return uuid.Nil, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	cur, err := dbank.Currencies.FindEnabled(bankAccountOrm.Currency)
+
+	if err != nil {
+		return bankAccountOrm.AccountUuid, fmt.Errorf("account currency %v : %w", bankAccountOrm.Currency, err)
 	}
 
-	if t.TransactionType == dbank.TransactionTypeOut && bankAccountOrm.CurrentBalance < t.Amount {
-		return bankAccountOrm.AccountUuid, fmt.Errorf("insufficient account balance %v for [out] transaction amount %v", bankAccountOrm.CurrentBalance, t.Amount)
+	t.Amount = cur.Round(t.Amount)
+
+	if t.Amount <= 0 {
+		return bankAccountOrm.AccountUuid, dbank.ErrAmountBelowMinorUnit
+	}
+
+	products := map[uuid.UUID]dbank.AccountProduct{}
+
+	if err := b.checkProductCurrency(products, bankAccountOrm, cur.Code); err != nil {
+		return bankAccountOrm.AccountUuid, err
+	}
+
+	t.Metadata = t.Metadata.Normalize()
+
+	if err := t.Metadata.Validate(); err != nil {
+		return bankAccountOrm.AccountUuid, err
+	}
+
+	ts, err := transactionTimestamp(t.Timestamp, now)
+
+	if err != nil {
+		return bankAccountOrm.AccountUuid, err
+	}
+
+	if !t.Timestamp.IsZero() {
+		if err := b.checkAccruedBackdate(bankAccountOrm, ts); err != nil {
+			return bankAccountOrm.AccountUuid, err
+		}
+	}
+
+	var fees feeAssessment
+
+	if !t.FeeExempt {
+		operation := dbank.FeeOperationDeposit
+		if t.TransactionType == dbank.TransactionTypeOut {
+			operation = dbank.FeeOperationWithdrawal
+		}
+
+		if fees, err = b.assessFees(bankAccountOrm, operation, cur, t.Amount, false); err != nil {
+			return bankAccountOrm.AccountUuid, err
+		}
 	}
 
 	transactionOrm := database.BankTransactionOrm{
 		TransactionUuid:      newuuid,
 		AccountUuid:          bankAccountOrm.AccountUuid,
 		TransactionType:      t.TransactionType,
-		TransactionTimestamp: now,
+		TransactionTimestamp: ts,
 		Amount:               t.Amount,
 		Notes:                t.Notes,
+		Metadata:             toTransactionMetadataOrm(t.Metadata),
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}
 
-	savedUuid, err := b.db.CreateTransaction(bankAccountOrm, transactionOrm)
+	var savedUuid uuid.UUID
 
-	return savedUuid, err
+	err = b.inTransaction(func(tb *BankService) error {
+		locked, err := tb.lockAccounts(bankAccountOrm)
+
+		if err != nil {
+			return err
+		}
+
+		bankAccountOrm = locked[0]
+
+		// a deposit pays its own fee
+		requested := cur.Round(t.Amount + fees.total)
+		if t.TransactionType != dbank.TransactionTypeOut {
+			requested = cur.Round(fees.total - t.Amount)
+		}
+
+		if t.TransactionType == dbank.TransactionTypeOut && !t.Charge {
+			overdraftFees, err := tb.assessOverdraftFees(bankAccountOrm, cur, requested)
+
+			if err != nil {
+				return err
+			}
+
+			fees.add(cur, overdraftFees)
+			requested = cur.Round(requested + overdraftFees.total)
+		}
+
+		if (t.TransactionType == dbank.TransactionTypeOut || fees.total > 0) && !t.Charge {
+			if err := tb.checkDebit(products, bankAccountOrm, requested, now, nil); err != nil {
+				return err
+			}
+		}
+
+		if savedUuid, err = tb.db.CreateTransaction(bankAccountOrm, transactionOrm); err != nil {
+			return dbank.NewUnavailableError("transaction creation", err)
+		}
+
+		if err := tb.postFees(bankAccountOrm, fees, nil, &savedUuid, now); err != nil {
+			return err
+		}
+
+		// a back dated transaction changes the reports of the closed periods
+		// it falls in
+		if ts.Before(dbank.SummaryPeriodStart(now, dbank.SummaryPeriodDay)) {
+			if err := tb.db.DeleteBankTransactionSummaryReports(bankAccountOrm.AccountUuid, ts); err != nil {
+				return dbank.NewUnavailableError("transaction summary report invalidation", err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return bankAccountOrm.AccountUuid, err
+	}
+
+	return savedUuid, nil
 }
 
 func (b *BankService) Transfer(tt dbank.TrasferTransaction) (uuid.UUID, bool, error) {
+	transferUuid, transferSuccess, err := b.transfer(tt)
+	b.recordAudit(tt.Origin, dbank.AuditActionTransfer, tt.FromAccountNumber, tt.Amount, tt.Currency,
+		fmt.Sprintf("transfer %v to %v", transferUuid, tt.ToAccountNumber), err)
+
+	return transferUuid, transferSuccess, err
+}
+
+func (b *BankService) transfer(tt dbank.TrasferTransaction) (uuid.UUID, bool, error) {
 	now := time.Now()
 
 	fromAccountOrm, err := b.db.GetBankAccountByAccountNumber(tt.FromAccountNumber)
 
 	if err != nil {
 		log.Printf("Can't find transfer from account %v : %v\n", tt.FromAccountNumber, err)
-		return uuid.Nil, false, dbank.ErrTransferSourceAccountNotFound
+		return uuid.Nil, false, accountLookupError(tt.FromAccountNumber, err, dbank.ErrTransferSourceAccountNotFound)
 	}
 
-	if fromAccountOrm.CurrentBalance < tt.Amount {
-		return uuid.Nil, false, dbank.ErrTransferTransactionPair
+	cur, err := dbank.Currencies.FindEnabled(tt.Currency)
+
+	if err != nil {
+		return uuid.Nil, false, err
 	}
 
+	if fromAccountOrm.Currency != cur.Code {
+		return uuid.Nil, false, dbank.ErrCurrencyMismatch
+	}
+
+	tt.Amount = cur.Round(tt.Amount)
+
+	if tt.Amount <= 0 {
+		return uuid.Nil, false, dbank.ErrAmountBelowMinorUnit
+	}
+
+	// transfers never convert currencies, so FX markups don't apply yet
+	fees, err := b.assessFees(fromAccountOrm, dbank.FeeOperationTransfer, cur, tt.Amount, false)
+
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+
+	debit := cur.Round(tt.Amount + fees.total)
+
 	toAccountOrm, err := b.db.GetBankAccountByAccountNumber(tt.ToAccountNumber)
 
 	if err != nil {
 		log.Printf("Can't find transfer to account %v : %v\n", tt.ToAccountNumber, err)
-		return uuid.Nil, false, dbank.ErrTransferDestinationAccountNotFound
+		return uuid.Nil, false, accountLookupError(tt.ToAccountNumber, err, dbank.ErrTransferDestinationAccountNotFound)
+	}
+
+	if toAccountOrm.Currency != cur.Code {
+		return uuid.Nil, false, dbank.ErrCurrencyMismatch
+	}
+
+	products := map[uuid.UUID]dbank.AccountProduct{}
+
+	if err := b.checkProductTransfer(products, fromAccountOrm, toAccountOrm, cur.Code); err != nil {
+		return uuid.Nil, false, err
+	}
+
+	// checked again under the lock of the account before the money moves,
+	// rejecting early leaves no transfer behind in the common case
+	if err := b.checkDebit(products, fromAccountOrm, debit, now, dbank.ErrTransferTransactionPair); err != nil {
+		return uuid.Nil, false, err
+	}
+
+	if err := b.checkTransferLimits(fromAccountOrm, cur.Code, tt.Amount, now); err != nil {
+		return uuid.Nil, false, err
 	}
 
+	newTransferUUid := uuid.New()
+
 	fromTransactionOrm := database.BankTransactionOrm{
 		TransactionUuid:      uuid.New(),
 		TransactionTimestamp: now,
@@ -133,6 +522,7 @@ func (b *BankService) Transfer(tt dbank.TrasferTransaction) (uuid.UUID, bool, er
 		AccountUuid:          fromAccountOrm.AccountUuid,
 		Amount:               tt.Amount,
 		Notes:                "Transfer out to " + tt.ToAccountNumber,
+		TransferUuid:         &newTransferUUid,
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}
@@ -144,12 +534,11 @@ func (b *BankService) Transfer(tt dbank.TrasferTransaction) (uuid.UUID, bool, er
 		AccountUuid:          toAccountOrm.AccountUuid,
 		Amount:               tt.Amount,
 		Notes:                "Transfer in to " + tt.FromAccountNumber,
+		TransferUuid:         &newTransferUUid,
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}
 
-	newTransferUUid := uuid.New()
-
 	transferOrm := database.BankTransferOrm{
 		TransferUuid:      newTransferUUid,
 		FromAccountUuid:   fromAccountOrm.AccountUuid,
@@ -158,19 +547,306 @@ func (b *BankService) Transfer(tt dbank.TrasferTransaction) (uuid.UUID, bool, er
 		Amount:            tt.Amount,
 		TransferTimestamp: now,
 		TransferSuccess:   false,
+		ReversalStatus:    dbank.ReversalStatusNone,
+		Status:            dbank.TransferStatusPending,
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	}
 
 	if _, err := b.db.CreateTransfer(transferOrm); err != nil {
 		log.Printf("Can't create transfer from %v to %v : %v\n", tt.FromAccountNumber, tt.ToAccountNumber, err)
-		return uuid.Nil, false, dbank.ErrTransferRecordFailed
+		return uuid.Nil, false, dbank.NewUnavailableError("transfer creation", dbank.ErrTransferRecordFailed)
+	}
+
+	if err := b.transitionTransfer(newTransferUUid, dbank.TransferStatusPending, dbank.TransferStatusProcessing, "", now); err != nil {
+		return newTransferUUid, false, err
+	}
+
+	err = b.inTransaction(func(tb *BankService) error {
+		locked, err := tb.lockAccounts(fromAccountOrm, toAccountOrm)
+
+		if err != nil {
+			return err
+		}
+
+		overdraftFees, err := tb.assessOverdraftFees(locked[0], cur, debit)
+
+		if err != nil {
+			return err
+		}
+
+		if err := tb.checkDebit(products, locked[0], cur.Round(debit+overdraftFees.total), now, dbank.ErrTransferTransactionPair); err != nil {
+			return err
+		}
+
+		if err := tb.checkTransferLimits(locked[0], cur.Code, tt.Amount, now); err != nil {
+			return err
+		}
+
+		if ok, err := tb.db.CreateTransferTransactionPair(locked[0], locked[1], fromTransactionOrm, toTransactionOrm); !ok {
+			log.Printf("Can't create transfer transaction pair from %v to %v : %v\n", tt.FromAccountNumber, tt.ToAccountNumber, err)
+
+			if err == nil {
+				err = dbank.ErrTransferTransactionPair
+			}
+
+			return &dbank.TransferFailedError{TransferUuid: newTransferUUid, Err: err}
+		}
+
+		charged := fees
+		charged.add(cur, overdraftFees)
+
+		if err := tb.postFees(locked[0], charged, &newTransferUUid, nil, now); err != nil {
+			return err
+		}
+
+		return tb.transitionTransfer(newTransferUUid, dbank.TransferStatusProcessing, dbank.TransferStatusCompleted, "", time.Now())
+	})
+
+	if err != nil {
+		failureReason := err.Error()
+
+		var failed *dbank.TransferFailedError
+		if errors.As(err, &failed) {
+			failureReason = failed.Err.Error()
+		}
+
+		if err := b.transitionTransfer(newTransferUUid, dbank.TransferStatusProcessing, dbank.TransferStatusFailed, failureReason, time.Now()); err != nil {
+			log.Printf("Can't fail transfer %v : %v\n", newTransferUUid, err)
+		}
+
+		return newTransferUUid, false, err
+	}
+
+	return newTransferUUid, true, nil
+}
+
+// GenerateStatement lists the transactions of acct between from and to with
+// the balance after each one. The opening balance is the stored balance of
+// the account worked back to from, so the last statement closes on it.
+func (b *BankService) GenerateStatement(acct string, from time.Time, to time.Time) (dbank.Statement, error) {
+	if !from.Before(to) {
+		return dbank.Statement{}, dbank.ErrStatementInvalidPeriod
+	}
+
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		log.Printf("Can't generate statement for %v : %v\n", acct, err)
+		return dbank.Statement{}, accountLookupError(acct, err, dbank.ErrStatementAccountNotFound)
+	}
+
+	openingBalance, err := b.db.GetBankAccountBalanceAt(bankAccountOrm.AccountUuid, from)
+
+	if err != nil {
+		return dbank.Statement{}, dbank.NewUnavailableError("opening balance calculation", err)
+	}
+
+	transactionOrms, err := b.db.GetBankTransactionsInPeriod(bankAccountOrm.AccountUuid, from, to)
+
+	if err != nil {
+		return dbank.Statement{}, dbank.NewUnavailableError("transaction lookup", err)
+	}
+
+	st := dbank.Statement{
+		AccountNumber:  bankAccountOrm.AccountNumber,
+		AccountName:    bankAccountOrm.AccountName,
+		Currency:       bankAccountOrm.Currency,
+		PeriodStart:    from,
+		PeriodEnd:      to,
+		OpeningBalance: openingBalance,
+		Lines:          make([]dbank.StatementLine, 0, len(transactionOrms)),
+	}
+
+	runningBalance := openingBalance
+
+	for _, t := range transactionOrms {
+		if t.TransactionType == dbank.TransactionTypeOut {
+			runningBalance -= t.Amount
+		} else {
+			runningBalance += t.Amount
+		}
+
+		st.Lines = append(st.Lines, dbank.StatementLine{
+			TransactionUuid: t.TransactionUuid,
+			Timestamp:       t.TransactionTimestamp,
+			TransactionType: t.TransactionType,
+			Amount:          t.Amount,
+			RunningBalance:  runningBalance,
+			Notes:           t.Notes,
+		})
+	}
+
+	st.ClosingBalance = runningBalance
+
+	return st, nil
+}
+
+// SummarizeTransactionsByPeriod totals the transactions of acct between from
+// and to by UTC day, week or month. The report of a closed period lying
+// entirely within from and to is stored the first time it is generated and
+// read back afterwards. The partial periods at either end and the current
+// period are always summarized from the transactions.
+func (b *BankService) SummarizeTransactionsByPeriod(acct string, period string, from time.Time, to time.Time) ([]dbank.TransactionSummary, error) {
+	switch period {
+	case dbank.SummaryPeriodDay, dbank.SummaryPeriodWeek, dbank.SummaryPeriodMonth:
+	default:
+		return nil, dbank.ErrSummaryUnknownPeriod
+	}
+
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		log.Printf("Can't summarize transactions for %v : %v\n", acct, err)
+		return nil, accountLookupError(acct, err, dbank.ErrSummaryAccountNotFound)
+	}
+
+	accountUuid := bankAccountOrm.AccountUuid
+
+	storedFrom := dbank.SummaryPeriodStart(from, period)
+	if storedFrom.Before(from) {
+		storedFrom = dbank.NextSummaryPeriod(storedFrom, period)
+	}
+
+	storedTo := dbank.SummaryPeriodStart(to, period)
+	if current := dbank.SummaryPeriodStart(time.Now(), period); current.Before(storedTo) {
+		storedTo = current
+	}
+
+	if !storedFrom.Before(storedTo) {
+		return b.summarizeTransactions(accountUuid, period, from, to)
+	}
+
+	res, err := b.summarizeTransactions(accountUuid, period, from, storedFrom)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := b.summaryReports(accountUuid, period, storedFrom, storedTo)
+
+	if err != nil {
+		return nil, err
 	}
 
-	if transferPairSuccess, _ := b.db.CreateTransferTransactionPair(fromAccountOrm, toAccountOrm, fromTransactionOrm, toTransactionOrm); transferPairSuccess {
-		b.db.UpdateBankTransferStatus(transferOrm, true)
-		return newTransferUUid, true, nil
-	} else {
-		return newTransferUUid, false, dbank.ErrTransferTransactionPair
+	res = append(res, stored...)
+
+	current, err := b.summarizeTransactions(accountUuid, period, storedTo, to)
+
+	if err != nil {
+		return nil, err
 	}
+
+	return append(res, current...), nil
+}
+
+// summarizeTransactions totals the transactions between from and to, only
+// periods with transactions are returned
+func (b *BankService) summarizeTransactions(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]dbank.TransactionSummary, error) {
+	if !from.Before(to) {
+		return nil, nil
+	}
+
+	summaryOrms, err := b.db.GetBankTransactionSummaries(accountUuid, period, from, to)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("transaction summary", err)
+	}
+
+	res := make([]dbank.TransactionSummary, 0, len(summaryOrms))
+
+	for _, s := range summaryOrms {
+		res = append(res, dbank.TransactionSummary{
+			SummaryOnDate: s.SummaryOnDate,
+			SumIn:         s.SumIn,
+			SumOut:        s.SumOut,
+			SumTotal:      s.SumIn - s.SumOut,
+			Count:         s.Count,
+		})
+	}
+
+	return res, nil
+}
+
+// summaryReports returns the stored reports of the closed periods between
+// from and to, which are period boundaries. Missing reports are generated and
+// stored, including those of periods without transactions.
+func (b *BankService) summaryReports(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]dbank.TransactionSummary, error) {
+	reportOrms, err := b.db.GetBankTransactionSummaryReports(accountUuid, period, from, to)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("transaction summary report lookup", err)
+	}
+
+	reports := make(map[int64]database.BankTransactionSummaryReportOrm, len(reportOrms))
+	for _, r := range reportOrms {
+		reports[r.PeriodStart.Unix()] = r
+	}
+
+	var missing []time.Time
+
+	for ps := from; ps.Before(to); ps = dbank.NextSummaryPeriod(ps, period) {
+		if _, ok := reports[ps.Unix()]; !ok {
+			missing = append(missing, ps)
+		}
+	}
+
+	if len(missing) > 0 {
+		generated, err := b.summarizeTransactions(accountUuid, period, missing[0], dbank.NextSummaryPeriod(missing[len(missing)-1], period))
+
+		if err != nil {
+			return nil, err
+		}
+
+		byStart := make(map[int64]dbank.TransactionSummary, len(generated))
+		for _, s := range generated {
+			byStart[s.SummaryOnDate.Unix()] = s
+		}
+
+		now := time.Now()
+		created := make([]database.BankTransactionSummaryReportOrm, 0, len(missing))
+
+		for _, ps := range missing {
+			s := byStart[ps.Unix()]
+
+			r := database.BankTransactionSummaryReportOrm{
+				AccountUuid: accountUuid,
+				Period:      period,
+				PeriodStart: ps,
+				PeriodEnd:   dbank.NextSummaryPeriod(ps, period),
+				SumIn:       s.SumIn,
+				SumOut:      s.SumOut,
+				Count:       s.Count,
+				GeneratedAt: now,
+			}
+
+			reports[ps.Unix()] = r
+			created = append(created, r)
+		}
+
+		// the reports are served either way, storing them only saves work
+		if err := b.db.CreateBankTransactionSummaryReports(created); err != nil {
+			log.Printf("Can't store transaction summary reports : %v\n", err)
+		}
+	}
+
+	res := make([]dbank.TransactionSummary, 0, len(reports))
+
+	for ps := from; ps.Before(to); ps = dbank.NextSummaryPeriod(ps, period) {
+		r := reports[ps.Unix()]
+
+		if r.Count == 0 {
+			continue
+		}
+
+		res = append(res, dbank.TransactionSummary{
+			SummaryOnDate: r.PeriodStart.UTC(),
+			SumIn:         r.SumIn,
+			SumOut:        r.SumOut,
+			SumTotal:      r.SumIn - r.SumOut,
+			Count:         r.Count,
+		})
+	}
+
+	return res, nil
 }
@@ -0,0 +1,351 @@
+package application
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// accrualDb serves the latest interest accrual of every account, any other
+// call panics
+type accrualDb struct {
+	port.BankDatabasePort
+	latest *database.BankInterestAccrualOrm
+	err    error
+}
+
+func (d *accrualDb) GetLatestBankInterestAccrual(accountUuid uuid.UUID) (database.BankInterestAccrualOrm, error) {
+	if d.err != nil {
+		return database.BankInterestAccrualOrm{}, d.err
+	}
+
+	if d.latest == nil {
+		return database.BankInterestAccrualOrm{}, database.ErrRecordNotFound
+	}
+
+	return *d.latest, nil
+}
+
+// backdateDb is a ledgerDb whose accounts accrued interest through latest
+type backdateDb struct {
+	*ledgerDb
+	latest *database.BankInterestAccrualOrm
+}
+
+func (d *backdateDb) GetLatestBankInterestAccrual(accountUuid uuid.UUID) (database.BankInterestAccrualOrm, error) {
+	if d.latest == nil {
+		return database.BankInterestAccrualOrm{}, database.ErrRecordNotFound
+	}
+
+	return *d.latest, nil
+}
+
+// ledgerDb keeps accounts and the transactions posted to them in memory.
+// Its transactions lock accounts until they end, like rows locked for
+// update, but are never rolled back. readers, when set, holds every account
+// read until all the readers it counts have read. Fees follow feeRules and
+// are credited to the revenue account. Any other call panics.
+type ledgerDb struct {
+	port.BankDatabasePort
+	mu       sync.Mutex
+	accounts map[uuid.UUID]*database.BankAccountOrm
+	locks    map[uuid.UUID]*sync.Mutex
+	posted   []database.BankTransactionOrm
+	readers  *sync.WaitGroup
+	feeRules []database.BankFeeRuleOrm
+	revenue  uuid.UUID
+	// invalidated records the times summary reports were dropped for
+	invalidated []time.Time
+}
+
+func newLedgerDb(accounts ...database.BankAccountOrm) *ledgerDb {
+	d := &ledgerDb{
+		accounts: map[uuid.UUID]*database.BankAccountOrm{},
+		locks:    map[uuid.UUID]*sync.Mutex{},
+	}
+
+	for _, a := range accounts {
+		d.accounts[a.AccountUuid] = &a
+		d.locks[a.AccountUuid] = &sync.Mutex{}
+	}
+
+	return d
+}
+
+// ledgerTx is a transaction of a ledgerDb and the account locks it holds
+type ledgerTx struct {
+	*ledgerDb
+	locked []*sync.Mutex
+}
+
+func (d *ledgerDb) PortTransaction(fn func(tx port.BankDatabasePort) error) error {
+	tx := &ledgerTx{ledgerDb: d}
+
+	defer func() {
+		for _, l := range tx.locked {
+			l.Unlock()
+		}
+	}()
+
+	return fn(tx)
+}
+
+func (tx *ledgerTx) LockBankAccounts(accountUuids []uuid.UUID) ([]database.BankAccountOrm, error) {
+	sorted := slices.Clone(accountUuids)
+	slices.SortFunc(sorted, func(a uuid.UUID, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	var res []database.BankAccountOrm
+
+	for _, u := range sorted {
+		tx.mu.Lock()
+		l := tx.locks[u]
+		tx.mu.Unlock()
+
+		l.Lock()
+		tx.locked = append(tx.locked, l)
+
+		tx.mu.Lock()
+		res = append(res, *tx.accounts[u])
+		tx.mu.Unlock()
+	}
+
+	return res, nil
+}
+
+func (d *ledgerDb) GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error) {
+	if d.readers != nil {
+		defer d.readers.Wait()
+		defer d.readers.Done()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, a := range d.accounts {
+		if a.AccountNumber == acct {
+			return *a, nil
+		}
+	}
+
+	return database.BankAccountOrm{}, database.ErrRecordNotFound
+}
+
+func (d *ledgerDb) GetBankHoldsActiveAmount(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	return 0, nil
+}
+
+func (d *ledgerDb) GetBankFeeRules(productUuid *uuid.UUID, accountUuid uuid.UUID, operation string) ([]database.BankFeeRuleOrm, error) {
+	var res []database.BankFeeRuleOrm
+	for _, r := range d.feeRules {
+		if r.Operation == operation {
+			res = append(res, r)
+		}
+	}
+
+	return res, nil
+}
+
+func (d *ledgerDb) GetBankFeeRuleTiers(feeRuleUuids []uuid.UUID) ([]database.BankFeeRuleTierOrm, error) {
+	return nil, nil
+}
+
+func (d *ledgerDb) GetBankFeeRevenueAccount(currency string) (database.BankAccountOrm, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	a, ok := d.accounts[d.revenue]
+	if !ok {
+		return database.BankAccountOrm{}, database.ErrRecordNotFound
+	}
+
+	return *a, nil
+}
+
+func (d *ledgerDb) CreateTransaction(acct database.BankAccountOrm, t database.BankTransactionOrm) (uuid.UUID, error) {
+	d.post(t)
+	return t.TransactionUuid, nil
+}
+
+func (d *ledgerDb) CreateBankFeeCharge(c database.BankFeeChargeOrm, debit database.BankTransactionOrm, credit database.BankTransactionOrm) error {
+	d.post(debit)
+	d.post(credit)
+
+	return nil
+}
+
+func (d *ledgerDb) DeleteBankTransactionSummaryReports(accountUuid uuid.UUID, ts time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.invalidated = append(d.invalidated, ts)
+
+	return nil
+}
+
+func (d *ledgerDb) post(t database.BankTransactionOrm) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	change := t.Amount
+	if t.TransactionType == dbank.TransactionTypeOut {
+		change = -t.Amount
+	}
+
+	d.accounts[t.AccountUuid].CurrentBalance += change
+	d.posted = append(d.posted, t)
+}
+
+func TestConcurrentDebits(t *testing.T) {
+	account := database.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "7835697001", Currency: "USD", CurrentBalance: 100}
+
+	db := newLedgerDb(account)
+	// both debits read the balance of 100 before either takes the lock
+	db.readers = &sync.WaitGroup{}
+	db.readers.Add(2)
+
+	b := NewBankService(db)
+
+	errs := make(chan error, 2)
+
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := b.createTransaction(account.AccountNumber, dbank.Transaction{TransactionType: dbank.TransactionTypeOut, Amount: 70})
+			errs <- err
+		}()
+	}
+
+	var succeeded, short int
+
+	for i := 0; i < 2; i++ {
+		var insufficient *dbank.InsufficientFundsError
+
+		if err := <-errs; err == nil {
+			succeeded++
+		} else if errors.As(err, &insufficient) && insufficient.Available == 30 {
+			short++
+		} else {
+			t.Errorf("createTransaction() = %v, want nil or insufficient funds with 30 available", err)
+		}
+	}
+
+	if succeeded != 1 || short != 1 {
+		t.Errorf("%v debits succeeded and %v were short, want one of each", succeeded, short)
+	}
+
+	if got := db.accounts[account.AccountUuid].CurrentBalance; got != 30 || len(db.posted) != 1 {
+		t.Errorf("balance = %v after %v debits, want 30 after one", got, len(db.posted))
+	}
+}
+
+func TestTransactionTimestamp(t *testing.T) {
+	now := time.Date(2024, 3, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		ts      time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{"not dated", time.Time{}, now, nil},
+		{"back-dated within the limit", now.Add(-dbank.MaxTransactionBackdate), now.Add(-dbank.MaxTransactionBackdate), nil},
+		{"back-dated beyond the limit", now.Add(-dbank.MaxTransactionBackdate - time.Second), time.Time{}, dbank.ErrTransactionTooOld},
+		{"ahead within clock skew", now.Add(dbank.MaxTransactionClockSkew), now.Add(dbank.MaxTransactionClockSkew), nil},
+		{"in the future", now.Add(dbank.MaxTransactionClockSkew + time.Second), time.Time{}, dbank.ErrTransactionInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transactionTimestamp(tt.ts, now)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) || !got.Equal(tt.want) {
+				t.Errorf("transactionTimestamp() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckAccruedBackdate(t *testing.T) {
+	accruedThrough := &database.BankInterestAccrualOrm{AccrualDate: time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)}
+	storageErr := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		db      *accrualDb
+		ts      time.Time
+		wantErr error
+	}{
+		{"never accrued", &accrualDb{}, time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC), nil},
+		{"day after the accrual", &accrualDb{latest: accruedThrough}, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC), nil},
+		{"end of the accrued day", &accrualDb{latest: accruedThrough}, time.Date(2024, 3, 13, 23, 59, 59, 0, time.UTC), dbank.ErrTransactionTooOld},
+		{"before the accrual", &accrualDb{latest: accruedThrough}, time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), dbank.ErrTransactionTooOld},
+		{"storage failure", &accrualDb{err: storageErr}, time.Date(2024, 3, 14, 9, 0, 0, 0, time.UTC), storageErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBankService(tt.db)
+
+			err := b.checkAccruedBackdate(database.BankAccountOrm{AccountNumber: "7835697001"}, tt.ts)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("checkAccruedBackdate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateTransactionBackdated(t *testing.T) {
+	today := startOfDay(time.Now())
+	accruedThrough := today.AddDate(0, 0, -3)
+
+	tests := []struct {
+		name    string
+		ts      time.Time
+		wantErr error
+	}{
+		{"into the accrued day", accruedThrough.Add(12 * time.Hour), dbank.ErrTransactionTooOld},
+		{"after the accrued day", accruedThrough.AddDate(0, 0, 1).Add(12 * time.Hour), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := database.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "7835697001", Currency: "USD", CurrentBalance: 100}
+
+			db := &backdateDb{ledgerDb: newLedgerDb(account)}
+			db.latest = &database.BankInterestAccrualOrm{AccountUuid: account.AccountUuid, AccrualDate: accruedThrough}
+
+			b := NewBankService(db)
+
+			_, err := b.createTransaction(account.AccountNumber, dbank.Transaction{TransactionType: dbank.TransactionTypeIn, Amount: 10, Timestamp: tt.ts})
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("createTransaction() = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if len(db.posted) != 0 || len(db.invalidated) != 0 {
+					t.Errorf("%v transactions posted and %v reports dropped, want none", len(db.posted), len(db.invalidated))
+				}
+				return
+			}
+
+			if len(db.posted) != 1 || !db.posted[0].TransactionTimestamp.Equal(tt.ts) {
+				t.Errorf("posted = %v, want one transaction dated %v", db.posted, tt.ts)
+			}
+
+			if len(db.invalidated) != 1 || !db.invalidated[0].Equal(tt.ts) {
+				t.Errorf("reports dropped at %v, want at %v", db.invalidated, tt.ts)
+			}
+		})
+	}
+}
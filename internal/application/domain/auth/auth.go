@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	MethodJwt        string = "JWT"
+	MethodApiKey     string = "API_KEY"
+	MethodClientCert string = "CLIENT_CERT"
+)
+
+const (
+	RoleCustomer string = "CUSTOMER"
+	RoleTeller   string = "TELLER"
+	RoleAdmin    string = "ADMIN"
+)
+
+const (
+	AccessOwner    string = "OWNER"
+	AccessDelegate string = "DELEGATE"
+)
+
+const (
+	ActionReadAccount       string = "READ_ACCOUNT"
+	ActionCreateTransaction string = "CREATE_TRANSACTION"
+	ActionTransfer          string = "TRANSFER"
+	ActionReadAudit         string = "READ_AUDIT"
+	ActionManageHold        string = "MANAGE_HOLD"
+	ActionReverseTransfer   string = "REVERSE_TRANSFER"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string
+	Method  string
+	Roles   []string
+}
+
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+var ErrMissingCredentials = errors.New("missing credentials")
+var ErrInvalidCredentials = errors.New("invalid credentials")
@@ -0,0 +1,120 @@
+package bank
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionCreateTransaction  string = "CREATE_TRANSACTION"
+	AuditActionTransfer           string = "TRANSFER"
+	AuditActionCreateExchangeRate string = "CREATE_EXCHANGE_RATE"
+	AuditActionAuthorization      string = "AUTHORIZATION"
+	AuditActionPlaceHold          string = "PLACE_HOLD"
+	AuditActionCaptureHold        string = "CAPTURE_HOLD"
+	AuditActionReleaseHold        string = "RELEASE_HOLD"
+	AuditActionCreateSchedule     string = "CREATE_SCHEDULED_TRANSFER"
+	AuditActionCancelSchedule     string = "CANCEL_SCHEDULED_TRANSFER"
+	AuditActionReverseTransfer    string = "REVERSE_TRANSFER"
+	AuditActionSubmitBatch        string = "SUBMIT_TRANSFER_BATCH"
+)
+
+const (
+	AuditOutcomeSuccess string = "SUCCESS"
+	AuditOutcomeFailure string = "FAILURE"
+	AuditOutcomeDenied  string = "DENIED"
+)
+
+// SystemActor is the actor of actions the server performs on its own
+const SystemActor = "system"
+
+// Origin identifies who asked for an action and from where
+type Origin struct {
+	Actor         string
+	RequestId     string
+	ClientAddress string
+}
+
+// AuditEvent is an append-only record of a money-moving or administrative
+// action. Each event is chained to the previous one through PrevHash.
+type AuditEvent struct {
+	Sequence      int64
+	EventUuid     uuid.UUID
+	Timestamp     time.Time
+	Actor         string
+	Action        string
+	AccountNumber string
+	Amount        float64
+	Currency      string
+	RequestId     string
+	ClientAddress string
+	Outcome       string
+	Details       string
+	PrevHash      string
+	Hash          string
+}
+
+// auditAmountDecimals is the precision audit amounts are stored with
+const auditAmountDecimals = 3
+
+// AuditAmount rounds amount to the minor units of its currency, or to the
+// stored precision when the currency is unknown. Events must be hashed with
+// the amount as stored, otherwise the database rounds it differently and the
+// chain no longer verifies.
+func AuditAmount(amount float64, currency string) float64 {
+	if cur, err := Currencies.Find(currency); err == nil && cur.MinorUnits <= auditAmountDecimals {
+		return cur.Round(amount)
+	}
+
+	factor := math.Pow10(auditAmountDecimals)
+
+	return math.Round(amount*factor) / factor
+}
+
+// ComputeHash returns the SHA-256 over the previous hash and every field of
+// the event except Hash itself. The timestamp is hashed at microsecond
+// precision, the precision it is stored with.
+func (e AuditEvent) ComputeHash() string {
+	fields := []string{
+		e.PrevHash,
+		fmt.Sprintf("%d", e.Sequence),
+		e.EventUuid.String(),
+		e.Timestamp.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		e.Actor,
+		e.Action,
+		e.AccountNumber,
+		fmt.Sprintf("%.3f", e.Amount),
+		e.Currency,
+		e.RequestId,
+		e.ClientAddress,
+		e.Outcome,
+		e.Details,
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+type AuditEventFilter struct {
+	Actor         string
+	Action        string
+	AccountNumber string
+	From          time.Time
+	To            time.Time
+	AfterSequence int64
+	Limit         int
+}
+
+type AuditChainVerification struct {
+	Verified       bool
+	EventsChecked  int64
+	BrokenSequence int64
+	Reason         string
+}
@@ -0,0 +1,96 @@
+package bank
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAuditAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		want     float64
+	}{
+		{"two minor units", 10.005, "USD", 10.01},
+		{"two minor units down", 0.1 + 0.2, "EUR", 0.3},
+		{"no minor units", 1234.5, "JPY", 1235},
+		{"three minor units", 1.0005, "BHD", 1.001},
+		{"unknown currency", 2.00049, "XXX", 2},
+		{"no currency", 7.1236, "", 7.124},
+		{"negative", -10.005, "USD", -10.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AuditAmount(tt.amount, tt.currency); got != tt.want {
+				t.Errorf("AuditAmount(%v, %v) = %v, want %v", tt.amount, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func testAuditEvent() AuditEvent {
+	return AuditEvent{
+		Sequence:      42,
+		EventUuid:     uuid.MustParse("6f1c1c5e-8d7e-4c55-9d6b-0c1f4a3b2a10"),
+		Timestamp:     time.Date(2024, 3, 14, 15, 9, 26, 535897000, time.UTC),
+		Actor:         "teller-1",
+		Action:        AuditActionTransfer,
+		AccountNumber: "7835697001",
+		Amount:        125.5,
+		Currency:      "USD",
+		RequestId:     "req-1",
+		ClientAddress: "10.0.0.1:5123",
+		Outcome:       AuditOutcomeSuccess,
+		Details:       "to 7835697002",
+		PrevHash:      "previous",
+	}
+}
+
+func TestComputeHash(t *testing.T) {
+	base := testAuditEvent()
+	baseHash := base.ComputeHash()
+
+	if len(baseHash) != 64 {
+		t.Fatalf("hash %q is not a hex SHA-256", baseHash)
+	}
+
+	tests := []struct {
+		name     string
+		change   func(e *AuditEvent)
+		sameHash bool
+	}{
+		{"unchanged", func(e *AuditEvent) {}, true},
+		{"hash itself ignored", func(e *AuditEvent) { e.Hash = "anything" }, true},
+		{"nanoseconds below the stored precision", func(e *AuditEvent) { e.Timestamp = e.Timestamp.Add(999) }, true},
+		{"same instant in another zone", func(e *AuditEvent) { e.Timestamp = e.Timestamp.In(time.FixedZone("UTC+3", 3*3600)) }, true},
+		{"amount read back from storage", func(e *AuditEvent) {
+			stored, _ := strconv.ParseFloat(fmt.Sprintf("%.3f", e.Amount), 64)
+			e.Amount = stored
+		}, true},
+		{"previous hash", func(e *AuditEvent) { e.PrevHash = "other" }, false},
+		{"sequence", func(e *AuditEvent) { e.Sequence++ }, false},
+		{"timestamp", func(e *AuditEvent) { e.Timestamp = e.Timestamp.Add(time.Microsecond) }, false},
+		{"actor", func(e *AuditEvent) { e.Actor = "teller-2" }, false},
+		{"amount", func(e *AuditEvent) { e.Amount = 125.51 }, false},
+		{"outcome", func(e *AuditEvent) { e.Outcome = AuditOutcomeFailure }, false},
+		{"details", func(e *AuditEvent) { e.Details = "to 7835697003" }, false},
+		{"field boundary", func(e *AuditEvent) { e.RequestId, e.ClientAddress = "req-110.0.0.1:5123", "" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testAuditEvent()
+			tt.change(&e)
+
+			if got := e.ComputeHash(); (got == baseHash) != tt.sameHash {
+				t.Errorf("hash changed = %v, want changed = %v", got != baseHash, !tt.sameHash)
+			}
+		})
+	}
+}
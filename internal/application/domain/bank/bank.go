@@ -11,6 +11,60 @@ const (
 	TransactionTypeOut     string = "OUT"
 )
 
+const (
+	SummaryPeriodDay   string = "day"
+	SummaryPeriodWeek  string = "week"
+	SummaryPeriodMonth string = "month"
+)
+
+// Overdraft lets an account balance go negative down to -Limit. InterestRate
+// is the annual rate charged on the overdrawn amount. The fees charged when
+// the account becomes overdrawn are fee rules of its product for
+// FeeOperationOverdraft.
+type Overdraft struct {
+	Limit        float64
+	InterestRate float64
+}
+
+// OverdrawnBy returns how far below zero a debit takes balance when balance
+// is zero or above, otherwise the debit doesn't make the account overdrawn
+// and it returns 0
+func OverdrawnBy(balance float64, debit float64) float64 {
+	if balance < 0 || balance-debit >= 0 {
+		return 0
+	}
+
+	return debit - balance
+}
+
+// DailyInterest returns the unrounded interest charged on an overdrawn
+// balance over day under convention, as a negative amount. A balance at or
+// above zero is charged nothing.
+func (o Overdraft) DailyInterest(convention string, balance float64, day time.Time) (float64, error) {
+	fraction, err := DayCountFraction(convention, day)
+
+	if err != nil || balance >= 0 || o.InterestRate <= 0 {
+		return 0, err
+	}
+
+	return balance * o.InterestRate * fraction, nil
+}
+
+// AccountBalance separates the ledger balance, the sum of all posted
+// transactions, from the balance available to spend, which includes the
+// overdraft facility and excludes the HeldAmount reserved by active holds.
+type AccountBalance struct {
+	AccountNumber    string
+	Currency         string
+	LedgerBalance    float64
+	AvailableBalance float64
+	HeldAmount       float64
+	Overdraft        Overdraft
+	ProductCode      string
+	ProductType      string
+	MinBalance       float64
+}
+
 type ExchangeRate struct {
 	FromCurrency       string
 	ToCurrency         string
@@ -19,18 +73,41 @@ type ExchangeRate struct {
 	ValidToTimestamp   time.Time
 }
 
+const (
+	// MaxTransactionBackdate is how far in the past a client may date a
+	// transaction, older periods may already have been reported on. Days
+	// whose interest the account accrued can't be back-dated to either.
+	MaxTransactionBackdate = 7 * 24 * time.Hour
+	// MaxTransactionClockSkew is how far in the future a transaction may be
+	// dated to allow for client clocks running ahead
+	MaxTransactionClockSkew = 5 * time.Minute
+)
+
+// Transaction is a deposit or withdrawal. A zero Timestamp dates the
+// transaction at the time it is recorded. FeeExempt is set on postings the
+// bank makes itself, such as interest, which are not charged fees.
 type Transaction struct {
 	Amount          float64
 	Timestamp       time.Time
 	TransactionType string
 	Notes           string
+	Metadata        TransactionMetadata
+	FeeExempt       bool
+	Origin          Origin
+	// Charge is a debit the bank takes whatever the available balance of
+	// the account, e.g. overdraft interest. Clients can never set it.
+	Charge bool
 }
 
+// TransactionSummary totals transactions. SummaryOnDate is the date of the
+// latest transaction summarized.
 type TransactionSummary struct {
+	AccountNumber string
 	SummaryOnDate time.Time
 	SumIn         float64
 	SumOut        float64
 	SumTotal      float64
+	Count         int64
 }
 
 type TrasferTransaction struct {
@@ -38,9 +115,16 @@ type TrasferTransaction struct {
 	ToAccountNumber   string
 	Currency          string
 	Amount            float64
+	Origin            Origin
 }
 
 var ErrTransferSourceAccountNotFound = errors.New("source account not found")
 var ErrTransferDestinationAccountNotFound = errors.New("destination account not found")
 var ErrTransferRecordFailed = errors.New("can't create transfer record")
 var ErrTransferTransactionPair = errors.New("can't create transfer transaction pair possibly insufficent fund on source account")
+var ErrSummaryAccountNotFound = errors.New("summary account not found")
+var ErrSummaryUnknownPeriod = errors.New("unknown summary period")
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+var ErrAccountNotFound = errors.New("account not found")
+var ErrTransactionTooOld = errors.New("transaction timestamp is earlier than allowed")
+var ErrTransactionInFuture = errors.New("transaction timestamp is in the future")
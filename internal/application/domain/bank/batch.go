@@ -0,0 +1,85 @@
+package bank
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TransferBatchFormatCsv  string = "CSV"
+	TransferBatchFormatJson string = "JSON"
+)
+
+const (
+	// TransferBatchModeAllOrNothing reverses every transfer of the batch as
+	// soon as one of them fails
+	TransferBatchModeAllOrNothing string = "ALL_OR_NOTHING"
+	// TransferBatchModeBestEffort makes every valid transfer it can
+	TransferBatchModeBestEffort string = "BEST_EFFORT"
+)
+
+const (
+	TransferBatchStatusProcessing         string = "PROCESSING"
+	TransferBatchStatusRejected           string = "REJECTED"
+	TransferBatchStatusCompleted          string = "COMPLETED"
+	TransferBatchStatusPartiallyCompleted string = "PARTIALLY_COMPLETED"
+	TransferBatchStatusRolledBack         string = "ROLLED_BACK"
+	TransferBatchStatusFailed             string = "FAILED"
+)
+
+const (
+	TransferBatchLineStatusPending    string = "PENDING"
+	TransferBatchLineStatusInvalid    string = "INVALID"
+	TransferBatchLineStatusSkipped    string = "SKIPPED"
+	TransferBatchLineStatusSuccess    string = "SUCCESS"
+	TransferBatchLineStatusFailed     string = "FAILED"
+	TransferBatchLineStatusRolledBack string = "ROLLED_BACK"
+)
+
+const (
+	// MaxTransferBatchSize is the largest batch file accepted, in bytes
+	MaxTransferBatchSize  = 10 << 20
+	MaxTransferBatchLines = 10000
+	// MaxTransferBatchReference is the longest reference of a line
+	MaxTransferBatchReference = 140
+)
+
+// TransferBatch is a file of transfers submitted at once. Lines keep the
+// order of the file.
+type TransferBatch struct {
+	BatchUuid      uuid.UUID
+	FileName       string
+	Format         string
+	Mode           string
+	Status         string
+	Lines          []TransferBatchLine
+	SucceededCount int
+	FailedCount    int
+	CreatedBy      string
+	CreatedAt      time.Time
+	CompletedAt    time.Time
+	Origin         Origin
+}
+
+// TransferBatchLine is one transfer of a batch. LineNumber is the line of a
+// CSV file, header included, or the position in a JSON array starting at 1.
+type TransferBatchLine struct {
+	LineNumber        int
+	FromAccountNumber string
+	ToAccountNumber   string
+	Currency          string
+	Amount            float64
+	Reference         string
+	Status            string
+	TransferUuid      uuid.UUID
+	Error             string
+}
+
+var ErrTransferBatchNotFound = errors.New("transfer batch not found")
+var ErrTransferBatchUnknownFormat = errors.New("unknown transfer batch format")
+var ErrTransferBatchUnknownMode = errors.New("unknown transfer batch mode")
+var ErrTransferBatchMalformed = errors.New("transfer batch file is malformed")
+var ErrTransferBatchEmpty = errors.New("transfer batch has no transfers")
+var ErrTransferBatchTooLarge = errors.New("transfer batch is too large")
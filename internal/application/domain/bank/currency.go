@@ -0,0 +1,122 @@
+package bank
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Currency is an ISO 4217 currency. MinorUnits is the number of decimals
+// amounts in this currency are kept to, e.g. 2 for USD and 0 for JPY.
+type Currency struct {
+	Code       string
+	Numeric    string
+	Name       string
+	MinorUnits int
+	Enabled    bool
+}
+
+// Round rounds amount half away from zero to the currency minor units
+func (c Currency) Round(amount float64) float64 {
+	factor := math.Pow10(c.MinorUnits)
+	return math.Round(amount*factor) / factor
+}
+
+type CurrencyRegistry struct {
+	mu         sync.RWMutex
+	currencies map[string]Currency
+}
+
+func NewCurrencyRegistry(currencies ...Currency) *CurrencyRegistry {
+	r := &CurrencyRegistry{
+		currencies: make(map[string]Currency, len(currencies)),
+	}
+
+	for _, c := range currencies {
+		r.currencies[c.Code] = c
+	}
+
+	return r
+}
+
+// Find returns the currency for an ISO code, whether it is enabled or not
+func (r *CurrencyRegistry) Find(code string) (Currency, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.currencies[code]
+	if !ok {
+		return Currency{}, ErrCurrencyNotSupported
+	}
+
+	return c, nil
+}
+
+// FindEnabled returns the currency for an ISO code only if it can be used
+// for accounts, rates and transfers
+func (r *CurrencyRegistry) FindEnabled(code string) (Currency, error) {
+	c, err := r.Find(code)
+	if err != nil {
+		return c, err
+	}
+
+	if !c.Enabled {
+		return c, ErrCurrencyDisabled
+	}
+
+	return c, nil
+}
+
+func (r *CurrencyRegistry) SetEnabled(code string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.currencies[code]
+	if !ok {
+		return ErrCurrencyNotSupported
+	}
+
+	c.Enabled = enabled
+	r.currencies[code] = c
+
+	return nil
+}
+
+// List returns every registered currency ordered by code
+func (r *CurrencyRegistry) List() []Currency {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]Currency, 0, len(r.currencies))
+	for _, c := range r.currencies {
+		res = append(res, c)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Code < res[j].Code
+	})
+
+	return res
+}
+
+// Currencies is the registry used by the bank service and the gRPC adapter
+var Currencies = NewCurrencyRegistry(
+	Currency{Code: "AUD", Numeric: "036", Name: "Australian dollar", MinorUnits: 2, Enabled: true},
+	Currency{Code: "BHD", Numeric: "048", Name: "Bahraini dinar", MinorUnits: 3, Enabled: false},
+	Currency{Code: "CAD", Numeric: "124", Name: "Canadian dollar", MinorUnits: 2, Enabled: true},
+	Currency{Code: "CHF", Numeric: "756", Name: "Swiss franc", MinorUnits: 2, Enabled: true},
+	Currency{Code: "CNY", Numeric: "156", Name: "Renminbi", MinorUnits: 2, Enabled: false},
+	Currency{Code: "EUR", Numeric: "978", Name: "Euro", MinorUnits: 2, Enabled: true},
+	Currency{Code: "GBP", Numeric: "826", Name: "Pound sterling", MinorUnits: 2, Enabled: true},
+	Currency{Code: "INR", Numeric: "356", Name: "Indian rupee", MinorUnits: 2, Enabled: true},
+	Currency{Code: "JPY", Numeric: "392", Name: "Japanese yen", MinorUnits: 0, Enabled: true},
+	Currency{Code: "KWD", Numeric: "414", Name: "Kuwaiti dinar", MinorUnits: 3, Enabled: false},
+	Currency{Code: "SGD", Numeric: "702", Name: "Singapore dollar", MinorUnits: 2, Enabled: true},
+	Currency{Code: "USD", Numeric: "840", Name: "United States dollar", MinorUnits: 2, Enabled: true},
+)
+
+var ErrCurrencyNotSupported = errors.New("currency not supported")
+var ErrCurrencyDisabled = errors.New("currency disabled")
+var ErrCurrencyMismatch = errors.New("currency does not match account currency")
+var ErrAmountBelowMinorUnit = errors.New("amount is smaller than the currency minor unit")
@@ -0,0 +1,99 @@
+package bank
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurrencyRound(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		amount   float64
+		want     float64
+	}{
+		{"cents", "USD", 10.234, 10.23},
+		{"half cent away from zero", "USD", 0.125, 0.13},
+		{"negative half cent away from zero", "USD", -0.125, -0.13},
+		{"no minor units", "JPY", 1234.5, 1235},
+		{"three minor units", "BHD", 1.23456, 1.235},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Currencies.Find(tt.currency)
+			if err != nil {
+				t.Fatalf("Find(%v) = %v", tt.currency, err)
+			}
+
+			if got := c.Round(tt.amount); got != tt.want {
+				t.Errorf("Round(%v) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyRegistry(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           string
+		enable         *bool
+		wantFindErr    error
+		wantEnabledErr error
+	}{
+		{"enabled", "USD", nil, nil, nil},
+		{"disabled", "KWD", nil, nil, ErrCurrencyDisabled},
+		{"unknown", "XYZ", nil, ErrCurrencyNotSupported, ErrCurrencyNotSupported},
+		{"lower case is unknown", "usd", nil, ErrCurrencyNotSupported, ErrCurrencyNotSupported},
+		{"enabled at runtime", "KWD", boolPtr(true), nil, nil},
+		{"disabled at runtime", "USD", boolPtr(false), nil, ErrCurrencyDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCurrencyRegistry(
+				Currency{Code: "USD", Numeric: "840", MinorUnits: 2, Enabled: true},
+				Currency{Code: "KWD", Numeric: "414", MinorUnits: 3, Enabled: false},
+			)
+
+			if tt.enable != nil {
+				if err := r.SetEnabled(tt.code, *tt.enable); err != nil {
+					t.Fatalf("SetEnabled() = %v", err)
+				}
+			}
+
+			if _, err := r.Find(tt.code); !errors.Is(err, tt.wantFindErr) || (tt.wantFindErr == nil) != (err == nil) {
+				t.Errorf("Find() = %v, want %v", err, tt.wantFindErr)
+			}
+
+			if _, err := r.FindEnabled(tt.code); !errors.Is(err, tt.wantEnabledErr) || (tt.wantEnabledErr == nil) != (err == nil) {
+				t.Errorf("FindEnabled() = %v, want %v", err, tt.wantEnabledErr)
+			}
+		})
+	}
+}
+
+func TestCurrencyRegistryList(t *testing.T) {
+	r := NewCurrencyRegistry(Currency{Code: "USD"}, Currency{Code: "EUR"}, Currency{Code: "JPY"})
+
+	if err := r.SetEnabled("GBP", true); !errors.Is(err, ErrCurrencyNotSupported) {
+		t.Errorf("SetEnabled() of an unknown currency = %v, want %v", err, ErrCurrencyNotSupported)
+	}
+
+	list := r.List()
+	want := []string{"EUR", "JPY", "USD"}
+
+	if len(list) != len(want) {
+		t.Fatalf("List() = %v, want %v", list, want)
+	}
+
+	for i, c := range list {
+		if c.Code != want[i] {
+			t.Errorf("List()[%v] = %v, want %v", i, c.Code, want[i])
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
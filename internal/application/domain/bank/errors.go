@@ -0,0 +1,181 @@
+package bank
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons are the stable, machine readable error identifiers reported to
+// clients. Existing values must never change, only new ones may be added.
+const (
+	ReasonAccountNotFound       string = "ACCOUNT_NOT_FOUND"
+	ReasonExchangeRateNotFound  string = "EXCHANGE_RATE_NOT_FOUND"
+	ReasonInsufficientFunds     string = "INSUFFICIENT_FUNDS"
+	ReasonConflict              string = "CONFLICT"
+	ReasonStorageUnavailable    string = "STORAGE_UNAVAILABLE"
+	ReasonInvalidArgument       string = "INVALID_ARGUMENT"
+	ReasonCurrencyNotSupported  string = "CURRENCY_NOT_SUPPORTED"
+	ReasonCurrencyDisabled      string = "CURRENCY_DISABLED"
+	ReasonCurrencyMismatch      string = "CURRENCY_MISMATCH"
+	ReasonAmountBelowMinorUnit  string = "AMOUNT_BELOW_MINOR_UNIT"
+	ReasonInternal              string = "INTERNAL"
+	ReasonPermissionDenied      string = "PERMISSION_DENIED"
+	ReasonRateLimited           string = "RATE_LIMITED"
+	ReasonTransferLimitExceeded string = "TRANSFER_LIMIT_EXCEEDED"
+	ReasonHoldNotFound          string = "HOLD_NOT_FOUND"
+	ReasonHoldNotActive         string = "HOLD_NOT_ACTIVE"
+	ReasonScheduleNotFound      string = "SCHEDULED_TRANSFER_NOT_FOUND"
+	ReasonScheduleNotActive     string = "SCHEDULED_TRANSFER_NOT_ACTIVE"
+	ReasonTransferNotFound      string = "TRANSFER_NOT_FOUND"
+	ReasonTransferReversed      string = "TRANSFER_ALREADY_REVERSED"
+	ReasonTransferNotReversible string = "TRANSFER_NOT_REVERSIBLE"
+	ReasonTransferBatchNotFound string = "TRANSFER_BATCH_NOT_FOUND"
+	ReasonReversalTooLarge      string = "REVERSAL_EXCEEDS_REMAINING"
+	ReasonFeeNotConfigured      string = "FEE_NOT_CONFIGURED"
+	ReasonProductCurrency       string = "PRODUCT_CURRENCY_NOT_ALLOWED"
+	ReasonProductMinBalance     string = "PRODUCT_MIN_BALANCE"
+	ReasonProductTransfersOut   string = "PRODUCT_TRANSFERS_OUT_NOT_ALLOWED"
+	ReasonTransferFailed        string = "TRANSFER_FAILED"
+)
+
+const (
+	ResourceAccount       string = "account"
+	ResourceExchangeRate  string = "exchange_rate"
+	ResourceHold          string = "hold"
+	ResourceSchedule      string = "scheduled_transfer"
+	ResourceTransfer      string = "transfer"
+	ResourceTransferBatch string = "transfer_batch"
+)
+
+// NotFoundError reports a resource that does not exist. Err is the sentinel
+// describing which lookup failed, e.g. ErrTransferSourceAccountNotFound.
+type NotFoundError struct {
+	Reason   string
+	Resource string
+	Key      string
+	Err      error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%v %v not found", e.Resource, e.Key)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func NewAccountNotFoundError(acct string, err error) *NotFoundError {
+	return &NotFoundError{
+		Reason:   ReasonAccountNotFound,
+		Resource: ResourceAccount,
+		Key:      acct,
+		Err:      err,
+	}
+}
+
+type InsufficientFundsError struct {
+	AccountNumber string
+	Available     float64
+	Requested     float64
+	Err           error
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("insufficient funds on account %v : available %v, requested %v", e.AccountNumber, e.Available, e.Requested)
+}
+
+func (e *InsufficientFundsError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictError reports a request that clashes with the current state of a
+// resource, e.g. a duplicate or concurrent modification.
+type ConflictError struct {
+	Reason   string
+	Resource string
+	Key      string
+	Detail   string
+	Err      error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflict on %v %v : %v", e.Resource, e.Key, e.Detail)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// UnavailableError reports a transient failure of a dependency such as the
+// database. The operation may be retried after RetryAfter.
+type UnavailableError struct {
+	Operation  string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%v unavailable : %v", e.Operation, e.Err)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultRetryAfter is suggested to clients when a dependency is unavailable
+const DefaultRetryAfter = 5 * time.Second
+
+func NewUnavailableError(operation string, err error) *UnavailableError {
+	return &UnavailableError{
+		Operation:  operation,
+		RetryAfter: DefaultRetryAfter,
+		Err:        err,
+	}
+}
+
+// TransferFailedError reports a transfer whose money could not be moved
+// after it was recorded. The transfer is FAILED and the same request is not
+// safe to retry, the caller should look the transfer up instead.
+type TransferFailedError struct {
+	TransferUuid uuid.UUID
+	Err          error
+}
+
+func (e *TransferFailedError) Error() string {
+	return fmt.Sprintf("transfer %v failed : %v", e.TransferUuid, e.Err)
+}
+
+func (e *TransferFailedError) Unwrap() error {
+	return e.Err
+}
+
+// PermissionDeniedError reports a caller acting on an account it neither
+// owns nor is delegated on
+type PermissionDeniedError struct {
+	Subject       string
+	Action        string
+	AccountNumber string
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return fmt.Sprintf("%v is not allowed to %v on account %v", e.Subject, e.Action, e.AccountNumber)
+}
+
+// TransferLimitError reports a transfer that would break Rule of the limit
+// named Limit. Current is what the account already used in the rule's window,
+// Requested what the transfer adds to it.
+type TransferLimitError struct {
+	AccountNumber string
+	Limit         string
+	Rule          string
+	Maximum       float64
+	Current       float64
+	Requested     float64
+}
+
+func (e *TransferLimitError) Error() string {
+	return fmt.Sprintf("transfer from account %v exceeds %v of limit %q : maximum %v, used %v, requested %v",
+		e.AccountNumber, e.Rule, e.Limit, e.Maximum, e.Current, e.Requested)
+}
@@ -0,0 +1,137 @@
+package bank
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fee operations are the operations a fee rule is evaluated for
+const (
+	FeeOperationTransfer   string = "TRANSFER"
+	FeeOperationDeposit    string = "DEPOSIT"
+	FeeOperationWithdrawal string = "WITHDRAWAL"
+	// FeeOperationOverdraft is a debit that makes the account overdrawn, its
+	// fees are evaluated on the amount it is overdrawn by
+	FeeOperationOverdraft string = "OVERDRAFT"
+)
+
+const (
+	// FeeTypeFlat charges FlatAmount
+	FeeTypeFlat string = "FLAT"
+	// FeeTypePercentage charges Rate of the amount
+	FeeTypePercentage string = "PERCENTAGE"
+	// FeeTypeTiered charges the flat amount and rate of the highest tier
+	// whose MinAmount the amount reaches
+	FeeTypeTiered string = "TIERED"
+	// FeeTypeFxMarkup charges Rate of the amount converted into the account
+	// currency, only when the operation converts currencies
+	FeeTypeFxMarkup string = "FX_MARKUP"
+)
+
+// FeeCategory is the metadata category of fee transactions
+const FeeCategory = "fee"
+
+type FeeTier struct {
+	MinAmount  float64
+	FlatAmount float64
+	Rate       float64
+}
+
+// FeeRule is a fee of an account product or of a single account. An empty Currency applies to every
+// currency. The fee is raised to MinFee and capped at MaxFee when those are
+// set.
+type FeeRule struct {
+	FeeRuleUuid uuid.UUID
+	FeeName     string
+	Operation   string
+	FeeType     string
+	Currency    string
+	FlatAmount  float64
+	Rate        float64
+	MinFee      float64
+	MaxFee      float64
+	Tiers       []FeeTier
+}
+
+// Applies tells whether the rule is evaluated for an operation in currency
+func (r FeeRule) Applies(operation string, currency string) bool {
+	return r.Operation == operation && (r.Currency == "" || r.Currency == currency)
+}
+
+// Evaluate returns the unrounded fee on amount, in the account currency.
+// converted tells whether the operation converted amount from another
+// currency.
+func (r FeeRule) Evaluate(amount float64, converted bool) float64 {
+	var fee float64
+
+	switch r.FeeType {
+	case FeeTypeFlat:
+		fee = r.FlatAmount
+	case FeeTypePercentage:
+		fee = amount * r.Rate
+	case FeeTypeTiered:
+		var tier *FeeTier
+
+		for i := range r.Tiers {
+			if amount >= r.Tiers[i].MinAmount && (tier == nil || r.Tiers[i].MinAmount > tier.MinAmount) {
+				tier = &r.Tiers[i]
+			}
+		}
+
+		if tier == nil {
+			return 0
+		}
+
+		fee = tier.FlatAmount + amount*tier.Rate
+	case FeeTypeFxMarkup:
+		if !converted {
+			return 0
+		}
+
+		fee = amount * r.Rate
+	default:
+		return 0
+	}
+
+	if r.MinFee > 0 {
+		fee = math.Max(fee, r.MinFee)
+	}
+
+	if r.MaxFee > 0 {
+		fee = math.Min(fee, r.MaxFee)
+	}
+
+	return fee
+}
+
+// Fee is a fee charged for an operation, posted as a debit of the account
+// and a credit of the fee revenue account of the currency
+type Fee struct {
+	ChargeUuid         uuid.UUID
+	FeeRuleUuid        uuid.UUID
+	FeeName            string
+	FeeType            string
+	Amount             float64
+	Currency           string
+	FeeTransactionUuid uuid.UUID
+	Timestamp          time.Time
+}
+
+// FeeRefund returns the part of fee, charged for a transfer of amount, that
+// a reversal taking the reversed amount of the transfer from before to after
+// refunds. Fees are refunded in proportion to the amount reversed, so once
+// the transfer is reversed in full the whole fee is refunded.
+func FeeRefund(cur Currency, fee float64, amount float64, before float64, after float64) float64 {
+	refunded := cur.Round(fee * before / amount)
+
+	if after >= amount {
+		return cur.Round(fee - refunded)
+	}
+
+	return cur.Round(cur.Round(fee*after/amount) - refunded)
+}
+
+var ErrFeeRevenueAccountNotFound = errors.New("no fee revenue account for currency")
@@ -0,0 +1,100 @@
+package bank
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFeeRuleApplies(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      FeeRule
+		operation string
+		currency  string
+		want      bool
+	}{
+		{"any currency", FeeRule{Operation: FeeOperationTransfer}, FeeOperationTransfer, "EUR", true},
+		{"same currency", FeeRule{Operation: FeeOperationTransfer, Currency: "USD"}, FeeOperationTransfer, "USD", true},
+		{"other currency", FeeRule{Operation: FeeOperationTransfer, Currency: "USD"}, FeeOperationTransfer, "EUR", false},
+		{"other operation", FeeRule{Operation: FeeOperationDeposit}, FeeOperationWithdrawal, "USD", false},
+		{"overdraft", FeeRule{Operation: FeeOperationOverdraft}, FeeOperationOverdraft, "USD", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Applies(tt.operation, tt.currency); got != tt.want {
+				t.Errorf("Applies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeeRuleEvaluate(t *testing.T) {
+	tiered := FeeRule{
+		FeeType: FeeTypeTiered,
+		Tiers: []FeeTier{
+			{MinAmount: 1000, FlatAmount: 2, Rate: 0.001},
+			{MinAmount: 0, FlatAmount: 1},
+			{MinAmount: 10000, Rate: 0.002},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		rule      FeeRule
+		amount    float64
+		converted bool
+		want      float64
+	}{
+		{"flat", FeeRule{FeeType: FeeTypeFlat, FlatAmount: 2.5}, 100, false, 2.5},
+		{"percentage", FeeRule{FeeType: FeeTypePercentage, Rate: 0.01}, 250, false, 2.5},
+		{"percentage raised to minimum", FeeRule{FeeType: FeeTypePercentage, Rate: 0.01, MinFee: 1}, 50, false, 1},
+		{"percentage capped at maximum", FeeRule{FeeType: FeeTypePercentage, Rate: 0.01, MaxFee: 10}, 5000, false, 10},
+		{"lowest tier", tiered, 500, false, 1},
+		{"middle tier", tiered, 1000, false, 3},
+		{"highest tier whatever the order", tiered, 20000, false, 40},
+		{"below every tier", FeeRule{FeeType: FeeTypeTiered, Tiers: []FeeTier{{MinAmount: 100, FlatAmount: 1}}}, 99, false, 0},
+		{"below every tier ignores minimum", FeeRule{FeeType: FeeTypeTiered, MinFee: 1, Tiers: []FeeTier{{MinAmount: 100}}}, 99, false, 0},
+		{"fx markup on a converted amount", FeeRule{FeeType: FeeTypeFxMarkup, Rate: 0.015}, 200, true, 3},
+		{"fx markup raised to minimum", FeeRule{FeeType: FeeTypeFxMarkup, Rate: 0.015, MinFee: 1}, 20, true, 1},
+		{"fx markup not charged on the same currency", FeeRule{FeeType: FeeTypeFxMarkup, Rate: 0.015, MinFee: 1}, 200, false, 0},
+		{"unknown type", FeeRule{FeeType: "CASHBACK", Rate: 0.01}, 100, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Evaluate(tt.amount, tt.converted); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeeRefund(t *testing.T) {
+	usd := Currency{Code: "USD", MinorUnits: 2}
+
+	tests := []struct {
+		name   string
+		fee    float64
+		amount float64
+		before float64
+		after  float64
+		want   float64
+	}{
+		{"full reversal", 2.5, 100, 0, 100, 2.5},
+		{"half reversal", 2.5, 100, 0, 50, 1.25},
+		{"rest of the reversal", 2.5, 100, 50, 100, 1.25},
+		{"third rounds", 1, 90, 0, 30, 0.33},
+		{"second third rounds", 1, 90, 30, 60, 0.34},
+		{"last third gets the remainder", 1, 90, 60, 90, 0.33},
+		{"tiny reversal refunds nothing", 0.01, 1000, 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FeeRefund(usd, tt.fee, tt.amount, tt.before, tt.after); got != tt.want {
+				t.Errorf("FeeRefund() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package bank
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HoldStatusActive   string = "ACTIVE"
+	HoldStatusCaptured string = "CAPTURED"
+	HoldStatusReleased string = "RELEASED"
+	HoldStatusExpired  string = "EXPIRED"
+)
+
+// DefaultHoldExpiry applies to holds placed without an expiry
+const DefaultHoldExpiry = 7 * 24 * time.Hour
+
+// Hold reserves Amount of an account until it is captured, released or
+// expires. Only a captured hold posts a transaction, for CapturedAmount.
+type Hold struct {
+	HoldUuid        uuid.UUID
+	AccountNumber   string
+	Currency        string
+	Amount          float64
+	Status          string
+	Reference       string
+	ExpiresAt       time.Time
+	CapturedAmount  float64
+	TransactionUuid uuid.UUID
+	CreatedAt       time.Time
+	Origin          Origin
+}
+
+var ErrHoldNotFound = errors.New("hold not found")
+var ErrHoldNotActive = errors.New("hold is not active")
+var ErrHoldInvalidExpiry = errors.New("hold must expire in the future")
+var ErrHoldCaptureExceedsAmount = errors.New("capture amount exceeds hold amount")
@@ -0,0 +1,104 @@
+package bank
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Day count conventions tell how much of the annual rate a single day earns
+const (
+	// DayCountAct365 counts every year as 365 days
+	DayCountAct365 string = "ACT_365"
+	// DayCountAct360 counts every year as 360 days
+	DayCountAct360 string = "ACT_360"
+	// DayCountActAct counts the actual days of the year, 365 or 366
+	DayCountActAct string = "ACT_ACT"
+	// DayCount30360 counts every month as 30 days, the 31st earns nothing
+	// and the last day of February earns up to the 30th
+	DayCount30360 string = "30_360"
+)
+
+// DefaultDayCountConvention counts the overdraft interest of accounts
+// without a product
+const DefaultDayCountConvention = DayCountAct365
+
+const (
+	InterestCapitalizationPending string = "PENDING"
+	InterestCapitalizationPosted  string = "POSTED"
+	// InterestCapitalizationEmpty is a period whose interest rounds to zero
+	InterestCapitalizationEmpty string = "EMPTY"
+)
+
+// InterestCategory is the metadata category of capitalized interest
+const InterestCategory = "interest"
+
+// InterestTier applies AnnualRate to the part of the balance above
+// MinBalance, up to the MinBalance of the next tier
+type InterestTier struct {
+	MinBalance float64
+	AnnualRate float64
+}
+
+// DayCountFraction returns the fraction of a year that day counts for
+func DayCountFraction(convention string, day time.Time) (float64, error) {
+	switch convention {
+	case DayCountAct365:
+		return 1.0 / 365, nil
+	case DayCountAct360:
+		return 1.0 / 360, nil
+	case DayCountActAct:
+		return 1.0 / float64(time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()), nil
+	case DayCount30360:
+		if day.Day() == 31 {
+			return 0, nil
+		}
+
+		if day.Month() == time.February && day.AddDate(0, 0, 1).Month() == time.March {
+			return float64(30-day.Day()+1) / 360, nil
+		}
+
+		return 1.0 / 360, nil
+	default:
+		return 0, ErrUnknownDayCountConvention
+	}
+}
+
+// DailyInterest returns the unrounded interest balance earns over day. A
+// balance at or below zero earns nothing.
+func (p AccountProduct) DailyInterest(balance float64, day time.Time) (float64, error) {
+	fraction, err := DayCountFraction(p.DayCountConvention, day)
+
+	if err != nil || balance <= 0 {
+		return 0, err
+	}
+
+	tiers := make([]InterestTier, len(p.InterestTiers))
+	copy(tiers, p.InterestTiers)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinBalance < tiers[j].MinBalance })
+
+	var interest float64
+
+	for i, t := range tiers {
+		if balance <= t.MinBalance {
+			break
+		}
+
+		upper := balance
+		if i+1 < len(tiers) && tiers[i+1].MinBalance < upper {
+			upper = tiers[i+1].MinBalance
+		}
+
+		interest += (upper - t.MinBalance) * t.AnnualRate * fraction
+	}
+
+	return interest, nil
+}
+
+// InterestPeriodStart returns the first day of the month interest accrued on
+// day is capitalized with
+func InterestPeriodStart(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+var ErrUnknownDayCountConvention = errors.New("unknown day count convention")
@@ -0,0 +1,129 @@
+package bank
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDayCountFraction(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention string
+		day        time.Time
+		want       float64
+		wantErr    error
+	}{
+		{"act 365", DayCountAct365, day(2024, 3, 14), 1.0 / 365, nil},
+		{"act 360", DayCountAct360, day(2024, 3, 14), 1.0 / 360, nil},
+		{"act act leap year", DayCountActAct, day(2024, 3, 14), 1.0 / 366, nil},
+		{"act act common year", DayCountActAct, day(2023, 3, 14), 1.0 / 365, nil},
+		{"30 360 ordinary day", DayCount30360, day(2024, 3, 14), 1.0 / 360, nil},
+		{"30 360 31st", DayCount30360, day(2024, 3, 31), 0, nil},
+		{"30 360 end of leap february", DayCount30360, day(2024, 2, 29), 2.0 / 360, nil},
+		{"30 360 end of february", DayCount30360, day(2023, 2, 28), 3.0 / 360, nil},
+		{"30 360 within february", DayCount30360, day(2024, 2, 28), 1.0 / 360, nil},
+		{"unknown", "ACT_999", day(2024, 3, 14), 0, ErrUnknownDayCountConvention},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DayCountFraction(tt.convention, tt.day)
+
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("DayCountFraction() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccountProductDailyInterest(t *testing.T) {
+	tiered := AccountProduct{
+		DayCountConvention: DayCountAct360,
+		InterestTiers: []InterestTier{
+			{MinBalance: 10000, AnnualRate: 0.036},
+			{MinBalance: 0, AnnualRate: 0.018},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		product AccountProduct
+		balance float64
+		want    float64
+		wantErr error
+	}{
+		{"lower tier", tiered, 3600, 3600 * 0.018 / 360, nil},
+		{"both tiers", tiered, 13600, (10000*0.018 + 3600*0.036) / 360, nil},
+		{"zero balance", tiered, 0, 0, nil},
+		{"overdrawn", tiered, -500, 0, nil},
+		{"no tiers", AccountProduct{DayCountConvention: DayCountAct365}, 1000, 0, nil},
+		{"unknown convention", AccountProduct{DayCountConvention: "X"}, 1000, 0, ErrUnknownDayCountConvention},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.product.DailyInterest(tt.balance, day(2024, 3, 14))
+
+			if !errors.Is(err, tt.wantErr) || math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("DailyInterest() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOverdraftDailyInterest(t *testing.T) {
+	tests := []struct {
+		name       string
+		overdraft  Overdraft
+		convention string
+		balance    float64
+		want       float64
+		wantErr    error
+	}{
+		{"overdrawn", Overdraft{InterestRate: 0.1825}, DayCountAct365, -1000, -0.5, nil},
+		{"in credit", Overdraft{InterestRate: 0.1825}, DayCountAct365, 1000, 0, nil},
+		{"zero balance", Overdraft{InterestRate: 0.1825}, DayCountAct365, 0, 0, nil},
+		{"no rate", Overdraft{}, DayCountAct365, -1000, 0, nil},
+		{"31st under 30 360", Overdraft{InterestRate: 0.18}, DayCount30360, -1000, 0, nil},
+		{"unknown convention", Overdraft{InterestRate: 0.18}, "X", -1000, 0, ErrUnknownDayCountConvention},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.overdraft.DailyInterest(tt.convention, tt.balance, day(2024, 3, 31))
+
+			if !errors.Is(err, tt.wantErr) || math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("DailyInterest() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOverdrawnBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance float64
+		debit   float64
+		want    float64
+	}{
+		{"becomes overdrawn", 100, 150, 50},
+		{"from exactly zero", 0, 10, 10},
+		{"down to exactly zero", 100, 100, 0},
+		{"stays in credit", 100, 40, 0},
+		{"already overdrawn", -10, 50, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OverdrawnBy(tt.balance, tt.debit); got != tt.want {
+				t.Errorf("OverdrawnBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package bank
+
+// Rules a transfer is evaluated against before it is committed. They name the
+// violated rule in TransferLimitError and are never renamed.
+const (
+	TransferRuleMaxAmountPerTransfer string = "MAX_AMOUNT_PER_TRANSFER"
+	TransferRuleMaxDailyAmount       string = "MAX_DAILY_AMOUNT"
+	TransferRuleMaxMonthlyAmount     string = "MAX_MONTHLY_AMOUNT"
+	TransferRuleMaxTransfersPerHour  string = "MAX_TRANSFERS_PER_HOUR"
+)
+
+// TransferLimit is a set of rules that applies to the transfers out of one
+// account, or of every account when AccountNumber is empty, in one currency,
+// or in every currency when Currency is empty. A zero maximum is not enforced.
+type TransferLimit struct {
+	Name                 string
+	AccountNumber        string
+	Currency             string
+	MaxAmountPerTransfer float64
+	MaxDailyAmount       float64
+	MaxMonthlyAmount     float64
+	MaxTransfersPerHour  int64
+}
@@ -0,0 +1,111 @@
+package bank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxTransactionTags           = 20
+	MaxTransactionTagLength      = 50
+	MaxTransactionCategoryLength = 50
+	// MaxTransactionMetadataLength applies to the counterparty and external
+	// reference fields
+	MaxTransactionMetadataLength = 140
+)
+
+// TransactionMetadata describes what a transaction was for. Category and
+// tags are kept in lower case so that filters don't depend on how a client
+// spelled them.
+type TransactionMetadata struct {
+	Category              string
+	Tags                  []string
+	CounterpartyName      string
+	CounterpartyReference string
+	ExternalReferenceId   string
+}
+
+// Normalize trims every field, lower cases the category and tags and drops
+// empty and repeated tags
+func (m TransactionMetadata) Normalize() TransactionMetadata {
+	res := TransactionMetadata{
+		Category:              strings.ToLower(strings.TrimSpace(m.Category)),
+		CounterpartyName:      strings.TrimSpace(m.CounterpartyName),
+		CounterpartyReference: strings.TrimSpace(m.CounterpartyReference),
+		ExternalReferenceId:   strings.TrimSpace(m.ExternalReferenceId),
+	}
+
+	seen := map[string]bool{}
+
+	for _, t := range m.Tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+
+		if t == "" || seen[t] {
+			continue
+		}
+
+		seen[t] = true
+		res.Tags = append(res.Tags, t)
+	}
+
+	return res
+}
+
+// Validate checks the lengths of a normalized metadata
+func (m TransactionMetadata) Validate() error {
+	switch {
+	case len(m.Category) > MaxTransactionCategoryLength:
+		return fmt.Errorf("%w : category is longer than %v characters", ErrTransactionMetadataInvalid, MaxTransactionCategoryLength)
+	case len(m.Tags) > MaxTransactionTags:
+		return fmt.Errorf("%w : more than %v tags", ErrTransactionMetadataInvalid, MaxTransactionTags)
+	case len(m.CounterpartyName) > MaxTransactionMetadataLength:
+		return fmt.Errorf("%w : counterparty name is longer than %v characters", ErrTransactionMetadataInvalid, MaxTransactionMetadataLength)
+	case len(m.CounterpartyReference) > MaxTransactionMetadataLength:
+		return fmt.Errorf("%w : counterparty reference is longer than %v characters", ErrTransactionMetadataInvalid, MaxTransactionMetadataLength)
+	case len(m.ExternalReferenceId) > MaxTransactionMetadataLength:
+		return fmt.Errorf("%w : external reference id is longer than %v characters", ErrTransactionMetadataInvalid, MaxTransactionMetadataLength)
+	}
+
+	for _, t := range m.Tags {
+		if len(t) > MaxTransactionTagLength {
+			return fmt.Errorf("%w : tag %q is longer than %v characters", ErrTransactionMetadataInvalid, t, MaxTransactionTagLength)
+		}
+	}
+
+	return nil
+}
+
+// TransactionRecord is a posted transaction as returned by queries
+type TransactionRecord struct {
+	TransactionUuid uuid.UUID
+	AccountNumber   string
+	TransactionType string
+	Amount          float64
+	Timestamp       time.Time
+	Notes           string
+	TransferUuid    uuid.UUID
+	Metadata        TransactionMetadata
+}
+
+// TransactionFilter selects transactions of one account. Category, Tag and
+// ExternalReferenceId match exactly, CounterpartyName matches any part of
+// the name regardless of case.
+type TransactionFilter struct {
+	AccountNumber       string
+	TransactionType     string
+	From                time.Time
+	To                  time.Time
+	Category            string
+	Tag                 string
+	CounterpartyName    string
+	ExternalReferenceId string
+	PageToken           string
+	Limit               int
+}
+
+var ErrTransactionMetadataInvalid = errors.New("transaction metadata is not valid")
+var ErrTransactionInvalidPageToken = errors.New("invalid transaction page token")
@@ -0,0 +1,77 @@
+package bank
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTransactionMetadataNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		m    TransactionMetadata
+		want TransactionMetadata
+	}{
+		{"empty", TransactionMetadata{}, TransactionMetadata{}},
+		{"trimmed and lower cased", TransactionMetadata{
+			Category:              "  Groceries ",
+			CounterpartyName:      " ACME Stores ",
+			CounterpartyReference: " 12345 ",
+			ExternalReferenceId:   " INV-1 ",
+		}, TransactionMetadata{
+			Category:              "groceries",
+			CounterpartyName:      "ACME Stores",
+			CounterpartyReference: "12345",
+			ExternalReferenceId:   "INV-1",
+		}},
+		{"tags deduplicated in order", TransactionMetadata{
+			Tags: []string{"Food", " weekly", "", "FOOD", "  ", "Weekly ", "home"},
+		}, TransactionMetadata{
+			Tags: []string{"food", "weekly", "home"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Normalize(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Normalize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransactionMetadataValidate(t *testing.T) {
+	tooManyTags := make([]string, MaxTransactionTags+1)
+	for i := range tooManyTags {
+		tooManyTags[i] = strings.Repeat("t", i+1)
+	}
+
+	tests := []struct {
+		name    string
+		m       TransactionMetadata
+		wantErr error
+	}{
+		{"empty", TransactionMetadata{}, nil},
+		{"at the limits", TransactionMetadata{
+			Category:            strings.Repeat("c", MaxTransactionCategoryLength),
+			Tags:                tooManyTags[:MaxTransactionTags],
+			CounterpartyName:    strings.Repeat("n", MaxTransactionMetadataLength),
+			ExternalReferenceId: strings.Repeat("e", MaxTransactionMetadataLength),
+		}, nil},
+		{"long category", TransactionMetadata{Category: strings.Repeat("c", MaxTransactionCategoryLength+1)}, ErrTransactionMetadataInvalid},
+		{"too many tags", TransactionMetadata{Tags: tooManyTags}, ErrTransactionMetadataInvalid},
+		{"long tag", TransactionMetadata{Tags: []string{"ok", strings.Repeat("t", MaxTransactionTagLength+1)}}, ErrTransactionMetadataInvalid},
+		{"long counterparty name", TransactionMetadata{CounterpartyName: strings.Repeat("n", MaxTransactionMetadataLength+1)}, ErrTransactionMetadataInvalid},
+		{"long counterparty reference", TransactionMetadata{CounterpartyReference: strings.Repeat("r", MaxTransactionMetadataLength+1)}, ErrTransactionMetadataInvalid},
+		{"long external reference id", TransactionMetadata{ExternalReferenceId: strings.Repeat("e", MaxTransactionMetadataLength+1)}, ErrTransactionMetadataInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.Validate(); !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
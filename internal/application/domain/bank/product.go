@@ -0,0 +1,55 @@
+package bank
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProductTypeChecking string = "CHECKING"
+	ProductTypeSavings  string = "SAVINGS"
+	ProductTypeBusiness string = "BUSINESS"
+)
+
+// AccountProduct is what an account is sold as. MinBalance is the balance,
+// net of holds and before any overdraft, an account must keep, zero means no
+// minimum. An empty Currencies allows every currency. The transfer limits and
+// fee rules of a product are evaluated with those of the account.
+type AccountProduct struct {
+	ProductUuid         uuid.UUID
+	ProductCode         string
+	ProductName         string
+	ProductType         string
+	DayCountConvention  string
+	MinBalance          float64
+	TransfersOutAllowed bool
+	Currencies          []string
+	InterestTiers       []InterestTier
+}
+
+func (p AccountProduct) AllowsCurrency(currency string) bool {
+	if len(p.Currencies) == 0 {
+		return true
+	}
+
+	for _, c := range p.Currencies {
+		if c == currency {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KeepsMinBalance tells whether balance, net of holds, is at or above the
+// minimum balance of the product
+func (p AccountProduct) KeepsMinBalance(balance float64) bool {
+	return p.MinBalance <= 0 || balance >= p.MinBalance
+}
+
+var (
+	ErrProductCurrencyNotAllowed     = errors.New("currency is not allowed by the account product")
+	ErrProductMinBalance             = errors.New("balance would fall below the account product minimum")
+	ErrProductTransfersOutNotAllowed = errors.New("account product does not allow transfers out")
+)
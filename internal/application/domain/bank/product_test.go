@@ -0,0 +1,50 @@
+package bank
+
+import "testing"
+
+func TestAccountProductAllowsCurrency(t *testing.T) {
+	tests := []struct {
+		name       string
+		currencies []string
+		currency   string
+		want       bool
+	}{
+		{"any currency", nil, "JPY", true},
+		{"listed", []string{"USD", "EUR"}, "EUR", true},
+		{"not listed", []string{"USD", "EUR"}, "GBP", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := AccountProduct{Currencies: tt.currencies}
+
+			if got := p.AllowsCurrency(tt.currency); got != tt.want {
+				t.Errorf("AllowsCurrency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountProductKeepsMinBalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		minBalance float64
+		balance    float64
+		want       bool
+	}{
+		{"no minimum", 0, -50, true},
+		{"above", 100, 100.01, true},
+		{"at", 100, 100, true},
+		{"below", 100, 99.99, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := AccountProduct{MinBalance: tt.minBalance}
+
+			if got := p.KeepsMinBalance(tt.balance); got != tt.want {
+				t.Errorf("KeepsMinBalance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package bank
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReversalStatusNone    string = "NONE"
+	ReversalStatusPartial string = "PARTIAL"
+	ReversalStatusFull    string = "FULL"
+)
+
+// TransferReversal moves Amount of a completed transfer back from its
+// destination to its source. ReversedAmount is the total reversed so far,
+// this reversal included.
+type TransferReversal struct {
+	ReversalUuid          uuid.UUID
+	TransferUuid          uuid.UUID
+	Amount                float64
+	Reason                string
+	Currency              string
+	ReversedAmount        float64
+	RemainingAmount       float64
+	ReversalStatus        string
+	DebitTransactionUuid  uuid.UUID
+	CreditTransactionUuid uuid.UUID
+	Timestamp             time.Time
+	Origin                Origin
+}
+
+var ErrTransferNotFound = errors.New("transfer not found")
+var ErrTransferNotReversible = errors.New("only successful transfers can be reversed")
+var ErrTransferAlreadyReversed = errors.New("transfer is already fully reversed")
+var ErrReversalExceedsRemaining = errors.New("reversal amount exceeds the amount left to reverse")
+var ErrReversalInsufficientFunds = errors.New("destination account can no longer fund the reversal")
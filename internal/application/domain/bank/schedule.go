@@ -0,0 +1,108 @@
+package bank
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScheduleFrequencyOnce    string = "ONCE"
+	ScheduleFrequencyDaily   string = "DAILY"
+	ScheduleFrequencyWeekly  string = "WEEKLY"
+	ScheduleFrequencyMonthly string = "MONTHLY"
+)
+
+const (
+	ScheduleStatusActive    string = "ACTIVE"
+	ScheduleStatusCompleted string = "COMPLETED"
+	ScheduleStatusCancelled string = "CANCELLED"
+)
+
+const (
+	ExecutionStatusStarted string = "STARTED"
+	ExecutionStatusSuccess string = "SUCCESS"
+	ExecutionStatusFailure string = "FAILURE"
+	ExecutionStatusRetry   string = "RETRY"
+	// ExecutionStatusUnknown marks an execution interrupted by a restart. The
+	// transfer may or may not have been made, so it is never retried.
+	ExecutionStatusUnknown string = "UNKNOWN"
+)
+
+const (
+	// ScheduledTransferMaxAttempts is how often an occurrence is tried when
+	// the transfer fails with a transient error
+	ScheduledTransferMaxAttempts = 5
+	// ScheduledTransferRetryBackoff is the delay before the first retry, it
+	// doubles with every further attempt
+	ScheduledTransferRetryBackoff = time.Minute
+	// ScheduledTransferLease is how long a claimed occurrence is reserved to
+	// the scheduler executing it
+	ScheduledTransferLease = 10 * time.Minute
+)
+
+// ScheduledTransfer is a standing order. Occurrence k is due at
+// NextOccurrence(StartAt, Frequency, Interval, k); NextRunAt is the due time
+// of the occurrence the scheduler works on.
+type ScheduledTransfer struct {
+	ScheduleUuid      uuid.UUID
+	FromAccountNumber string
+	ToAccountNumber   string
+	Currency          string
+	Amount            float64
+	Frequency         string
+	Interval          int
+	StartAt           time.Time
+	EndAt             time.Time
+	NextRunAt         time.Time
+	Status            string
+	CreatedBy         string
+	Executions        []ScheduledTransferExecution
+	Origin            Origin
+}
+
+type ScheduledTransferExecution struct {
+	ExecutionUuid uuid.UUID
+	ScheduledFor  time.Time
+	Attempt       int
+	Status        string
+	TransferUuid  uuid.UUID
+	Error         string
+	StartedAt     time.Time
+	FinishedAt    time.Time
+}
+
+// NextOccurrence returns the due time of occurrence k (starting at 0) of a
+// schedule. Monthly schedules keep the day of month of start, clamped to the
+// last day of shorter months, so the 31st runs on Feb 28 and again on Mar 31.
+func NextOccurrence(start time.Time, frequency string, interval int, k int) time.Time {
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch frequency {
+	case ScheduleFrequencyDaily:
+		return start.AddDate(0, 0, k*interval)
+	case ScheduleFrequencyWeekly:
+		return start.AddDate(0, 0, 7*k*interval)
+	case ScheduleFrequencyMonthly:
+		firstOfMonth := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		target := firstOfMonth.AddDate(0, k*interval, 0)
+		lastDay := target.AddDate(0, 1, -1).Day()
+
+		day := start.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+
+		return target.AddDate(0, 0, day-1)
+	default:
+		return start
+	}
+}
+
+var ErrScheduleNotFound = errors.New("scheduled transfer not found")
+var ErrScheduleNotActive = errors.New("scheduled transfer is not active")
+var ErrScheduleUnknownFrequency = errors.New("unknown schedule frequency")
+var ErrScheduleInvalidPeriod = errors.New("schedule must start in the future and end after it starts")
@@ -0,0 +1,44 @@
+package bank
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		frequency string
+		interval  int
+		k         int
+		want      time.Time
+	}{
+		{"once", at(2024, 1, 15), ScheduleFrequencyOnce, 1, 3, at(2024, 1, 15)},
+		{"first occurrence", at(2024, 1, 15), ScheduleFrequencyDaily, 1, 0, at(2024, 1, 15)},
+		{"daily", at(2024, 2, 28), ScheduleFrequencyDaily, 1, 2, at(2024, 3, 1)},
+		{"every other day", at(2024, 1, 15), ScheduleFrequencyDaily, 2, 3, at(2024, 1, 21)},
+		{"weekly", at(2024, 1, 15), ScheduleFrequencyWeekly, 1, 3, at(2024, 2, 5)},
+		{"fortnightly", at(2024, 1, 15), ScheduleFrequencyWeekly, 2, 1, at(2024, 1, 29)},
+		{"zero interval counts as one", at(2024, 1, 15), ScheduleFrequencyWeekly, 0, 1, at(2024, 1, 22)},
+		{"monthly", at(2024, 1, 15), ScheduleFrequencyMonthly, 1, 1, at(2024, 2, 15)},
+		{"monthly clamped to february of a leap year", at(2024, 1, 31), ScheduleFrequencyMonthly, 1, 1, at(2024, 2, 29)},
+		{"monthly clamped to february", at(2023, 1, 31), ScheduleFrequencyMonthly, 1, 1, at(2023, 2, 28)},
+		{"monthly back to the 31st after february", at(2023, 1, 31), ScheduleFrequencyMonthly, 1, 2, at(2023, 3, 31)},
+		{"monthly clamped to a 30 day month", at(2024, 1, 31), ScheduleFrequencyMonthly, 1, 3, at(2024, 4, 30)},
+		{"quarterly across the year", at(2024, 11, 30), ScheduleFrequencyMonthly, 3, 1, at(2025, 2, 28)},
+		{"unknown frequency", at(2024, 1, 15), "YEARLY", 1, 1, at(2024, 1, 15)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextOccurrence(tt.start, tt.frequency, tt.interval, tt.k); !got.Equal(tt.want) {
+				t.Errorf("NextOccurrence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package bank
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatementFormatCsv  string = "CSV"
+	StatementFormatJson string = "JSON"
+	StatementFormatText string = "TEXT"
+)
+
+type StatementLine struct {
+	TransactionUuid uuid.UUID
+	Timestamp       time.Time
+	TransactionType string
+	Amount          float64
+	RunningBalance  float64
+	Notes           string
+}
+
+type Statement struct {
+	AccountNumber  string
+	AccountName    string
+	Currency       string
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	OpeningBalance float64
+	ClosingBalance float64
+	Lines          []StatementLine
+}
+
+var ErrStatementAccountNotFound = errors.New("statement account not found")
+var ErrStatementInvalidPeriod = errors.New("statement period start must be before period end")
+var ErrStatementUnknownFormat = errors.New("unknown statement format")
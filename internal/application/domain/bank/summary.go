@@ -0,0 +1,32 @@
+package bank
+
+import "time"
+
+// SummaryPeriodStart returns the start of the UTC day, week or month ts falls
+// in. Weeks start on Monday, as date_trunc('week') does.
+func SummaryPeriodStart(ts time.Time, period string) time.Time {
+	ts = ts.UTC()
+	day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case SummaryPeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case SummaryPeriodMonth:
+		return time.Date(ts.Year(), ts.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// NextSummaryPeriod returns the start of the period after the one starting at
+// start
+func NextSummaryPeriod(start time.Time, period string) time.Time {
+	switch period {
+	case SummaryPeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case SummaryPeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,52 @@
+package bank
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummaryPeriodStart(t *testing.T) {
+	tests := []struct {
+		name   string
+		ts     time.Time
+		period string
+		want   time.Time
+	}{
+		{"day", time.Date(2024, 3, 14, 15, 9, 26, 0, time.UTC), SummaryPeriodDay, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)},
+		{"day in another zone", time.Date(2024, 3, 15, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*3600)), SummaryPeriodDay, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)},
+		{"week from thursday", time.Date(2024, 3, 14, 15, 0, 0, 0, time.UTC), SummaryPeriodWeek, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"week from monday", time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), SummaryPeriodWeek, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"week from sunday", time.Date(2024, 3, 17, 23, 59, 0, 0, time.UTC), SummaryPeriodWeek, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"week across months", time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC), SummaryPeriodWeek, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC)},
+		{"month", time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC), SummaryPeriodMonth, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummaryPeriodStart(tt.ts, tt.period); !got.Equal(tt.want) {
+				t.Errorf("SummaryPeriodStart(%v, %v) = %v, want %v", tt.ts, tt.period, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextSummaryPeriod(t *testing.T) {
+	tests := []struct {
+		name   string
+		start  time.Time
+		period string
+		want   time.Time
+	}{
+		{"day", time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), SummaryPeriodDay, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"week", time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), SummaryPeriodWeek, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"month", time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), SummaryPeriodMonth, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextSummaryPeriod(tt.start, tt.period); !got.Equal(tt.want) {
+				t.Errorf("NextSummaryPeriod(%v, %v) = %v, want %v", tt.start, tt.period, got, tt.want)
+			}
+		})
+	}
+}
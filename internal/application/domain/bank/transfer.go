@@ -0,0 +1,86 @@
+package bank
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TransferStatusPending    string = "PENDING"
+	TransferStatusProcessing string = "PROCESSING"
+	TransferStatusCompleted  string = "COMPLETED"
+	TransferStatusFailed     string = "FAILED"
+	TransferStatusReversed   string = "REVERSED"
+)
+
+// transferTransitions lists the states a transfer may move to from each
+// state. Failed and reversed transfers are final.
+var transferTransitions = map[string][]string{
+	TransferStatusPending:    {TransferStatusProcessing, TransferStatusFailed},
+	TransferStatusProcessing: {TransferStatusCompleted, TransferStatusFailed},
+	TransferStatusCompleted:  {TransferStatusReversed},
+}
+
+func CanTransitionTransfer(from string, to string) bool {
+	for _, s := range transferTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transfer is the lifecycle of a transfer. The time of each state is zero
+// until the transfer reaches it, CreatedAt is when it became pending.
+type Transfer struct {
+	TransferUuid      uuid.UUID
+	FromAccountNumber string
+	ToAccountNumber   string
+	Currency          string
+	Amount            float64
+	Status            string
+	FailureReason     string
+	ReversedAmount    float64
+	ReversalStatus    string
+	CreatedAt         time.Time
+	ProcessingAt      time.Time
+	CompletedAt       time.Time
+	FailedAt          time.Time
+	ReversedAt        time.Time
+	Reversals         []TransferReversal
+	Transactions      []TransferTransaction
+	Fees              []Fee
+}
+
+// TransferTransaction is a debit or credit posted by a transfer or by one of
+// its reversals
+type TransferTransaction struct {
+	TransactionUuid uuid.UUID
+	AccountNumber   string
+	TransactionType string
+	Amount          float64
+	Timestamp       time.Time
+	Notes           string
+}
+
+// TransferFilter selects transfers, zero fields do not filter. AccountNumber
+// matches either side of a transfer. PageToken continues a previous listing.
+type TransferFilter struct {
+	AccountNumber     string
+	FromAccountNumber string
+	ToAccountNumber   string
+	Status            string
+	Currency          string
+	MinAmount         float64
+	MaxAmount         float64
+	From              time.Time
+	To                time.Time
+	PageToken         string
+	Limit             int
+}
+
+var ErrTransferInvalidPageToken = errors.New("invalid transfer page token")
+var ErrTransferInvalidTransition = errors.New("transfer can't move to the requested status")
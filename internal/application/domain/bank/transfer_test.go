@@ -0,0 +1,32 @@
+package bank
+
+import "testing"
+
+func TestCanTransitionTransfer(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{TransferStatusPending, TransferStatusProcessing, true},
+		{TransferStatusPending, TransferStatusFailed, true},
+		{TransferStatusPending, TransferStatusCompleted, false},
+		{TransferStatusProcessing, TransferStatusCompleted, true},
+		{TransferStatusProcessing, TransferStatusFailed, true},
+		{TransferStatusProcessing, TransferStatusPending, false},
+		{TransferStatusCompleted, TransferStatusReversed, true},
+		{TransferStatusCompleted, TransferStatusFailed, false},
+		{TransferStatusFailed, TransferStatusProcessing, false},
+		{TransferStatusReversed, TransferStatusCompleted, false},
+		{TransferStatusCompleted, TransferStatusCompleted, false},
+		{"UNKNOWN", TransferStatusProcessing, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			if got := CanTransitionTransfer(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransitionTransfer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
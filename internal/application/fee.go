@@ -0,0 +1,299 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// feeAssessment holds the fees an operation will be charged and the account
+// they are credited to
+type feeAssessment struct {
+	fees    []dbank.Fee
+	total   float64
+	revenue database.BankAccountOrm
+}
+
+// add appends the fees of o, assessed for the same account in cur
+func (a *feeAssessment) add(cur dbank.Currency, o feeAssessment) {
+	if o.total == 0 {
+		return
+	}
+
+	a.fees = append(a.fees, o.fees...)
+	a.total = cur.Round(a.total + o.total)
+	a.revenue = o.revenue
+}
+
+// feeRules returns the fee rules of the account and of its product for an
+// operation
+func (b *BankService) feeRules(accountOrm database.BankAccountOrm, operation string) ([]dbank.FeeRule, error) {
+	ruleOrms, err := b.db.GetBankFeeRules(accountOrm.ProductUuid, accountOrm.AccountUuid, operation)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("fee rule lookup", err)
+	}
+
+	ruleUuids := make([]uuid.UUID, 0, len(ruleOrms))
+	for _, r := range ruleOrms {
+		ruleUuids = append(ruleUuids, r.FeeRuleUuid)
+	}
+
+	tierOrms, err := b.db.GetBankFeeRuleTiers(ruleUuids)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("fee tier lookup", err)
+	}
+
+	tiers := map[uuid.UUID][]dbank.FeeTier{}
+	for _, t := range tierOrms {
+		tiers[t.FeeRuleUuid] = append(tiers[t.FeeRuleUuid], dbank.FeeTier{
+			MinAmount:  t.MinAmount,
+			FlatAmount: t.FlatAmount,
+			Rate:       t.Rate,
+		})
+	}
+
+	rules := make([]dbank.FeeRule, 0, len(ruleOrms))
+
+	for _, r := range ruleOrms {
+		rule := dbank.FeeRule{
+			FeeRuleUuid: r.FeeRuleUuid,
+			FeeName:     r.FeeName,
+			Operation:   r.Operation,
+			FeeType:     r.FeeType,
+			FlatAmount:  r.FlatAmount,
+			Rate:        r.Rate,
+			MinFee:      r.MinFee,
+			MaxFee:      r.MaxFee,
+			Tiers:       tiers[r.FeeRuleUuid],
+		}
+
+		if r.Currency != nil {
+			rule.Currency = *r.Currency
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// assessFees evaluates the fee rules of the account and of its product for
+// an operation of amount in cur. converted tells whether the operation
+// converted amount from another currency.
+func (b *BankService) assessFees(accountOrm database.BankAccountOrm, operation string, cur dbank.Currency, amount float64, converted bool) (feeAssessment, error) {
+	var res feeAssessment
+
+	rules, err := b.feeRules(accountOrm, operation)
+
+	if err != nil {
+		return res, err
+	}
+
+	for _, r := range rules {
+		if !r.Applies(operation, cur.Code) {
+			continue
+		}
+
+		fee := cur.Round(r.Evaluate(amount, converted))
+
+		if fee <= 0 {
+			continue
+		}
+
+		res.fees = append(res.fees, dbank.Fee{
+			FeeRuleUuid: r.FeeRuleUuid,
+			FeeName:     r.FeeName,
+			FeeType:     r.FeeType,
+			Amount:      fee,
+			Currency:    cur.Code,
+		})
+		res.total = cur.Round(res.total + fee)
+	}
+
+	if res.total == 0 {
+		return res, nil
+	}
+
+	res.revenue, err = b.db.GetBankFeeRevenueAccount(cur.Code)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return res, fmt.Errorf("%w %v", dbank.ErrFeeRevenueAccountNotFound, cur.Code)
+	} else if err != nil {
+		return res, dbank.NewUnavailableError("fee revenue account lookup", err)
+	}
+
+	if res.revenue.AccountUuid == accountOrm.AccountUuid {
+		return feeAssessment{}, nil
+	}
+
+	return res, nil
+}
+
+// postFees charges the assessed fees in the database transaction of the
+// operation they are for, once its debit was checked against the available
+// balance with the fees included. transferUuid or transactionUuid identifies
+// the operation.
+func (b *BankService) postFees(accountOrm database.BankAccountOrm, a feeAssessment, transferUuid *uuid.UUID, transactionUuid *uuid.UUID, now time.Time) error {
+	for _, f := range a.fees {
+		f.ChargeUuid = uuid.New()
+		f.FeeTransactionUuid = uuid.New()
+		f.Timestamp = now
+
+		metadata := database.TransactionMetadataOrm{
+			Category:            dbank.FeeCategory,
+			ExternalReferenceId: "fee:" + f.ChargeUuid.String(),
+		}
+
+		debitOrm := database.BankTransactionOrm{
+			TransactionUuid:      f.FeeTransactionUuid,
+			AccountUuid:          accountOrm.AccountUuid,
+			TransactionType:      dbank.TransactionTypeOut,
+			TransactionTimestamp: now,
+			Amount:               f.Amount,
+			Notes:                f.FeeName,
+			TransferUuid:         transferUuid,
+			Metadata:             metadata,
+			CreatedAt:            now,
+			UpdatedAt:            now,
+		}
+
+		creditOrm := database.BankTransactionOrm{
+			TransactionUuid:      uuid.New(),
+			AccountUuid:          a.revenue.AccountUuid,
+			TransactionType:      dbank.TransactionTypeIn,
+			TransactionTimestamp: now,
+			Amount:               f.Amount,
+			Notes:                f.FeeName + " from " + accountOrm.AccountNumber,
+			TransferUuid:         transferUuid,
+			Metadata:             metadata,
+			CreatedAt:            now,
+			UpdatedAt:            now,
+		}
+
+		chargeOrm := database.BankFeeChargeOrm{
+			ChargeUuid:             f.ChargeUuid,
+			FeeRuleUuid:            f.FeeRuleUuid,
+			AccountUuid:            accountOrm.AccountUuid,
+			TransferUuid:           transferUuid,
+			TransactionUuid:        transactionUuid,
+			FeeName:                f.FeeName,
+			FeeType:                f.FeeType,
+			Amount:                 f.Amount,
+			Currency:               f.Currency,
+			FeeTransactionUuid:     debitOrm.TransactionUuid,
+			RevenueTransactionUuid: creditOrm.TransactionUuid,
+			CreatedAt:              now,
+		}
+
+		if err := b.db.CreateBankFeeCharge(chargeOrm, debitOrm, creditOrm); err != nil {
+			log.Printf("Can't charge %v to %v : %v\n", f.FeeName, accountOrm.AccountNumber, err)
+			return dbank.NewUnavailableError("fee charge", err)
+		}
+	}
+
+	return nil
+}
+
+// refundTransferFees refunds the part of the fees charged for a transfer
+// that a reversal of reversed takes back, see dbank.FeeRefund. It runs in
+// the database transaction of the reversal, once the transfer is updated.
+func (b *BankService) refundTransferFees(transferUuid uuid.UUID, reversed float64, cur dbank.Currency, now time.Time) error {
+	chargeOrms, err := b.db.GetBankFeeChargesByTransfer(transferUuid)
+
+	if err != nil {
+		return dbank.NewUnavailableError("fee charge lookup", err)
+	}
+
+	if len(chargeOrms) == 0 {
+		return nil
+	}
+
+	// read again, the reversal holds the lock of the transfer
+	transferOrm, err := b.db.GetBankTransfer(transferUuid)
+
+	if err != nil {
+		return dbank.NewUnavailableError("transfer lookup", err)
+	}
+
+	revenueOrm, err := b.db.GetBankFeeRevenueAccount(cur.Code)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return fmt.Errorf("%w %v", dbank.ErrFeeRevenueAccountNotFound, cur.Code)
+	} else if err != nil {
+		return dbank.NewUnavailableError("fee revenue account lookup", err)
+	}
+
+	before := cur.Round(transferOrm.ReversedAmount - reversed)
+
+	for _, c := range chargeOrms {
+		refund := dbank.FeeRefund(cur, c.Amount, transferOrm.Amount, before, transferOrm.ReversedAmount)
+
+		if refund <= 0 {
+			continue
+		}
+
+		metadata := database.TransactionMetadataOrm{
+			Category:            dbank.FeeCategory,
+			ExternalReferenceId: "fee-refund:" + c.ChargeUuid.String(),
+		}
+
+		debitOrm := database.BankTransactionOrm{
+			TransactionUuid:      uuid.New(),
+			AccountUuid:          revenueOrm.AccountUuid,
+			TransactionType:      dbank.TransactionTypeOut,
+			TransactionTimestamp: now,
+			Amount:               refund,
+			Notes:                "Refund of " + c.FeeName,
+			TransferUuid:         &transferUuid,
+			Metadata:             metadata,
+			CreatedAt:            now,
+			UpdatedAt:            now,
+		}
+
+		creditOrm := debitOrm
+		creditOrm.TransactionUuid = uuid.New()
+		creditOrm.AccountUuid = c.AccountUuid
+		creditOrm.TransactionType = dbank.TransactionTypeIn
+
+		for _, t := range []database.BankTransactionOrm{debitOrm, creditOrm} {
+			if _, err := b.db.CreateTransaction(database.BankAccountOrm{AccountUuid: t.AccountUuid}, t); err != nil {
+				return dbank.NewUnavailableError("fee refund", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetTransferFees returns the fees charged for a transfer
+func (b *BankService) GetTransferFees(transferUuid uuid.UUID) ([]dbank.Fee, error) {
+	chargeOrms, err := b.db.GetBankFeeChargesByTransfer(transferUuid)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("fee charge lookup", err)
+	}
+
+	fees := make([]dbank.Fee, 0, len(chargeOrms))
+
+	for _, c := range chargeOrms {
+		fees = append(fees, dbank.Fee{
+			ChargeUuid:         c.ChargeUuid,
+			FeeRuleUuid:        c.FeeRuleUuid,
+			FeeName:            c.FeeName,
+			FeeType:            c.FeeType,
+			Amount:             c.Amount,
+			Currency:           c.Currency,
+			FeeTransactionUuid: c.FeeTransactionUuid,
+			Timestamp:          c.CreatedAt,
+		})
+	}
+
+	return fees, nil
+}
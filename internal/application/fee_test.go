@@ -0,0 +1,213 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// feeDb serves fee rules, charges and one transfer and records the
+// transactions posted, any other call panics
+type feeDb struct {
+	port.BankDatabasePort
+	rules    []database.BankFeeRuleOrm
+	revenue  database.BankAccountOrm
+	charges  []database.BankFeeChargeOrm
+	transfer database.BankTransferOrm
+	posted   []database.BankTransactionOrm
+}
+
+func (d *feeDb) GetBankFeeRules(productUuid *uuid.UUID, accountUuid uuid.UUID, operation string) ([]database.BankFeeRuleOrm, error) {
+	var res []database.BankFeeRuleOrm
+	for _, r := range d.rules {
+		ofAccount := r.AccountUuid != nil && *r.AccountUuid == accountUuid
+		ofProduct := r.ProductUuid != nil && productUuid != nil && *r.ProductUuid == *productUuid
+
+		if (ofAccount || ofProduct) && r.Operation == operation {
+			res = append(res, r)
+		}
+	}
+
+	return res, nil
+}
+
+func (d *feeDb) GetBankFeeRuleTiers(feeRuleUuids []uuid.UUID) ([]database.BankFeeRuleTierOrm, error) {
+	return nil, nil
+}
+
+func (d *feeDb) GetBankFeeRevenueAccount(currency string) (database.BankAccountOrm, error) {
+	return d.revenue, nil
+}
+
+func (d *feeDb) GetBankFeeChargesByTransfer(transferUuid uuid.UUID) ([]database.BankFeeChargeOrm, error) {
+	return d.charges, nil
+}
+
+func (d *feeDb) GetBankTransfer(transferUuid uuid.UUID) (database.BankTransferOrm, error) {
+	return d.transfer, nil
+}
+
+func (d *feeDb) CreateTransaction(acct database.BankAccountOrm, t database.BankTransactionOrm) (uuid.UUID, error) {
+	d.posted = append(d.posted, t)
+	return t.TransactionUuid, nil
+}
+
+func TestAssessOverdraftFees(t *testing.T) {
+	productUuid := uuid.New()
+	accountUuid := uuid.New()
+	usd, _ := dbank.Currencies.Find("USD")
+
+	db := &feeDb{
+		revenue: database.BankAccountOrm{AccountUuid: uuid.New()},
+		rules: []database.BankFeeRuleOrm{
+			{FeeRuleUuid: uuid.New(), ProductUuid: &productUuid, FeeName: "Overdraft fee", Operation: dbank.FeeOperationOverdraft, FeeType: dbank.FeeTypeFlat, FlatAmount: 5},
+			{FeeRuleUuid: uuid.New(), ProductUuid: &productUuid, FeeName: "Overdraft usage", Operation: dbank.FeeOperationOverdraft, FeeType: dbank.FeeTypePercentage, Rate: 0.01},
+			{FeeRuleUuid: uuid.New(), ProductUuid: &productUuid, FeeName: "Withdrawal fee", Operation: dbank.FeeOperationWithdrawal, FeeType: dbank.FeeTypeFlat, FlatAmount: 1},
+			{FeeRuleUuid: uuid.New(), AccountUuid: &accountUuid, FeeName: "Overdraft fee", Operation: dbank.FeeOperationOverdraft, FeeType: dbank.FeeTypeFlat, FlatAmount: 12},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		balance float64
+		debit   float64
+		account uuid.UUID
+		product *uuid.UUID
+		want    float64
+	}{
+		{"becomes overdrawn", 100, 300, uuid.New(), &productUuid, 7},
+		{"stays in credit", 100, 100, uuid.New(), &productUuid, 0},
+		{"already overdrawn", -50, 10, uuid.New(), &productUuid, 0},
+		{"no product", 100, 300, uuid.New(), nil, 0},
+		{"account rule without product", 100, 300, accountUuid, nil, 12},
+		{"account rule with the product rules", 100, 300, accountUuid, &productUuid, 19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBankService(db)
+
+			a, err := b.assessOverdraftFees(database.BankAccountOrm{
+				AccountUuid:    tt.account,
+				CurrentBalance: tt.balance,
+				ProductUuid:    tt.product,
+			}, usd, tt.debit)
+
+			if err != nil || a.total != tt.want {
+				t.Errorf("assessOverdraftFees() = %v, %v, want %v", a.total, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverdraftFeeWithinLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		debit       float64
+		wantBalance float64
+		wantErr     bool
+	}{
+		{"stays in credit", 40, 10, false},
+		{"fee reaches the limit", 140, -100, false},
+		{"fee beyond the limit", 145, 50, true},
+		{"debit reaches the limit", 150, 50, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := database.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "7835697001", Currency: "USD", CurrentBalance: 50, OverdraftLimit: 100}
+			revenue := database.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "7835690000", Currency: "USD"}
+
+			db := newLedgerDb(account, revenue)
+			db.revenue = revenue.AccountUuid
+			db.feeRules = []database.BankFeeRuleOrm{
+				{FeeRuleUuid: uuid.New(), AccountUuid: &account.AccountUuid, FeeName: "Overdraft fee", Operation: dbank.FeeOperationOverdraft, FeeType: dbank.FeeTypeFlat, FlatAmount: 10},
+			}
+
+			b := NewBankService(db)
+
+			_, err := b.createTransaction(account.AccountNumber, dbank.Transaction{TransactionType: dbank.TransactionTypeOut, Amount: tt.debit})
+
+			var insufficient *dbank.InsufficientFundsError
+
+			if tt.wantErr {
+				// the fee is requested with the debit
+				if !errors.As(err, &insufficient) || insufficient.Available != 150 || insufficient.Requested != tt.debit+10 {
+					t.Errorf("createTransaction() = %v, want insufficient funds for %v out of 150", err, tt.debit+10)
+				}
+			} else if err != nil {
+				t.Errorf("createTransaction() = %v, want nil", err)
+			}
+
+			if got := db.accounts[account.AccountUuid].CurrentBalance; got != tt.wantBalance {
+				t.Errorf("balance = %v, want %v", got, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestRefundTransferFees(t *testing.T) {
+	usd, _ := dbank.Currencies.Find("USD")
+	payerUuid := uuid.New()
+	revenueUuid := uuid.New()
+
+	charges := []database.BankFeeChargeOrm{
+		{ChargeUuid: uuid.New(), AccountUuid: payerUuid, FeeName: "Transfer fee", Amount: 2.5, Currency: "USD"},
+		{ChargeUuid: uuid.New(), AccountUuid: payerUuid, FeeName: "Overdraft fee", Amount: 5, Currency: "USD"},
+	}
+
+	tests := []struct {
+		name     string
+		charges  []database.BankFeeChargeOrm
+		reversed float64
+		// reversedAmount is the reversed amount of the transfer including
+		// this reversal
+		reversedAmount float64
+		want           []float64
+	}{
+		{"full reversal", charges, 100, 100, []float64{2.5, 5}},
+		{"first half", charges, 50, 50, []float64{1.25, 2.5}},
+		{"second half", charges, 50, 100, []float64{1.25, 2.5}},
+		{"no fees", nil, 100, 100, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &feeDb{
+				revenue:  database.BankAccountOrm{AccountUuid: revenueUuid},
+				charges:  tt.charges,
+				transfer: database.BankTransferOrm{Amount: 100, ReversedAmount: tt.reversedAmount},
+			}
+			b := NewBankService(db)
+
+			if err := b.refundTransferFees(uuid.New(), tt.reversed, usd, time.Now()); err != nil {
+				t.Fatalf("refundTransferFees() = %v", err)
+			}
+
+			if len(db.posted) != 2*len(tt.want) {
+				t.Fatalf("posted %v transactions, want %v", len(db.posted), 2*len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				debit, credit := db.posted[2*i], db.posted[2*i+1]
+
+				if debit.AccountUuid != revenueUuid || debit.TransactionType != dbank.TransactionTypeOut || debit.Amount != want {
+					t.Errorf("refund debit %v = %v %v of %v, want OUT %v of the revenue account", i, debit.TransactionType, debit.Amount, debit.AccountUuid, want)
+				}
+
+				if credit.AccountUuid != payerUuid || credit.TransactionType != dbank.TransactionTypeIn || credit.Amount != want {
+					t.Errorf("refund credit %v = %v %v of %v, want IN %v of the payer", i, credit.TransactionType, credit.Amount, credit.AccountUuid, want)
+				}
+
+				if credit.Metadata.Category != dbank.FeeCategory {
+					t.Errorf("refund category = %q, want %q", credit.Metadata.Category, dbank.FeeCategory)
+				}
+			}
+		})
+	}
+}
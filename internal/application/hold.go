@@ -0,0 +1,275 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+func toHold(h database.BankHoldOrm, acct string) dbank.Hold {
+	hold := dbank.Hold{
+		HoldUuid:       h.HoldUuid,
+		AccountNumber:  acct,
+		Currency:       h.Currency,
+		Amount:         h.Amount,
+		Status:         h.Status,
+		Reference:      h.Reference,
+		ExpiresAt:      h.ExpiresAt,
+		CapturedAmount: h.CapturedAmount,
+		CreatedAt:      h.CreatedAt,
+	}
+
+	if h.TransactionUuid != nil {
+		hold.TransactionUuid = *h.TransactionUuid
+	}
+
+	return hold
+}
+
+func holdNotFoundError(holdUuid uuid.UUID) error {
+	return &dbank.NotFoundError{
+		Reason:   dbank.ReasonHoldNotFound,
+		Resource: dbank.ResourceHold,
+		Key:      holdUuid.String(),
+		Err:      dbank.ErrHoldNotFound,
+	}
+}
+
+func holdNotActiveError(h database.BankHoldOrm) error {
+	return &dbank.ConflictError{
+		Reason:   dbank.ReasonHoldNotActive,
+		Resource: dbank.ResourceHold,
+		Key:      h.HoldUuid.String(),
+		Detail:   "hold is " + h.Status,
+		Err:      dbank.ErrHoldNotActive,
+	}
+}
+
+func (b *BankService) PlaceHold(h dbank.Hold) (dbank.Hold, error) {
+	placed, err := b.placeHold(h)
+	b.recordAudit(h.Origin, dbank.AuditActionPlaceHold, h.AccountNumber, h.Amount, h.Currency,
+		fmt.Sprintf("hold %v %v", placed.HoldUuid, h.Reference), err)
+
+	return placed, err
+}
+
+func (b *BankService) placeHold(h dbank.Hold) (dbank.Hold, error) {
+	now := time.Now()
+
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(h.AccountNumber)
+
+	if err != nil {
+		log.Printf("Can't place hold on %v : %v\n", h.AccountNumber, err)
+		return dbank.Hold{}, accountLookupError(h.AccountNumber, err, dbank.ErrAccountNotFound)
+	}
+
+	cur, err := dbank.Currencies.FindEnabled(h.Currency)
+
+	if err != nil {
+		return dbank.Hold{}, err
+	}
+
+	if bankAccountOrm.Currency != cur.Code {
+		return dbank.Hold{}, dbank.ErrCurrencyMismatch
+	}
+
+	h.Amount = cur.Round(h.Amount)
+
+	if h.Amount <= 0 {
+		return dbank.Hold{}, dbank.ErrAmountBelowMinorUnit
+	}
+
+	if h.ExpiresAt.IsZero() {
+		h.ExpiresAt = now.Add(dbank.DefaultHoldExpiry)
+	} else if !h.ExpiresAt.After(now) {
+		return dbank.Hold{}, dbank.ErrHoldInvalidExpiry
+	}
+
+	products := map[uuid.UUID]dbank.AccountProduct{}
+
+	if err := b.checkProductCurrency(products, bankAccountOrm, cur.Code); err != nil {
+		return dbank.Hold{}, err
+	}
+
+	available, held, err := b.availableBalance(bankAccountOrm, now)
+
+	if err != nil {
+		return dbank.Hold{}, err
+	}
+
+	if available < h.Amount {
+		return dbank.Hold{}, &dbank.InsufficientFundsError{
+			AccountNumber: h.AccountNumber,
+			Available:     available,
+			Requested:     h.Amount,
+		}
+	}
+
+	if err := b.checkProductMinBalance(products, bankAccountOrm, held, h.Amount); err != nil {
+		return dbank.Hold{}, err
+	}
+
+	holdOrm := database.BankHoldOrm{
+		HoldUuid:    uuid.New(),
+		AccountUuid: bankAccountOrm.AccountUuid,
+		Amount:      h.Amount,
+		Currency:    cur.Code,
+		Status:      dbank.HoldStatusActive,
+		Reference:   h.Reference,
+		ExpiresAt:   h.ExpiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if _, err := b.db.CreateBankHold(holdOrm); err != nil {
+		return dbank.Hold{}, dbank.NewUnavailableError("hold creation", err)
+	}
+
+	return toHold(holdOrm, h.AccountNumber), nil
+}
+
+// findAccountHold loads a hold and checks that it was placed on the account
+func (b *BankService) findAccountHold(acct string, holdUuid uuid.UUID) (database.BankAccountOrm, database.BankHoldOrm, error) {
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		return bankAccountOrm, database.BankHoldOrm{}, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	holdOrm, err := b.db.GetBankHold(holdUuid)
+
+	if errors.Is(err, database.ErrRecordNotFound) || (err == nil && holdOrm.AccountUuid != bankAccountOrm.AccountUuid) {
+		return bankAccountOrm, holdOrm, holdNotFoundError(holdUuid)
+	} else if err != nil {
+		return bankAccountOrm, holdOrm, dbank.NewUnavailableError("hold lookup", err)
+	}
+
+	return bankAccountOrm, holdOrm, nil
+}
+
+// CaptureHold posts an OUT transaction for amount, or the whole hold when
+// amount is zero, and releases the rest of the hold
+func (b *BankService) CaptureHold(origin dbank.Origin, acct string, holdUuid uuid.UUID, amount float64) (dbank.Hold, error) {
+	captured, err := b.captureHold(acct, holdUuid, amount)
+	b.recordAudit(origin, dbank.AuditActionCaptureHold, acct, captured.CapturedAmount, captured.Currency,
+		fmt.Sprintf("hold %v", holdUuid), err)
+
+	return captured, err
+}
+
+func (b *BankService) captureHold(acct string, holdUuid uuid.UUID, amount float64) (dbank.Hold, error) {
+	now := time.Now()
+
+	_, holdOrm, err := b.findAccountHold(acct, holdUuid)
+
+	if err != nil {
+		return dbank.Hold{}, err
+	}
+
+	if holdOrm.Status != dbank.HoldStatusActive || !holdOrm.ExpiresAt.After(now) {
+		return toHold(holdOrm, acct), holdNotActiveError(holdOrm)
+	}
+
+	if amount == 0 {
+		amount = holdOrm.Amount
+	}
+
+	if cur, err := dbank.Currencies.Find(holdOrm.Currency); err == nil {
+		amount = cur.Round(amount)
+	}
+
+	if amount <= 0 {
+		return toHold(holdOrm, acct), dbank.ErrAmountBelowMinorUnit
+	}
+
+	if amount > holdOrm.Amount {
+		return toHold(holdOrm, acct), dbank.ErrHoldCaptureExceedsAmount
+	}
+
+	transactionOrm := database.BankTransactionOrm{
+		TransactionUuid:      uuid.New(),
+		AccountUuid:          holdOrm.AccountUuid,
+		TransactionType:      dbank.TransactionTypeOut,
+		TransactionTimestamp: now,
+		Amount:               amount,
+		Notes:                "Capture of hold " + holdOrm.Reference,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	ok, err := b.db.CaptureBankHold(holdOrm, transactionOrm, now)
+
+	if err != nil {
+		return toHold(holdOrm, acct), dbank.NewUnavailableError("hold capture", err)
+	}
+
+	if !ok {
+		holdOrm.Status = dbank.HoldStatusExpired
+		if refreshed, err := b.db.GetBankHold(holdUuid); err == nil {
+			holdOrm = refreshed
+		}
+
+		return toHold(holdOrm, acct), holdNotActiveError(holdOrm)
+	}
+
+	holdOrm.Status = dbank.HoldStatusCaptured
+	holdOrm.CapturedAmount = amount
+	holdOrm.TransactionUuid = &transactionOrm.TransactionUuid
+
+	return toHold(holdOrm, acct), nil
+}
+
+func (b *BankService) ReleaseHold(origin dbank.Origin, acct string, holdUuid uuid.UUID) (dbank.Hold, error) {
+	released, err := b.releaseHold(acct, holdUuid)
+	b.recordAudit(origin, dbank.AuditActionReleaseHold, acct, released.Amount, released.Currency,
+		fmt.Sprintf("hold %v", holdUuid), err)
+
+	return released, err
+}
+
+func (b *BankService) releaseHold(acct string, holdUuid uuid.UUID) (dbank.Hold, error) {
+	_, holdOrm, err := b.findAccountHold(acct, holdUuid)
+
+	if err != nil {
+		return dbank.Hold{}, err
+	}
+
+	if holdOrm.Status != dbank.HoldStatusActive {
+		return toHold(holdOrm, acct), holdNotActiveError(holdOrm)
+	}
+
+	ok, err := b.db.UpdateBankHoldStatus(holdUuid, dbank.HoldStatusReleased)
+
+	if err != nil {
+		return toHold(holdOrm, acct), dbank.NewUnavailableError("hold release", err)
+	}
+
+	if !ok {
+		if refreshed, err := b.db.GetBankHold(holdUuid); err == nil {
+			holdOrm = refreshed
+		}
+
+		return toHold(holdOrm, acct), holdNotActiveError(holdOrm)
+	}
+
+	holdOrm.Status = dbank.HoldStatusReleased
+
+	return toHold(holdOrm, acct), nil
+}
+
+// ExpireHolds marks every active hold past its expiry at ts as expired. The
+// funds of such holds are already available, this keeps their status exact.
+func (b *BankService) ExpireHolds(ts time.Time) (int64, error) {
+	expired, err := b.db.ExpireBankHolds(ts)
+
+	if err != nil {
+		return 0, dbank.NewUnavailableError("hold expiry", err)
+	}
+
+	return expired, nil
+}
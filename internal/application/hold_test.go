@@ -0,0 +1,224 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// holdDb serves one account and one hold and records the holds created and
+// the transactions captured, any other call panics
+type holdDb struct {
+	port.BankDatabasePort
+	account  database.BankAccountOrm
+	held     float64
+	hold     database.BankHoldOrm
+	created  []database.BankHoldOrm
+	captured []database.BankTransactionOrm
+}
+
+func (d *holdDb) GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error) {
+	if acct != d.account.AccountNumber {
+		return database.BankAccountOrm{}, database.ErrRecordNotFound
+	}
+
+	return d.account, nil
+}
+
+func (d *holdDb) GetBankHoldsActiveAmount(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	return d.held, nil
+}
+
+func (d *holdDb) CreateBankHold(h database.BankHoldOrm) (uuid.UUID, error) {
+	d.created = append(d.created, h)
+	return h.HoldUuid, nil
+}
+
+func (d *holdDb) GetBankHold(holdUuid uuid.UUID) (database.BankHoldOrm, error) {
+	if holdUuid != d.hold.HoldUuid {
+		return database.BankHoldOrm{}, database.ErrRecordNotFound
+	}
+
+	return d.hold, nil
+}
+
+func (d *holdDb) CaptureBankHold(h database.BankHoldOrm, t database.BankTransactionOrm, ts time.Time) (bool, error) {
+	d.captured = append(d.captured, t)
+	return true, nil
+}
+
+func (d *holdDb) UpdateBankHoldStatus(holdUuid uuid.UUID, status string) (bool, error) {
+	return true, nil
+}
+
+func holdAccount() database.BankAccountOrm {
+	return database.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "7835697001", Currency: "USD", CurrentBalance: 100, OverdraftLimit: 50}
+}
+
+func TestPlaceHold(t *testing.T) {
+	tests := []struct {
+		name       string
+		hold       dbank.Hold
+		held       float64
+		wantAmount float64
+		wantErr    error
+		// wantShort expects an insufficient funds error
+		wantShort bool
+	}{
+		{"within the balance", dbank.Hold{AccountNumber: "7835697001", Currency: "USD", Amount: 80}, 0, 80, nil, false},
+		{"into the overdraft", dbank.Hold{AccountNumber: "7835697001", Currency: "USD", Amount: 150}, 0, 150, nil, false},
+		{"rounded to cents", dbank.Hold{AccountNumber: "7835697001", Currency: "USD", Amount: 10.004}, 0, 10, nil, false},
+		{"beyond the available balance", dbank.Hold{AccountNumber: "7835697001", Currency: "USD", Amount: 120}, 40, 0, nil, true},
+		{"below a cent", dbank.Hold{AccountNumber: "7835697001", Currency: "USD", Amount: 0.004}, 0, 0, dbank.ErrAmountBelowMinorUnit, false},
+		{"other currency", dbank.Hold{AccountNumber: "7835697001", Currency: "EUR", Amount: 10}, 0, 0, dbank.ErrCurrencyMismatch, false},
+		{"already expired", dbank.Hold{AccountNumber: "7835697001", Currency: "USD", Amount: 10, ExpiresAt: time.Now().Add(-time.Minute)}, 0, 0, dbank.ErrHoldInvalidExpiry, false},
+		{"unknown account", dbank.Hold{AccountNumber: "7835697999", Currency: "USD", Amount: 10}, 0, 0, dbank.ErrAccountNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &holdDb{account: holdAccount(), held: tt.held}
+			b := NewBankService(db)
+
+			placed, err := b.placeHold(tt.hold)
+
+			var short *dbank.InsufficientFundsError
+			if tt.wantShort {
+				if !errors.As(err, &short) {
+					t.Fatalf("placeHold() = %v, want insufficient funds", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("placeHold() = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if len(db.created) != 0 {
+					t.Errorf("placeHold() created %v holds, want none", len(db.created))
+				}
+				return
+			}
+
+			if placed.Status != dbank.HoldStatusActive || placed.Amount != tt.wantAmount || len(db.created) != 1 {
+				t.Errorf("placeHold() = %v %v, want ACTIVE %v", placed.Status, placed.Amount, tt.wantAmount)
+			}
+
+			if wantExpiry := time.Now().Add(dbank.DefaultHoldExpiry); placed.ExpiresAt.After(wantExpiry) || placed.ExpiresAt.Before(wantExpiry.Add(-time.Minute)) {
+				t.Errorf("placeHold() expires at %v, want the default expiry", placed.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestCaptureHold(t *testing.T) {
+	account := holdAccount()
+	otherAccountUuid := uuid.New()
+
+	activeHold := func() database.BankHoldOrm {
+		return database.BankHoldOrm{
+			HoldUuid:    uuid.New(),
+			AccountUuid: account.AccountUuid,
+			Amount:      60,
+			Currency:    "USD",
+			Status:      dbank.HoldStatusActive,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		hold       func(h database.BankHoldOrm) database.BankHoldOrm
+		amount     float64
+		wantAmount float64
+		wantErr    error
+	}{
+		{"whole hold", nil, 0, 60, nil},
+		{"part of the hold", nil, 25.5, 25.5, nil},
+		{"more than the hold", nil, 60.01, 0, dbank.ErrHoldCaptureExceedsAmount},
+		{"below a cent", nil, 0.001, 0, dbank.ErrAmountBelowMinorUnit},
+		{"released", func(h database.BankHoldOrm) database.BankHoldOrm {
+			h.Status = dbank.HoldStatusReleased
+			return h
+		}, 0, 0, dbank.ErrHoldNotActive},
+		{"past its expiry", func(h database.BankHoldOrm) database.BankHoldOrm {
+			h.ExpiresAt = time.Now().Add(-time.Second)
+			return h
+		}, 0, 0, dbank.ErrHoldNotActive},
+		{"on another account", func(h database.BankHoldOrm) database.BankHoldOrm {
+			h.AccountUuid = otherAccountUuid
+			return h
+		}, 0, 0, dbank.ErrHoldNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold := activeHold()
+			if tt.hold != nil {
+				hold = tt.hold(hold)
+			}
+
+			db := &holdDb{account: account, hold: hold}
+			b := NewBankService(db)
+
+			captured, err := b.captureHold(account.AccountNumber, hold.HoldUuid, tt.amount)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("captureHold() = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if len(db.captured) != 0 {
+					t.Errorf("captureHold() posted %v transactions, want none", len(db.captured))
+				}
+				return
+			}
+
+			if captured.Status != dbank.HoldStatusCaptured || captured.CapturedAmount != tt.wantAmount {
+				t.Errorf("captureHold() = %v %v, want CAPTURED %v", captured.Status, captured.CapturedAmount, tt.wantAmount)
+			}
+
+			if len(db.captured) != 1 || db.captured[0].TransactionType != dbank.TransactionTypeOut || db.captured[0].Amount != tt.wantAmount {
+				t.Errorf("captureHold() posted %v, want one OUT of %v", db.captured, tt.wantAmount)
+			}
+
+			if captured.TransactionUuid != db.captured[0].TransactionUuid {
+				t.Errorf("captureHold() transaction = %v, want %v", captured.TransactionUuid, db.captured[0].TransactionUuid)
+			}
+		})
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	account := holdAccount()
+
+	tests := []struct {
+		name    string
+		status  string
+		wantErr error
+	}{
+		{"active", dbank.HoldStatusActive, nil},
+		{"captured", dbank.HoldStatusCaptured, dbank.ErrHoldNotActive},
+		{"expired", dbank.HoldStatusExpired, dbank.ErrHoldNotActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold := database.BankHoldOrm{HoldUuid: uuid.New(), AccountUuid: account.AccountUuid, Amount: 10, Currency: "USD", Status: tt.status}
+			b := NewBankService(&holdDb{account: account, hold: hold})
+
+			released, err := b.releaseHold(account.AccountNumber, hold.HoldUuid)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("releaseHold() = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && released.Status != dbank.HoldStatusReleased {
+				t.Errorf("releaseHold() status = %v, want %v", released.Status, dbank.HoldStatusReleased)
+			}
+		})
+	}
+}
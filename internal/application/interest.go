@@ -0,0 +1,266 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// maxInterestCatchUpDays bounds how many missed days one run accrues for an
+// account, e.g. after the server was down
+const maxInterestCatchUpDays = 366
+
+func startOfDay(ts time.Time) time.Time {
+	ts = ts.UTC()
+	return time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// AccrueInterest accrues the interest of every interest bearing account on
+// its ledger balance at the end of each day up to and including the UTC day
+// of through. An account accrues from the day after its last accrual, or
+// from through itself the first time. Days already accrued are left alone, so
+// running it again for the same day changes nothing. It returns how many
+// days were accrued.
+func (b *BankService) AccrueInterest(through time.Time) (int, error) {
+	through = startOfDay(through)
+
+	accountOrms, err := b.db.FindInterestBearingBankAccounts()
+
+	if err != nil {
+		return 0, dbank.NewUnavailableError("interest bearing account lookup", err)
+	}
+
+	products := map[uuid.UUID]dbank.AccountProduct{}
+	accrued := 0
+
+	for _, a := range accountOrms {
+		n, err := b.accrueAccountInterest(products, a, through)
+		accrued += n
+
+		if err != nil {
+			log.Printf("Can't accrue interest for %v : %v\n", a.AccountNumber, err)
+		}
+	}
+
+	return accrued, nil
+}
+
+func (b *BankService) accrueAccountInterest(products map[uuid.UUID]dbank.AccountProduct, a database.BankAccountOrm, through time.Time) (int, error) {
+	product, hasProduct, err := b.productOf(products, a)
+
+	if err != nil {
+		return 0, err
+	}
+
+	convention := dbank.DefaultDayCountConvention
+	if hasProduct {
+		convention = product.DayCountConvention
+	}
+
+	overdraft := overdraftOf(a)
+
+	cur, err := dbank.Currencies.Find(a.Currency)
+
+	if err != nil {
+		return 0, err
+	}
+
+	from := through
+	latestOrm, err := b.db.GetLatestBankInterestAccrual(a.AccountUuid)
+
+	if err == nil {
+		from = startOfDay(latestOrm.AccrualDate).AddDate(0, 0, 1)
+	} else if !errors.Is(err, database.ErrRecordNotFound) {
+		return 0, dbank.NewUnavailableError("interest accrual lookup", err)
+	}
+
+	if earliest := through.AddDate(0, 0, -maxInterestCatchUpDays); from.Before(earliest) {
+		from = earliest
+	}
+
+	if opened := startOfDay(a.CreatedAt); from.Before(opened) {
+		from = opened
+	}
+
+	accrued := 0
+
+	for day := from; !day.After(through); day = day.AddDate(0, 0, 1) {
+		balance, err := b.db.GetBankAccountBalanceAt(a.AccountUuid, day.AddDate(0, 0, 1))
+
+		if err != nil {
+			return accrued, dbank.NewUnavailableError("end of day balance", err)
+		}
+
+		balance = cur.Round(balance)
+
+		var interest float64
+
+		if hasProduct {
+			if interest, err = product.DailyInterest(balance, day); err != nil {
+				return accrued, fmt.Errorf("product %v : %w", product.ProductCode, err)
+			}
+		}
+
+		// an overdrawn balance earns nothing from the product and is charged
+		// the overdraft rate instead
+		charged, err := overdraft.DailyInterest(convention, balance, day)
+
+		if err != nil {
+			return accrued, err
+		}
+
+		interest += charged
+
+		ok, err := b.db.CreateBankInterestAccrual(database.BankInterestAccrualOrm{
+			AccountUuid:        a.AccountUuid,
+			AccrualDate:        day,
+			PeriodStart:        dbank.InterestPeriodStart(day),
+			Balance:            balance,
+			Amount:             interest,
+			DayCountConvention: convention,
+			CreatedAt:          time.Now(),
+		})
+
+		if err != nil {
+			return accrued, dbank.NewUnavailableError("interest accrual", err)
+		}
+
+		if ok {
+			accrued++
+		}
+	}
+
+	return accrued, nil
+}
+
+func interestReference(acct string, periodStart time.Time) string {
+	return fmt.Sprintf("interest:%v:%v", acct, periodStart.Format("2006-01"))
+}
+
+// CapitalizeInterest posts the interest accrued over every month before the
+// UTC month of now as an IN transaction, or as an OUT transaction when the
+// overdraft interest charged outweighs it. A period is recorded before its
+// transaction is posted and the transaction carries the period as external
+// reference, so a period interrupted half way is finished without posting
+// twice. It returns how many periods were posted.
+func (b *BankService) CapitalizeInterest(now time.Time) (int, error) {
+	periodOrms, err := b.db.FindUncapitalizedBankInterestPeriods(dbank.InterestPeriodStart(now.UTC()))
+
+	if err != nil {
+		return 0, dbank.NewUnavailableError("interest period lookup", err)
+	}
+
+	numbers := map[uuid.UUID]string{}
+	posted := 0
+
+	for _, p := range periodOrms {
+		ok, err := b.capitalizeInterestPeriod(numbers, p)
+
+		if err != nil {
+			log.Printf("Can't capitalize interest of %v for %v : %v\n", p.AccountUuid, p.PeriodStart.Format("2006-01"), err)
+		} else if ok {
+			posted++
+		}
+	}
+
+	return posted, nil
+}
+
+func (b *BankService) capitalizeInterestPeriod(numbers map[uuid.UUID]string, p database.BankInterestPeriodOrm) (bool, error) {
+	acct, err := b.accountNumberOf(numbers, p.AccountUuid)
+
+	if err != nil {
+		return false, err
+	}
+
+	accountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		return false, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	cur, err := dbank.Currencies.Find(accountOrm.Currency)
+
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+
+	capitalizationOrm, err := b.db.CreateBankInterestCapitalization(database.BankInterestCapitalizationOrm{
+		AccountUuid: p.AccountUuid,
+		PeriodStart: p.PeriodStart,
+		Amount:      cur.Round(p.Amount),
+		Status:      dbank.InterestCapitalizationPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+
+	if err != nil {
+		return false, dbank.NewUnavailableError("interest capitalization", err)
+	}
+
+	if capitalizationOrm.Status != dbank.InterestCapitalizationPending {
+		return false, nil
+	}
+
+	if capitalizationOrm.Amount == 0 {
+		_, err := b.db.FinishBankInterestCapitalization(p.AccountUuid, p.PeriodStart, dbank.InterestCapitalizationEmpty, nil)
+		return false, err
+	}
+
+	reference := interestReference(acct, p.PeriodStart)
+
+	existing, _, err := b.ListTransactions(dbank.TransactionFilter{
+		AccountNumber:       acct,
+		ExternalReferenceId: reference,
+		Limit:               1,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	var transactionUuid uuid.UUID
+
+	if len(existing) > 0 {
+		transactionUuid = existing[0].TransactionUuid
+	} else {
+		t := dbank.Transaction{
+			Amount:          capitalizationOrm.Amount,
+			TransactionType: dbank.TransactionTypeIn,
+			Notes:           "Interest for " + p.PeriodStart.Format("January 2006"),
+			Metadata: dbank.TransactionMetadata{
+				Category:            dbank.InterestCategory,
+				ExternalReferenceId: reference,
+			},
+			FeeExempt: true,
+			Origin:    dbank.Origin{Actor: dbank.SystemActor},
+		}
+
+		// overdraft interest outweighing the interest earned is charged
+		if t.Amount < 0 {
+			t.Amount = -t.Amount
+			t.TransactionType = dbank.TransactionTypeOut
+			t.Notes = "Overdraft interest for " + p.PeriodStart.Format("January 2006")
+			t.Charge = true
+		}
+
+		transactionUuid, err = b.CreateTransaction(acct, t)
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := b.db.FinishBankInterestCapitalization(p.AccountUuid, p.PeriodStart, dbank.InterestCapitalizationPosted, &transactionUuid); err != nil {
+		return false, dbank.NewUnavailableError("interest capitalization", err)
+	}
+
+	return true, nil
+}
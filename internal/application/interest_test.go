@@ -0,0 +1,266 @@
+package application
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// interestDb is a ledgerDb with one interest bearing product and the interest
+// accrued and capitalized on its accounts, any other call panics
+type interestDb struct {
+	*ledgerDb
+	product         database.BankAccountProductOrm
+	tiers           []database.BankAccountProductInterestTierOrm
+	accruals        []database.BankInterestAccrualOrm
+	capitalizations map[time.Time]*database.BankInterestCapitalizationOrm
+}
+
+func (d *interestDb) GetBankAccountProduct(productUuid uuid.UUID) (database.BankAccountProductOrm, error) {
+	return d.product, nil
+}
+
+func (d *interestDb) GetBankAccountProductCurrencies(productUuid uuid.UUID) ([]database.BankAccountProductCurrencyOrm, error) {
+	return []database.BankAccountProductCurrencyOrm{{ProductUuid: productUuid, Currency: "USD"}}, nil
+}
+
+func (d *interestDb) GetBankAccountProductInterestTiers(productUuid uuid.UUID) ([]database.BankAccountProductInterestTierOrm, error) {
+	return d.tiers, nil
+}
+
+func (d *interestDb) FindInterestBearingBankAccounts() ([]database.BankAccountOrm, error) {
+	var res []database.BankAccountOrm
+	for _, a := range d.accounts {
+		res = append(res, *a)
+	}
+
+	return res, nil
+}
+
+func (d *interestDb) GetBankAccountByUuid(accountUuid uuid.UUID) (database.BankAccountOrm, error) {
+	a, ok := d.accounts[accountUuid]
+	if !ok {
+		return database.BankAccountOrm{}, database.ErrRecordNotFound
+	}
+
+	return *a, nil
+}
+
+// GetBankAccountBalanceAt takes the transactions posted since ts off the
+// stored balance
+func (d *interestDb) GetBankAccountBalanceAt(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	balance := d.accounts[accountUuid].CurrentBalance
+
+	for _, t := range d.posted {
+		if t.AccountUuid != accountUuid || t.TransactionTimestamp.Before(ts) {
+			continue
+		}
+
+		if t.TransactionType == dbank.TransactionTypeOut {
+			balance += t.Amount
+		} else {
+			balance -= t.Amount
+		}
+	}
+
+	return balance, nil
+}
+
+func (d *interestDb) FindBankTransactions(q database.BankTransactionQuery) ([]database.BankTransactionOrm, error) {
+	var res []database.BankTransactionOrm
+	for _, t := range d.posted {
+		if t.AccountUuid == q.AccountUuid && t.Metadata.ExternalReferenceId == q.ExternalReferenceId {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+func (d *interestDb) AppendAuditEvent(e database.AuditEventOrm, hashFn func(e database.AuditEventOrm) string) (database.AuditEventOrm, error) {
+	return e, nil
+}
+
+func (d *interestDb) GetLatestBankInterestAccrual(accountUuid uuid.UUID) (database.BankInterestAccrualOrm, error) {
+	var latest *database.BankInterestAccrualOrm
+
+	for i, a := range d.accruals {
+		if a.AccountUuid == accountUuid && (latest == nil || a.AccrualDate.After(latest.AccrualDate)) {
+			latest = &d.accruals[i]
+		}
+	}
+
+	if latest == nil {
+		return database.BankInterestAccrualOrm{}, database.ErrRecordNotFound
+	}
+
+	return *latest, nil
+}
+
+func (d *interestDb) CreateBankInterestAccrual(a database.BankInterestAccrualOrm) (bool, error) {
+	for _, existing := range d.accruals {
+		if existing.AccountUuid == a.AccountUuid && existing.AccrualDate.Equal(a.AccrualDate) {
+			return false, nil
+		}
+	}
+
+	d.accruals = append(d.accruals, a)
+
+	return true, nil
+}
+
+func (d *interestDb) FindUncapitalizedBankInterestPeriods(periodStart time.Time) ([]database.BankInterestPeriodOrm, error) {
+	sums := map[time.Time]float64{}
+	var accountUuid uuid.UUID
+
+	for _, a := range d.accruals {
+		if !a.PeriodStart.Before(periodStart) {
+			continue
+		}
+
+		if c, ok := d.capitalizations[a.PeriodStart]; ok && c.Status != dbank.InterestCapitalizationPending {
+			continue
+		}
+
+		sums[a.PeriodStart] += a.Amount
+		accountUuid = a.AccountUuid
+	}
+
+	var res []database.BankInterestPeriodOrm
+	for p, amount := range sums {
+		res = append(res, database.BankInterestPeriodOrm{AccountUuid: accountUuid, PeriodStart: p, Amount: amount})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].PeriodStart.Before(res[j].PeriodStart) })
+
+	return res, nil
+}
+
+func (d *interestDb) CreateBankInterestCapitalization(c database.BankInterestCapitalizationOrm) (database.BankInterestCapitalizationOrm, error) {
+	if existing, ok := d.capitalizations[c.PeriodStart]; ok {
+		return *existing, nil
+	}
+
+	d.capitalizations[c.PeriodStart] = &c
+
+	return c, nil
+}
+
+func (d *interestDb) FinishBankInterestCapitalization(accountUuid uuid.UUID, periodStart time.Time, status string, transactionUuid *uuid.UUID) (bool, error) {
+	c, ok := d.capitalizations[periodStart]
+	if !ok || c.Status != dbank.InterestCapitalizationPending {
+		return false, nil
+	}
+
+	c.Status = status
+	c.TransactionUuid = transactionUuid
+
+	return true, nil
+}
+
+func TestAccrueAndCapitalizeInterest(t *testing.T) {
+	productUuid := uuid.New()
+	opened := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	account := database.BankAccountOrm{
+		AccountUuid:   uuid.New(),
+		AccountNumber: "7835697001",
+		Currency:      "USD",
+		ProductUuid:   &productUuid,
+		// the account was opened with a balance that no transaction accounts for
+		CurrentBalance: 1000,
+		CreatedAt:      opened,
+	}
+
+	db := &interestDb{
+		ledgerDb:        newLedgerDb(account),
+		product:         database.BankAccountProductOrm{ProductUuid: productUuid, ProductCode: "SAVINGS", DayCountConvention: dbank.DayCountAct360},
+		tiers:           []database.BankAccountProductInterestTierOrm{{ProductUuid: productUuid, MinBalance: 0, AnnualRate: 0.036}},
+		capitalizations: map[time.Time]*database.BankInterestCapitalizationOrm{},
+	}
+
+	// a deposit in March and a withdrawal after the month, the current
+	// balance of 1500 is not what the account held on any day in March
+	for _, tx := range []database.BankTransactionOrm{
+		{TransactionType: dbank.TransactionTypeIn, Amount: 800, TransactionTimestamp: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)},
+		{TransactionType: dbank.TransactionTypeOut, Amount: 300, TransactionTimestamp: time.Date(2024, 4, 5, 12, 0, 0, 0, time.UTC)},
+	} {
+		tx.TransactionUuid = uuid.New()
+		tx.AccountUuid = account.AccountUuid
+		db.CreateTransaction(account, tx)
+	}
+
+	b := NewBankService(db)
+
+	// the daily run, from the day the account was opened through April 1
+	accrued := 0
+	for day := opened; day.Before(time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)); day = day.AddDate(0, 0, 1) {
+		n, err := b.AccrueInterest(day.Add(14 * time.Hour))
+
+		if err != nil {
+			t.Fatalf("AccrueInterest(%v) = %v", day, err)
+		}
+
+		accrued += n
+	}
+
+	if accrued != 32 {
+		t.Errorf("days accrued = %v, want 32", accrued)
+	}
+
+	if n, err := b.AccrueInterest(time.Date(2024, 4, 1, 23, 0, 0, 0, time.UTC)); n != 0 || err != nil {
+		t.Errorf("AccrueInterest() again = %v, %v, want 0 days", n, err)
+	}
+
+	for _, a := range db.accruals {
+		want := 1800.0
+		if a.AccrualDate.Day() < 10 && a.AccrualDate.Month() == time.March {
+			want = 1000
+		}
+
+		if a.Balance != want {
+			t.Errorf("balance accrued on %v = %v, want %v", a.AccrualDate.Format("2006-01-02"), a.Balance, want)
+		}
+	}
+
+	now := time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC)
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	if n, err := b.CapitalizeInterest(now); n != 1 || err != nil {
+		t.Fatalf("CapitalizeInterest() = %v, %v, want 1 period", n, err)
+	}
+
+	// 9 days on 1000 and 22 days on 1800 at 3.6% over 360 days
+	interest := db.posted[len(db.posted)-1]
+
+	if interest.TransactionType != dbank.TransactionTypeIn || interest.Amount != 4.86 || interest.Metadata.ExternalReferenceId != "interest:7835697001:2024-03" {
+		t.Errorf("interest posted = %v %v %v, want IN 4.86 interest:7835697001:2024-03", interest.TransactionType, interest.Amount, interest.Metadata.ExternalReferenceId)
+	}
+
+	if got := db.accounts[account.AccountUuid].CurrentBalance; got != 1504.86 {
+		t.Errorf("balance = %v, want 1504.86", got)
+	}
+
+	postings := len(db.posted)
+
+	if n, err := b.CapitalizeInterest(now); n != 0 || err != nil || len(db.posted) != postings {
+		t.Errorf("CapitalizeInterest() again = %v, %v with %v new transactions, want nothing posted", n, err, len(db.posted)-postings)
+	}
+
+	// a run interrupted after posting and before recording it finishes the
+	// period with the transaction carrying its reference
+	c := db.capitalizations[march]
+	c.Status = dbank.InterestCapitalizationPending
+	c.TransactionUuid = nil
+
+	if _, err := b.CapitalizeInterest(now); err != nil || len(db.posted) != postings {
+		t.Errorf("CapitalizeInterest() after an interruption = %v with %v new transactions, want nothing posted", err, len(db.posted)-postings)
+	}
+
+	if c.Status != dbank.InterestCapitalizationPosted || c.TransactionUuid == nil || *c.TransactionUuid != interest.TransactionUuid {
+		t.Errorf("capitalization = %v %v, want posted with transaction %v", c.Status, c.TransactionUuid, interest.TransactionUuid)
+	}
+}
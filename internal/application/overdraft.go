@@ -0,0 +1,27 @@
+package application
+
+import (
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+)
+
+func overdraftOf(bankAccountOrm database.BankAccountOrm) dbank.Overdraft {
+	return dbank.Overdraft{
+		Limit:        bankAccountOrm.OverdraftLimit,
+		InterestRate: bankAccountOrm.OverdraftInterestRate,
+	}
+}
+
+// assessOverdraftFees evaluates the overdraft fee rules of the account and
+// of its product for a debit taking its balance from zero or above to below
+// zero, on the amount it is overdrawn by. bankAccountOrm is the account
+// locked and read before the debit.
+func (b *BankService) assessOverdraftFees(bankAccountOrm database.BankAccountOrm, cur dbank.Currency, debit float64) (feeAssessment, error) {
+	overdrawn := cur.Round(dbank.OverdrawnBy(bankAccountOrm.CurrentBalance, debit))
+
+	if overdrawn <= 0 {
+		return feeAssessment{}, nil
+	}
+
+	return b.assessFees(bankAccountOrm, dbank.FeeOperationOverdraft, cur, overdrawn, false)
+}
@@ -0,0 +1,141 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// accountProduct loads a product with its currencies and interest tiers,
+// products are cached in products for the duration of a run
+func (b *BankService) accountProduct(products map[uuid.UUID]dbank.AccountProduct, productUuid uuid.UUID) (dbank.AccountProduct, error) {
+	if p, ok := products[productUuid]; ok {
+		return p, nil
+	}
+
+	productOrm, err := b.db.GetBankAccountProduct(productUuid)
+
+	if err != nil {
+		return dbank.AccountProduct{}, dbank.NewUnavailableError("account product lookup", err)
+	}
+
+	currencyOrms, err := b.db.GetBankAccountProductCurrencies(productUuid)
+
+	if err != nil {
+		return dbank.AccountProduct{}, dbank.NewUnavailableError("product currency lookup", err)
+	}
+
+	tierOrms, err := b.db.GetBankAccountProductInterestTiers(productUuid)
+
+	if err != nil {
+		return dbank.AccountProduct{}, dbank.NewUnavailableError("interest tier lookup", err)
+	}
+
+	p := dbank.AccountProduct{
+		ProductUuid:         productOrm.ProductUuid,
+		ProductCode:         productOrm.ProductCode,
+		ProductName:         productOrm.ProductName,
+		ProductType:         productOrm.ProductType,
+		DayCountConvention:  productOrm.DayCountConvention,
+		MinBalance:          productOrm.MinBalance,
+		TransfersOutAllowed: productOrm.TransfersOutAllowed,
+		Currencies:          make([]string, 0, len(currencyOrms)),
+		InterestTiers:       make([]dbank.InterestTier, 0, len(tierOrms)),
+	}
+
+	for _, c := range currencyOrms {
+		p.Currencies = append(p.Currencies, c.Currency)
+	}
+
+	for _, t := range tierOrms {
+		p.InterestTiers = append(p.InterestTiers, dbank.InterestTier{
+			MinBalance: t.MinBalance,
+			AnnualRate: t.AnnualRate,
+		})
+	}
+
+	products[productUuid] = p
+
+	return p, nil
+}
+
+// productOf returns the product of an account, ok is false for an account
+// without a product, which no product rule applies to
+func (b *BankService) productOf(products map[uuid.UUID]dbank.AccountProduct, accountOrm database.BankAccountOrm) (dbank.AccountProduct, bool, error) {
+	if accountOrm.ProductUuid == nil {
+		return dbank.AccountProduct{}, false, nil
+	}
+
+	p, err := b.accountProduct(products, *accountOrm.ProductUuid)
+
+	if err != nil {
+		return dbank.AccountProduct{}, false, err
+	}
+
+	return p, true, nil
+}
+
+func (b *BankService) checkProductCurrency(products map[uuid.UUID]dbank.AccountProduct, accountOrm database.BankAccountOrm, currency string) error {
+	p, ok, err := b.productOf(products, accountOrm)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	if !p.AllowsCurrency(currency) {
+		return fmt.Errorf("%w : %v on %v account %v", dbank.ErrProductCurrencyNotAllowed, currency, p.ProductCode, accountOrm.AccountNumber)
+	}
+
+	return nil
+}
+
+// checkProductMinBalance checks the account keeps the minimum balance of its
+// product once debit is taken from its balance net of held
+func (b *BankService) checkProductMinBalance(products map[uuid.UUID]dbank.AccountProduct, accountOrm database.BankAccountOrm, held float64, debit float64) error {
+	if debit <= 0 {
+		return nil
+	}
+
+	p, ok, err := b.productOf(products, accountOrm)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	if !p.KeepsMinBalance(accountOrm.CurrentBalance - held - debit) {
+		return fmt.Errorf("%w : %v account %v must keep %v", dbank.ErrProductMinBalance, p.ProductCode, accountOrm.AccountNumber, p.MinBalance)
+	}
+
+	return nil
+}
+
+// checkProductTransfer checks the product of the source account allows
+// transfers out and the products of both accounts allow the currency
+func (b *BankService) checkProductTransfer(products map[uuid.UUID]dbank.AccountProduct, fromAccountOrm database.BankAccountOrm, toAccountOrm database.BankAccountOrm, currency string) error {
+	p, ok, err := b.productOf(products, fromAccountOrm)
+
+	if err != nil {
+		return err
+	}
+
+	if ok && !p.TransfersOutAllowed {
+		return fmt.Errorf("%w : %v account %v", dbank.ErrProductTransfersOutNotAllowed, p.ProductCode, fromAccountOrm.AccountNumber)
+	}
+
+	if err := b.checkProductCurrency(products, fromAccountOrm, currency); err != nil {
+		return err
+	}
+
+	return b.checkProductCurrency(products, toAccountOrm, currency)
+}
+
+// isProductRuleError tells whether err is a product rule the operation broke
+// rather than a failure to check it
+func isProductRuleError(err error) bool {
+	return errors.Is(err, dbank.ErrProductCurrencyNotAllowed) ||
+		errors.Is(err, dbank.ErrProductMinBalance) ||
+		errors.Is(err, dbank.ErrProductTransfersOutNotAllowed)
+}
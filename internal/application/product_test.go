@@ -0,0 +1,155 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// productDb serves products with their currencies and counts the product
+// lookups, any other call panics
+type productDb struct {
+	port.BankDatabasePort
+	products   map[uuid.UUID]database.BankAccountProductOrm
+	currencies map[uuid.UUID][]string
+	lookups    int
+}
+
+func (d *productDb) GetBankAccountProduct(productUuid uuid.UUID) (database.BankAccountProductOrm, error) {
+	d.lookups++
+
+	p, ok := d.products[productUuid]
+	if !ok {
+		return database.BankAccountProductOrm{}, database.ErrRecordNotFound
+	}
+
+	return p, nil
+}
+
+func (d *productDb) GetBankAccountProductCurrencies(productUuid uuid.UUID) ([]database.BankAccountProductCurrencyOrm, error) {
+	var res []database.BankAccountProductCurrencyOrm
+	for _, c := range d.currencies[productUuid] {
+		res = append(res, database.BankAccountProductCurrencyOrm{ProductUuid: productUuid, Currency: c})
+	}
+
+	return res, nil
+}
+
+func (d *productDb) GetBankAccountProductInterestTiers(productUuid uuid.UUID) ([]database.BankAccountProductInterestTierOrm, error) {
+	return nil, nil
+}
+
+func testProductDb() (*productDb, map[string]*uuid.UUID) {
+	checking, savings, business := uuid.New(), uuid.New(), uuid.New()
+
+	db := &productDb{
+		products: map[uuid.UUID]database.BankAccountProductOrm{
+			checking: {ProductUuid: checking, ProductCode: "CHECKING", TransfersOutAllowed: true},
+			savings:  {ProductUuid: savings, ProductCode: "SAVINGS", MinBalance: 100},
+			business: {ProductUuid: business, ProductCode: "BUSINESS", TransfersOutAllowed: true, MinBalance: 1000},
+		},
+		currencies: map[uuid.UUID][]string{
+			savings:  {"USD"},
+			business: {"USD", "EUR"},
+		},
+	}
+
+	return db, map[string]*uuid.UUID{"CHECKING": &checking, "SAVINGS": &savings, "BUSINESS": &business, "": nil}
+}
+
+func TestCheckProductTransfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		currency string
+		wantErr  error
+	}{
+		{"any currency", "CHECKING", "", "JPY", nil},
+		{"from an account without product", "", "BUSINESS", "EUR", nil},
+		{"transfers out not allowed", "SAVINGS", "CHECKING", "USD", dbank.ErrProductTransfersOutNotAllowed},
+		{"currency not allowed at the source", "BUSINESS", "CHECKING", "GBP", dbank.ErrProductCurrencyNotAllowed},
+		{"currency not allowed at the destination", "CHECKING", "SAVINGS", "EUR", dbank.ErrProductCurrencyNotAllowed},
+		{"into savings", "BUSINESS", "SAVINGS", "USD", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, products := testProductDb()
+			b := NewBankService(db)
+
+			err := b.checkProductTransfer(map[uuid.UUID]dbank.AccountProduct{},
+				database.BankAccountOrm{AccountNumber: "7835697001", ProductUuid: products[tt.from]},
+				database.BankAccountOrm{AccountNumber: "7835697002", ProductUuid: products[tt.to]},
+				tt.currency)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("checkProductTransfer() = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && !isProductRuleError(err) {
+				t.Errorf("checkProductTransfer() = %v, want a product rule error", err)
+			}
+		})
+	}
+}
+
+func TestCheckProductMinBalance(t *testing.T) {
+	tests := []struct {
+		name    string
+		product string
+		balance float64
+		held    float64
+		debit   float64
+		wantErr error
+	}{
+		{"no product", "", 10, 0, 50, nil},
+		{"no minimum", "CHECKING", 10, 0, 50, nil},
+		{"keeps the minimum", "SAVINGS", 300, 0, 200, nil},
+		{"falls below the minimum", "SAVINGS", 300, 0, 200.01, dbank.ErrProductMinBalance},
+		{"holds count against the minimum", "SAVINGS", 300, 50, 200, dbank.ErrProductMinBalance},
+		{"credits are not checked", "SAVINGS", 50, 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, products := testProductDb()
+			b := NewBankService(db)
+
+			err := b.checkProductMinBalance(map[uuid.UUID]dbank.AccountProduct{},
+				database.BankAccountOrm{AccountNumber: "7835697001", CurrentBalance: tt.balance, ProductUuid: products[tt.product]},
+				tt.held, tt.debit)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("checkProductMinBalance() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccountProductCached(t *testing.T) {
+	db, products := testProductDb()
+	b := NewBankService(db)
+	cache := map[uuid.UUID]dbank.AccountProduct{}
+
+	for i := 0; i < 3; i++ {
+		p, err := b.accountProduct(cache, *products["BUSINESS"])
+
+		if err != nil || p.ProductCode != "BUSINESS" || len(p.Currencies) != 2 {
+			t.Fatalf("accountProduct() = %+v, %v", p, err)
+		}
+	}
+
+	if db.lookups != 1 {
+		t.Errorf("product lookups = %v, want 1", db.lookups)
+	}
+
+	var unavailable *dbank.UnavailableError
+	if _, err := b.accountProduct(cache, uuid.New()); !errors.As(err, &unavailable) {
+		t.Errorf("accountProduct() of an unknown product = %v, want unavailable", err)
+	}
+}
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+func transferNotFoundError(transferUuid uuid.UUID) error {
+	return &dbank.NotFoundError{
+		Reason:   dbank.ReasonTransferNotFound,
+		Resource: dbank.ResourceTransfer,
+		Key:      transferUuid.String(),
+		Err:      dbank.ErrTransferNotFound,
+	}
+}
+
+func transferReversedError(transferUuid uuid.UUID) error {
+	return &dbank.ConflictError{
+		Reason:   dbank.ReasonTransferReversed,
+		Resource: dbank.ResourceTransfer,
+		Key:      transferUuid.String(),
+		Detail:   "nothing left to reverse",
+		Err:      dbank.ErrTransferAlreadyReversed,
+	}
+}
+
+// ReverseTransfer moves r.Amount of a successful transfer, or everything not
+// reversed yet when r.Amount is zero, back from its destination to its source.
+// The fees charged for the transfer are refunded in proportion.
+func (b *BankService) ReverseTransfer(r dbank.TransferReversal) (dbank.TransferReversal, error) {
+	reversal, acct, err := b.reverseTransfer(r)
+	b.recordAudit(r.Origin, dbank.AuditActionReverseTransfer, acct, reversal.Amount, reversal.Currency,
+		fmt.Sprintf("transfer %v reversal %v %v", r.TransferUuid, reversal.ReversalUuid, r.Reason), err)
+
+	return reversal, err
+}
+
+func (b *BankService) reverseTransfer(r dbank.TransferReversal) (dbank.TransferReversal, string, error) {
+	now := time.Now()
+
+	transferOrm, err := b.db.GetBankTransfer(r.TransferUuid)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return dbank.TransferReversal{}, "", transferNotFoundError(r.TransferUuid)
+	} else if err != nil {
+		return dbank.TransferReversal{}, "", dbank.NewUnavailableError("transfer lookup", err)
+	}
+
+	toAccountOrm, err := b.db.GetBankAccountByUuid(transferOrm.ToAccountUuid)
+
+	if err != nil {
+		return dbank.TransferReversal{}, "", dbank.NewUnavailableError("transfer account lookup", err)
+	}
+
+	acct := toAccountOrm.AccountNumber
+
+	if transferOrm.Status == dbank.TransferStatusReversed {
+		return dbank.TransferReversal{}, acct, transferReversedError(r.TransferUuid)
+	}
+
+	if transferOrm.Status != dbank.TransferStatusCompleted {
+		return dbank.TransferReversal{}, acct, dbank.ErrTransferNotReversible
+	}
+
+	cur, err := dbank.Currencies.Find(transferOrm.Currency)
+
+	if err != nil {
+		return dbank.TransferReversal{}, acct, err
+	}
+
+	remaining := cur.Round(transferOrm.Amount - transferOrm.ReversedAmount)
+
+	if remaining <= 0 {
+		return dbank.TransferReversal{}, acct, transferReversedError(r.TransferUuid)
+	}
+
+	amount := remaining
+	if r.Amount != 0 {
+		amount = cur.Round(r.Amount)
+	}
+
+	if amount <= 0 {
+		return dbank.TransferReversal{}, acct, dbank.ErrAmountBelowMinorUnit
+	}
+
+	if amount > remaining {
+		return dbank.TransferReversal{}, acct, dbank.ErrReversalExceedsRemaining
+	}
+
+	notes := "Reversal of transfer " + r.TransferUuid.String()
+
+	debitOrm := database.BankTransactionOrm{
+		TransactionUuid:      uuid.New(),
+		AccountUuid:          transferOrm.ToAccountUuid,
+		TransactionType:      dbank.TransactionTypeOut,
+		TransactionTimestamp: now,
+		Amount:               amount,
+		Notes:                notes,
+		TransferUuid:         &transferOrm.TransferUuid,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	creditOrm := database.BankTransactionOrm{
+		TransactionUuid:      uuid.New(),
+		AccountUuid:          transferOrm.FromAccountUuid,
+		TransactionType:      dbank.TransactionTypeIn,
+		TransactionTimestamp: now,
+		Amount:               amount,
+		Notes:                notes,
+		TransferUuid:         &transferOrm.TransferUuid,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	reversalOrm := database.BankTransferReversalOrm{
+		ReversalUuid:          uuid.New(),
+		TransferUuid:          transferOrm.TransferUuid,
+		Amount:                amount,
+		Reason:                r.Reason,
+		DebitTransactionUuid:  debitOrm.TransactionUuid,
+		CreditTransactionUuid: creditOrm.TransactionUuid,
+		CreatedBy:             r.Origin.Actor,
+		CreatedAt:             now,
+	}
+
+	var ok bool
+
+	err = b.inTransaction(func(tb *BankService) error {
+		locked, err := tb.lockAccounts(toAccountOrm)
+
+		if err != nil {
+			return err
+		}
+
+		// the destination account is debited, it must still have the money
+		available, _, err := tb.availableBalance(locked[0], now)
+
+		if err != nil {
+			return err
+		}
+
+		if available < amount {
+			return &dbank.InsufficientFundsError{
+				AccountNumber: acct,
+				Available:     available,
+				Requested:     amount,
+			}
+		}
+
+		ok, err = tb.db.ReverseBankTransfer(reversalOrm, debitOrm, creditOrm)
+
+		if errors.Is(err, dbank.ErrReversalInsufficientFunds) {
+			return &dbank.InsufficientFundsError{
+				AccountNumber: acct,
+				Available:     available,
+				Requested:     amount,
+				Err:           err,
+			}
+		}
+
+		if err != nil {
+			log.Printf("Can't reverse transfer %v : %v\n", r.TransferUuid, err)
+			return dbank.NewUnavailableError("transfer reversal", err)
+		}
+
+		if !ok {
+			return nil
+		}
+
+		return tb.refundTransferFees(transferOrm.TransferUuid, amount, cur, now)
+	})
+
+	if err != nil {
+		return dbank.TransferReversal{}, acct, err
+	}
+
+	if !ok {
+		// another reversal of the same transfer got in first
+		if refreshed, err := b.db.GetBankTransfer(r.TransferUuid); err == nil && refreshed.Status == dbank.TransferStatusReversed {
+			return dbank.TransferReversal{}, acct, transferReversedError(r.TransferUuid)
+		}
+
+		return dbank.TransferReversal{}, acct, dbank.ErrReversalExceedsRemaining
+	}
+
+	reversed := cur.Round(transferOrm.ReversedAmount + amount)
+
+	status := dbank.ReversalStatusPartial
+	if reversed >= transferOrm.Amount {
+		status = dbank.ReversalStatusFull
+	}
+
+	return dbank.TransferReversal{
+		ReversalUuid:          reversalOrm.ReversalUuid,
+		TransferUuid:          transferOrm.TransferUuid,
+		Amount:                amount,
+		Reason:                r.Reason,
+		Currency:              transferOrm.Currency,
+		ReversedAmount:        reversed,
+		RemainingAmount:       cur.Round(transferOrm.Amount - reversed),
+		ReversalStatus:        status,
+		DebitTransactionUuid:  debitOrm.TransactionUuid,
+		CreditTransactionUuid: creditOrm.TransactionUuid,
+		Timestamp:             now,
+		Origin:                r.Origin,
+	}, acct, nil
+}
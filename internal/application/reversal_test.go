@@ -0,0 +1,83 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// reversalDb serves one transfer and its accounts, any other call panics
+type reversalDb struct {
+	port.BankDatabasePort
+	transfer database.BankTransferOrm
+}
+
+func (d *reversalDb) GetBankTransfer(transferUuid uuid.UUID) (database.BankTransferOrm, error) {
+	if transferUuid != d.transfer.TransferUuid {
+		return database.BankTransferOrm{}, database.ErrRecordNotFound
+	}
+
+	return d.transfer, nil
+}
+
+func (d *reversalDb) GetBankAccountByUuid(accountUuid uuid.UUID) (database.BankAccountOrm, error) {
+	return database.BankAccountOrm{AccountUuid: accountUuid, AccountNumber: "7835697002", Currency: "USD"}, nil
+}
+
+// TestReverseTransferRefused covers the checks made before the reversal is
+// posted, posting it needs a database transaction
+func TestReverseTransferRefused(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		reversed float64
+		amount   float64
+		unknown  bool
+		wantErr  error
+	}{
+		{"unknown transfer", dbank.TransferStatusCompleted, 0, 0, true, dbank.ErrTransferNotFound},
+		{"pending", dbank.TransferStatusPending, 0, 0, false, dbank.ErrTransferNotReversible},
+		{"processing", dbank.TransferStatusProcessing, 0, 0, false, dbank.ErrTransferNotReversible},
+		{"failed", dbank.TransferStatusFailed, 0, 0, false, dbank.ErrTransferNotReversible},
+		{"reversed", dbank.TransferStatusReversed, 100, 0, false, dbank.ErrTransferAlreadyReversed},
+		{"nothing left to reverse", dbank.TransferStatusCompleted, 100, 0, false, dbank.ErrTransferAlreadyReversed},
+		{"more than the transfer", dbank.TransferStatusCompleted, 0, 100.01, false, dbank.ErrReversalExceedsRemaining},
+		{"more than what is left", dbank.TransferStatusCompleted, 60, 40.5, false, dbank.ErrReversalExceedsRemaining},
+		{"below a cent", dbank.TransferStatusCompleted, 0, 0.004, false, dbank.ErrAmountBelowMinorUnit},
+		{"negative", dbank.TransferStatusCompleted, 0, -10, false, dbank.ErrAmountBelowMinorUnit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transferOrm := database.BankTransferOrm{
+				TransferUuid:    uuid.New(),
+				FromAccountUuid: uuid.New(),
+				ToAccountUuid:   uuid.New(),
+				Currency:        "USD",
+				Amount:          100,
+				ReversedAmount:  tt.reversed,
+				Status:          tt.status,
+			}
+			b := NewBankService(&reversalDb{transfer: transferOrm})
+
+			transferUuid := transferOrm.TransferUuid
+			if tt.unknown {
+				transferUuid = uuid.New()
+			}
+
+			_, acct, err := b.reverseTransfer(dbank.TransferReversal{TransferUuid: transferUuid, Amount: tt.amount})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("reverseTransfer() = %v, want %v", err, tt.wantErr)
+			}
+
+			if !tt.unknown && acct != "7835697002" {
+				t.Errorf("reverseTransfer() account = %q, want the destination account", acct)
+			}
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// maxScheduledTransfersPerRun bounds the occurrences a single scheduler tick
+// executes, the rest are picked up by the next tick
+const maxScheduledTransfersPerRun = 100
+
+func toScheduledTransfer(s database.ScheduledTransferOrm, fromAcct string, toAcct string) dbank.ScheduledTransfer {
+	st := dbank.ScheduledTransfer{
+		ScheduleUuid:      s.ScheduleUuid,
+		FromAccountNumber: fromAcct,
+		ToAccountNumber:   toAcct,
+		Currency:          s.Currency,
+		Amount:            s.Amount,
+		Frequency:         s.Frequency,
+		Interval:          s.Interval,
+		StartAt:           s.StartAt,
+		NextRunAt:         s.NextRunAt,
+		Status:            s.Status,
+		CreatedBy:         s.CreatedBy,
+	}
+
+	if s.EndAt != nil {
+		st.EndAt = *s.EndAt
+	}
+
+	return st
+}
+
+func toScheduledTransferExecution(e database.ScheduledTransferExecutionOrm) dbank.ScheduledTransferExecution {
+	ex := dbank.ScheduledTransferExecution{
+		ExecutionUuid: e.ExecutionUuid,
+		ScheduledFor:  e.ScheduledFor,
+		Attempt:       e.Attempt,
+		Status:        e.Status,
+		Error:         e.Error,
+		StartedAt:     e.StartedAt,
+	}
+
+	if e.TransferUuid != nil {
+		ex.TransferUuid = *e.TransferUuid
+	}
+
+	if e.FinishedAt != nil {
+		ex.FinishedAt = *e.FinishedAt
+	}
+
+	return ex
+}
+
+func scheduleNotFoundError(scheduleUuid uuid.UUID) error {
+	return &dbank.NotFoundError{
+		Reason:   dbank.ReasonScheduleNotFound,
+		Resource: dbank.ResourceSchedule,
+		Key:      scheduleUuid.String(),
+		Err:      dbank.ErrScheduleNotFound,
+	}
+}
+
+func (b *BankService) CreateScheduledTransfer(s dbank.ScheduledTransfer) (dbank.ScheduledTransfer, error) {
+	created, err := b.createScheduledTransfer(s)
+	b.recordAudit(s.Origin, dbank.AuditActionCreateSchedule, s.FromAccountNumber, s.Amount, s.Currency,
+		fmt.Sprintf("scheduled transfer %v to %v %v", created.ScheduleUuid, s.ToAccountNumber, s.Frequency), err)
+
+	return created, err
+}
+
+func (b *BankService) createScheduledTransfer(s dbank.ScheduledTransfer) (dbank.ScheduledTransfer, error) {
+	now := time.Now()
+
+	switch s.Frequency {
+	case dbank.ScheduleFrequencyOnce, dbank.ScheduleFrequencyDaily, dbank.ScheduleFrequencyWeekly, dbank.ScheduleFrequencyMonthly:
+	default:
+		return dbank.ScheduledTransfer{}, dbank.ErrScheduleUnknownFrequency
+	}
+
+	if s.Interval < 1 {
+		s.Interval = 1
+	}
+
+	if s.StartAt.IsZero() {
+		s.StartAt = now
+	}
+
+	if s.StartAt.Before(now.Add(-time.Minute)) || (!s.EndAt.IsZero() && s.EndAt.Before(s.StartAt)) {
+		return dbank.ScheduledTransfer{}, dbank.ErrScheduleInvalidPeriod
+	}
+
+	fromAccountOrm, err := b.db.GetBankAccountByAccountNumber(s.FromAccountNumber)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, accountLookupError(s.FromAccountNumber, err, dbank.ErrTransferSourceAccountNotFound)
+	}
+
+	toAccountOrm, err := b.db.GetBankAccountByAccountNumber(s.ToAccountNumber)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, accountLookupError(s.ToAccountNumber, err, dbank.ErrTransferDestinationAccountNotFound)
+	}
+
+	cur, err := dbank.Currencies.FindEnabled(s.Currency)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, err
+	}
+
+	if fromAccountOrm.Currency != cur.Code || toAccountOrm.Currency != cur.Code {
+		return dbank.ScheduledTransfer{}, dbank.ErrCurrencyMismatch
+	}
+
+	if err := b.checkProductTransfer(map[uuid.UUID]dbank.AccountProduct{}, fromAccountOrm, toAccountOrm, cur.Code); err != nil {
+		return dbank.ScheduledTransfer{}, err
+	}
+
+	s.Amount = cur.Round(s.Amount)
+
+	if s.Amount <= 0 {
+		return dbank.ScheduledTransfer{}, dbank.ErrAmountBelowMinorUnit
+	}
+
+	createdBy := s.Origin.Actor
+	if createdBy == "" {
+		createdBy = dbank.SystemActor
+	}
+
+	scheduleOrm := database.ScheduledTransferOrm{
+		ScheduleUuid:    uuid.New(),
+		FromAccountUuid: fromAccountOrm.AccountUuid,
+		ToAccountUuid:   toAccountOrm.AccountUuid,
+		Currency:        cur.Code,
+		Amount:          s.Amount,
+		Frequency:       s.Frequency,
+		Interval:        s.Interval,
+		StartAt:         s.StartAt,
+		NextRunAt:       s.StartAt,
+		NextAttemptAt:   s.StartAt,
+		Status:          dbank.ScheduleStatusActive,
+		CreatedBy:       createdBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if !s.EndAt.IsZero() {
+		scheduleOrm.EndAt = &s.EndAt
+	}
+
+	if _, err := b.db.CreateScheduledTransfer(scheduleOrm); err != nil {
+		return dbank.ScheduledTransfer{}, dbank.NewUnavailableError("scheduled transfer creation", err)
+	}
+
+	return toScheduledTransfer(scheduleOrm, s.FromAccountNumber, s.ToAccountNumber), nil
+}
+
+// findAccountSchedule loads a schedule and checks that it transfers out of
+// the account
+func (b *BankService) findAccountSchedule(acct string, scheduleUuid uuid.UUID) (dbank.ScheduledTransfer, error) {
+	fromAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	scheduleOrm, err := b.db.GetScheduledTransfer(scheduleUuid)
+
+	if errors.Is(err, database.ErrRecordNotFound) || (err == nil && scheduleOrm.FromAccountUuid != fromAccountOrm.AccountUuid) {
+		return dbank.ScheduledTransfer{}, scheduleNotFoundError(scheduleUuid)
+	} else if err != nil {
+		return dbank.ScheduledTransfer{}, dbank.NewUnavailableError("scheduled transfer lookup", err)
+	}
+
+	toAccountOrm, err := b.db.GetBankAccountByUuid(scheduleOrm.ToAccountUuid)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, dbank.NewUnavailableError("account lookup", err)
+	}
+
+	return toScheduledTransfer(scheduleOrm, acct, toAccountOrm.AccountNumber), nil
+}
+
+// GetScheduledTransfer returns the schedule with every execution so far
+func (b *BankService) GetScheduledTransfer(acct string, scheduleUuid uuid.UUID) (dbank.ScheduledTransfer, error) {
+	st, err := b.findAccountSchedule(acct, scheduleUuid)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, err
+	}
+
+	executionOrms, err := b.db.GetScheduledTransferExecutions(scheduleUuid)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, dbank.NewUnavailableError("scheduled transfer execution lookup", err)
+	}
+
+	st.Executions = make([]dbank.ScheduledTransferExecution, 0, len(executionOrms))
+
+	for _, e := range executionOrms {
+		st.Executions = append(st.Executions, toScheduledTransferExecution(e))
+	}
+
+	return st, nil
+}
+
+func (b *BankService) ListScheduledTransfers(acct string) ([]dbank.ScheduledTransfer, error) {
+	fromAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		return nil, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	scheduleOrms, err := b.db.FindScheduledTransfers(fromAccountOrm.AccountUuid)
+
+	if err != nil {
+		return nil, dbank.NewUnavailableError("scheduled transfer lookup", err)
+	}
+
+	toAccounts := map[uuid.UUID]string{}
+	res := make([]dbank.ScheduledTransfer, 0, len(scheduleOrms))
+
+	for _, s := range scheduleOrms {
+		toAcct, ok := toAccounts[s.ToAccountUuid]
+
+		if !ok {
+			toAccountOrm, err := b.db.GetBankAccountByUuid(s.ToAccountUuid)
+
+			if err != nil {
+				return nil, dbank.NewUnavailableError("account lookup", err)
+			}
+
+			toAcct = toAccountOrm.AccountNumber
+			toAccounts[s.ToAccountUuid] = toAcct
+		}
+
+		res = append(res, toScheduledTransfer(s, acct, toAcct))
+	}
+
+	return res, nil
+}
+
+func (b *BankService) CancelScheduledTransfer(origin dbank.Origin, acct string, scheduleUuid uuid.UUID) (dbank.ScheduledTransfer, error) {
+	cancelled, err := b.cancelScheduledTransfer(acct, scheduleUuid)
+	b.recordAudit(origin, dbank.AuditActionCancelSchedule, acct, cancelled.Amount, cancelled.Currency,
+		fmt.Sprintf("scheduled transfer %v", scheduleUuid), err)
+
+	return cancelled, err
+}
+
+func (b *BankService) cancelScheduledTransfer(acct string, scheduleUuid uuid.UUID) (dbank.ScheduledTransfer, error) {
+	st, err := b.findAccountSchedule(acct, scheduleUuid)
+
+	if err != nil {
+		return dbank.ScheduledTransfer{}, err
+	}
+
+	notActiveErr := &dbank.ConflictError{
+		Reason:   dbank.ReasonScheduleNotActive,
+		Resource: dbank.ResourceSchedule,
+		Key:      scheduleUuid.String(),
+		Detail:   "scheduled transfer is " + st.Status,
+		Err:      dbank.ErrScheduleNotActive,
+	}
+
+	if st.Status != dbank.ScheduleStatusActive {
+		return st, notActiveErr
+	}
+
+	ok, err := b.db.UpdateScheduledTransferStatus(scheduleUuid, dbank.ScheduleStatusCancelled)
+
+	if err != nil {
+		return st, dbank.NewUnavailableError("scheduled transfer cancellation", err)
+	}
+
+	if !ok {
+		return st, notActiveErr
+	}
+
+	st.Status = dbank.ScheduleStatusCancelled
+
+	return st, nil
+}
+
+// RunDueScheduledTransfers executes the occurrences due at ts and returns how
+// many were executed. An occurrence is claimed before it runs and its start
+// is recorded before the transfer is made, so a restart never executes it
+// twice : an execution found started but unfinished is marked unknown and
+// the schedule moves on.
+func (b *BankService) RunDueScheduledTransfers(ts time.Time) (int, error) {
+	executed := 0
+
+	for executed < maxScheduledTransfersPerRun {
+		scheduleOrm, ok, err := b.db.ClaimDueScheduledTransfer(ts, ts.Add(dbank.ScheduledTransferLease))
+
+		if err != nil {
+			return executed, dbank.NewUnavailableError("scheduled transfer claim", err)
+		}
+
+		if !ok {
+			return executed, nil
+		}
+
+		if err := b.runScheduledTransfer(scheduleOrm, ts); err != nil {
+			log.Printf("Can't run scheduled transfer %v : %v\n", scheduleOrm.ScheduleUuid, err)
+		}
+
+		executed++
+	}
+
+	return executed, nil
+}
+
+// advanceSchedule moves the schedule to its next occurrence after ts, or
+// completes it. Occurrences missed while the server was down are skipped.
+func advanceSchedule(s *database.ScheduledTransferOrm, ts time.Time) {
+	s.Attempts = 0
+
+	switch s.Frequency {
+	case dbank.ScheduleFrequencyDaily, dbank.ScheduleFrequencyWeekly, dbank.ScheduleFrequencyMonthly:
+	default:
+		s.Status = dbank.ScheduleStatusCompleted
+		return
+	}
+
+	next := s.NextRunAt
+
+	for !next.After(ts) || !next.After(s.NextRunAt) {
+		s.Occurrence++
+		next = dbank.NextOccurrence(s.StartAt, s.Frequency, s.Interval, s.Occurrence)
+	}
+
+	if s.EndAt != nil && next.After(*s.EndAt) {
+		s.Status = dbank.ScheduleStatusCompleted
+		return
+	}
+
+	s.NextRunAt = next
+	s.NextAttemptAt = next
+}
+
+func (b *BankService) runScheduledTransfer(s database.ScheduledTransferOrm, ts time.Time) error {
+	startedOrm, err := b.db.GetStartedScheduledTransferExecution(s.ScheduleUuid)
+
+	if err == nil {
+		log.Printf("Scheduled transfer %v execution %v was interrupted, its outcome must be checked manually\n",
+			s.ScheduleUuid, startedOrm.ExecutionUuid)
+
+		finishedAt := time.Now()
+		startedOrm.Status = dbank.ExecutionStatusUnknown
+		startedOrm.Error = "interrupted before its result was recorded"
+		startedOrm.FinishedAt = &finishedAt
+		advanceSchedule(&s, ts)
+
+		return b.db.FinishScheduledTransferExecution(startedOrm, s)
+	} else if !errors.Is(err, database.ErrRecordNotFound) {
+		return err
+	}
+
+	executionOrm := database.ScheduledTransferExecutionOrm{
+		ExecutionUuid: uuid.New(),
+		ScheduleUuid:  s.ScheduleUuid,
+		ScheduledFor:  s.NextRunAt,
+		Attempt:       s.Attempts + 1,
+		Status:        dbank.ExecutionStatusStarted,
+		StartedAt:     time.Now(),
+	}
+
+	if _, err := b.db.CreateScheduledTransferExecution(executionOrm); err != nil {
+		return err
+	}
+
+	transferUuid, transferErr := b.executeScheduledTransfer(s, executionOrm)
+
+	finishedAt := time.Now()
+	executionOrm.FinishedAt = &finishedAt
+
+	if transferUuid != uuid.Nil {
+		executionOrm.TransferUuid = &transferUuid
+	}
+
+	var unavailable *dbank.UnavailableError
+
+	switch {
+	case transferErr == nil:
+		executionOrm.Status = dbank.ExecutionStatusSuccess
+		advanceSchedule(&s, ts)
+	case errors.As(transferErr, &unavailable) && executionOrm.Attempt < dbank.ScheduledTransferMaxAttempts:
+		executionOrm.Status = dbank.ExecutionStatusRetry
+		executionOrm.Error = transferErr.Error()
+		s.Attempts = executionOrm.Attempt
+		s.NextAttemptAt = ts.Add(dbank.ScheduledTransferRetryBackoff << (executionOrm.Attempt - 1))
+	default:
+		executionOrm.Status = dbank.ExecutionStatusFailure
+		executionOrm.Error = transferErr.Error()
+		advanceSchedule(&s, ts)
+	}
+
+	log.Printf("Scheduled transfer %v for %v attempt %v : %v\n", s.ScheduleUuid, executionOrm.ScheduledFor.Format(time.RFC3339),
+		executionOrm.Attempt, executionOrm.Status)
+
+	return b.db.FinishScheduledTransferExecution(executionOrm, s)
+}
+
+func (b *BankService) executeScheduledTransfer(s database.ScheduledTransferOrm, e database.ScheduledTransferExecutionOrm) (uuid.UUID, error) {
+	fromAccountOrm, err := b.db.GetBankAccountByUuid(s.FromAccountUuid)
+
+	if err != nil {
+		return uuid.Nil, dbank.NewUnavailableError("account lookup", err)
+	}
+
+	toAccountOrm, err := b.db.GetBankAccountByUuid(s.ToAccountUuid)
+
+	if err != nil {
+		return uuid.Nil, dbank.NewUnavailableError("account lookup", err)
+	}
+
+	transferUuid, _, err := b.Transfer(dbank.TrasferTransaction{
+		FromAccountNumber: fromAccountOrm.AccountNumber,
+		ToAccountNumber:   toAccountOrm.AccountNumber,
+		Currency:          s.Currency,
+		Amount:            s.Amount,
+		Origin: dbank.Origin{
+			Actor:     dbank.SystemActor,
+			RequestId: e.ExecutionUuid.String(),
+		},
+	})
+
+	return transferUuid, err
+}
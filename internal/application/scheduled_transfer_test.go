@@ -0,0 +1,142 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// scheduleDb fails every account lookup so transfers never start, and records
+// how executions finish, any other call panics
+type scheduleDb struct {
+	port.BankDatabasePort
+	started   *database.ScheduledTransferExecutionOrm
+	execution database.ScheduledTransferExecutionOrm
+	schedule  database.ScheduledTransferOrm
+}
+
+func (d *scheduleDb) GetStartedScheduledTransferExecution(scheduleUuid uuid.UUID) (database.ScheduledTransferExecutionOrm, error) {
+	if d.started == nil {
+		return database.ScheduledTransferExecutionOrm{}, database.ErrRecordNotFound
+	}
+
+	return *d.started, nil
+}
+
+func (d *scheduleDb) CreateScheduledTransferExecution(e database.ScheduledTransferExecutionOrm) (uuid.UUID, error) {
+	return e.ExecutionUuid, nil
+}
+
+func (d *scheduleDb) FinishScheduledTransferExecution(e database.ScheduledTransferExecutionOrm, s database.ScheduledTransferOrm) error {
+	d.execution = e
+	d.schedule = s
+	return nil
+}
+
+func (d *scheduleDb) GetBankAccountByUuid(accountUuid uuid.UUID) (database.BankAccountOrm, error) {
+	return database.BankAccountOrm{}, errors.New("connection refused")
+}
+
+func TestAdvanceSchedule(t *testing.T) {
+	start := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	endAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		frequency      string
+		occurrence     int
+		nextRunAt      time.Time
+		endAt          *time.Time
+		ts             time.Time
+		wantStatus     string
+		wantNextRunAt  time.Time
+		wantOccurrence int
+	}{
+		{"once completes", dbank.ScheduleFrequencyOnce, 0, start, nil, start, dbank.ScheduleStatusCompleted, start, 0},
+		{"next month", dbank.ScheduleFrequencyMonthly, 0, start, nil, start, dbank.ScheduleStatusActive, time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), 1},
+		{"missed occurrences skipped", dbank.ScheduleFrequencyDaily, 0, start, nil, start.AddDate(0, 0, 3).Add(time.Hour), dbank.ScheduleStatusActive, start.AddDate(0, 0, 4), 4},
+		{"run early still advances", dbank.ScheduleFrequencyWeekly, 0, start, nil, start.Add(-time.Hour), dbank.ScheduleStatusActive, start.AddDate(0, 0, 7), 1},
+		{"next occurrence after the end", dbank.ScheduleFrequencyMonthly, 1, time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), &endAt, time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), dbank.ScheduleStatusCompleted, time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := database.ScheduledTransferOrm{
+				Frequency:  tt.frequency,
+				Interval:   1,
+				StartAt:    start,
+				EndAt:      tt.endAt,
+				Occurrence: tt.occurrence,
+				NextRunAt:  tt.nextRunAt,
+				Attempts:   2,
+				Status:     dbank.ScheduleStatusActive,
+			}
+
+			advanceSchedule(&s, tt.ts)
+
+			if s.Status != tt.wantStatus || !s.NextRunAt.Equal(tt.wantNextRunAt) || s.Occurrence != tt.wantOccurrence {
+				t.Errorf("advanceSchedule() = %v next %v occurrence %v, want %v next %v occurrence %v",
+					s.Status, s.NextRunAt, s.Occurrence, tt.wantStatus, tt.wantNextRunAt, tt.wantOccurrence)
+			}
+
+			if s.Attempts != 0 {
+				t.Errorf("advanceSchedule() attempts = %v, want 0", s.Attempts)
+			}
+		})
+	}
+}
+
+func TestRunScheduledTransfer(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		attempts        int
+		started         *database.ScheduledTransferExecutionOrm
+		wantStatus      string
+		wantAttempts    int
+		wantNextRunAt   time.Time
+		wantNextAttempt time.Time
+	}{
+		{"first failure retried", 0, nil, dbank.ExecutionStatusRetry, 1, ts, ts.Add(dbank.ScheduledTransferRetryBackoff)},
+		{"backoff doubles", 2, nil, dbank.ExecutionStatusRetry, 3, ts, ts.Add(4 * dbank.ScheduledTransferRetryBackoff)},
+		{"last attempt fails the occurrence", dbank.ScheduledTransferMaxAttempts - 1, nil, dbank.ExecutionStatusFailure, 0, ts.AddDate(0, 0, 1), ts.AddDate(0, 0, 1)},
+		{"interrupted execution never retried", 0, &database.ScheduledTransferExecutionOrm{ExecutionUuid: uuid.New(), Status: dbank.ExecutionStatusStarted}, dbank.ExecutionStatusUnknown, 0, ts.AddDate(0, 0, 1), ts.AddDate(0, 0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &scheduleDb{started: tt.started}
+			b := NewBankService(db)
+
+			err := b.runScheduledTransfer(database.ScheduledTransferOrm{
+				ScheduleUuid:  uuid.New(),
+				Frequency:     dbank.ScheduleFrequencyDaily,
+				Interval:      1,
+				StartAt:       ts,
+				NextRunAt:     ts,
+				NextAttemptAt: ts,
+				Attempts:      tt.attempts,
+				Status:        dbank.ScheduleStatusActive,
+			}, ts)
+
+			if err != nil {
+				t.Fatalf("runScheduledTransfer() = %v", err)
+			}
+
+			if db.execution.Status != tt.wantStatus {
+				t.Errorf("execution status = %v, want %v", db.execution.Status, tt.wantStatus)
+			}
+
+			if db.schedule.Attempts != tt.wantAttempts || !db.schedule.NextRunAt.Equal(tt.wantNextRunAt) || !db.schedule.NextAttemptAt.Equal(tt.wantNextAttempt) {
+				t.Errorf("schedule = attempts %v next run %v next attempt %v, want %v, %v, %v",
+					db.schedule.Attempts, db.schedule.NextRunAt, db.schedule.NextAttemptAt, tt.wantAttempts, tt.wantNextRunAt, tt.wantNextAttempt)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+)
+
+const statementDateLayout = "2006-01-02"
+
+type statementLineJson struct {
+	TransactionUuid string  `json:"transaction_uuid"`
+	Timestamp       string  `json:"timestamp"`
+	TransactionType string  `json:"transaction_type"`
+	Amount          float64 `json:"amount"`
+	RunningBalance  float64 `json:"running_balance"`
+	Notes           string  `json:"notes"`
+}
+
+type statementJson struct {
+	AccountNumber  string              `json:"account_number"`
+	AccountName    string              `json:"account_name"`
+	Currency       string              `json:"currency"`
+	PeriodStart    string              `json:"period_start"`
+	PeriodEnd      string              `json:"period_end"`
+	OpeningBalance float64             `json:"opening_balance"`
+	ClosingBalance float64             `json:"closing_balance"`
+	Lines          []statementLineJson `json:"lines"`
+}
+
+func (b *BankService) ExportStatement(st dbank.Statement, format string) ([]byte, error) {
+	switch format {
+	case dbank.StatementFormatCsv:
+		return exportStatementCsv(st)
+	case dbank.StatementFormatJson:
+		return exportStatementJson(st)
+	case dbank.StatementFormatText:
+		return exportStatementText(st), nil
+	default:
+		return nil, dbank.ErrStatementUnknownFormat
+	}
+}
+
+// statementPeriodEnd returns the last day covered by the statement, as the
+// period end itself is exclusive.
+func statementPeriodEnd(st dbank.Statement) string {
+	return st.PeriodEnd.Add(-1 * time.Nanosecond).Format(statementDateLayout)
+}
+
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
+
+func exportStatementCsv(st dbank.Statement) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{
+		{"account_number", st.AccountNumber},
+		{"account_name", st.AccountName},
+		{"currency", st.Currency},
+		{"period_start", st.PeriodStart.Format(statementDateLayout)},
+		{"period_end", statementPeriodEnd(st)},
+		{},
+		{"timestamp", "transaction_uuid", "transaction_type", "amount", "running_balance", "notes"},
+		{"", "", "OPENING", "", formatAmount(st.OpeningBalance), "Opening balance"},
+	}
+
+	for _, l := range st.Lines {
+		records = append(records, []string{
+			l.Timestamp.UTC().Format(time.RFC3339),
+			l.TransactionUuid.String(),
+			l.TransactionType,
+			formatAmount(l.Amount),
+			formatAmount(l.RunningBalance),
+			l.Notes,
+		})
+	}
+
+	records = append(records, []string{"", "", "CLOSING", "", formatAmount(st.ClosingBalance), "Closing balance"})
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func exportStatementJson(st dbank.Statement) ([]byte, error) {
+	res := statementJson{
+		AccountNumber:  st.AccountNumber,
+		AccountName:    st.AccountName,
+		Currency:       st.Currency,
+		PeriodStart:    st.PeriodStart.Format(statementDateLayout),
+		PeriodEnd:      statementPeriodEnd(st),
+		OpeningBalance: st.OpeningBalance,
+		ClosingBalance: st.ClosingBalance,
+		Lines:          make([]statementLineJson, 0, len(st.Lines)),
+	}
+
+	for _, l := range st.Lines {
+		res.Lines = append(res.Lines, statementLineJson{
+			TransactionUuid: l.TransactionUuid.String(),
+			Timestamp:       l.Timestamp.UTC().Format(time.RFC3339),
+			TransactionType: l.TransactionType,
+			Amount:          l.Amount,
+			RunningBalance:  l.RunningBalance,
+			Notes:           l.Notes,
+		})
+	}
+
+	return json.MarshalIndent(res, "", "  ")
+}
+
+func exportStatementText(st dbank.Statement) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "Account  : %v (%v)\n", st.AccountNumber, st.AccountName)
+	fmt.Fprintf(&buf, "Currency : %v\n", st.Currency)
+	fmt.Fprintf(&buf, "Period   : %v to %v\n\n", st.PeriodStart.Format(statementDateLayout), statementPeriodEnd(st))
+
+	fmt.Fprintf(&buf, "%-20s %-4s %15s %15s  %s\n", "DATE", "TYPE", "AMOUNT", "BALANCE", "NOTES")
+	fmt.Fprintf(&buf, "%-20s %-4s %15s %15s  %s\n", "", "", "", formatAmount(st.OpeningBalance), "Opening balance")
+
+	for _, l := range st.Lines {
+		fmt.Fprintf(&buf, "%-20s %-4s %15s %15s  %s\n",
+			l.Timestamp.UTC().Format("2006-01-02 15:04:05"),
+			l.TransactionType,
+			formatAmount(l.Amount),
+			formatAmount(l.RunningBalance),
+			l.Notes,
+		)
+	}
+
+	fmt.Fprintf(&buf, "%-20s %-4s %15s %15s  %s\n", "", "", "", formatAmount(st.ClosingBalance), "Closing balance")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,259 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// statementDb serves one account with its stored balance and transactions,
+// any other call panics
+type statementDb struct {
+	port.BankDatabasePort
+	balance      float64
+	transactions []database.BankTransactionOrm
+}
+
+// newStatementDb stores the balance the account reaches from opening through
+// the transactions
+func newStatementDb(opening float64, transactions []database.BankTransactionOrm) *statementDb {
+	return &statementDb{balance: opening + netAmount(transactions, time.Time{}), transactions: transactions}
+}
+
+func netAmount(transactions []database.BankTransactionOrm, since time.Time) float64 {
+	var net float64
+
+	for _, t := range transactions {
+		if t.TransactionTimestamp.Before(since) {
+			continue
+		}
+
+		if t.TransactionType == dbank.TransactionTypeOut {
+			net -= t.Amount
+		} else {
+			net += t.Amount
+		}
+	}
+
+	return net
+}
+
+func (d *statementDb) GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error) {
+	if acct != "7835697001" {
+		return database.BankAccountOrm{}, database.ErrRecordNotFound
+	}
+
+	return database.BankAccountOrm{AccountNumber: acct, AccountName: "Alice", Currency: "USD"}, nil
+}
+
+func (d *statementDb) GetBankAccountBalanceAt(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	return d.balance - netAmount(d.transactions, ts), nil
+}
+
+func (d *statementDb) GetBankTransactionsInPeriod(accountUuid uuid.UUID, from time.Time, to time.Time) ([]database.BankTransactionOrm, error) {
+	var res []database.BankTransactionOrm
+
+	for _, t := range d.transactions {
+		if !t.TransactionTimestamp.Before(from) && t.TransactionTimestamp.Before(to) {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+func testStatement() dbank.Statement {
+	return dbank.Statement{
+		AccountNumber:  "7835697001",
+		AccountName:    "Alice",
+		Currency:       "USD",
+		PeriodStart:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:      time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: 100,
+		ClosingBalance: 75.5,
+		Lines: []dbank.StatementLine{
+			{TransactionUuid: uuid.New(), Timestamp: time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC), TransactionType: dbank.TransactionTypeOut, Amount: 24.5, RunningBalance: 75.5, Notes: `Coffee, "large"`},
+		},
+	}
+}
+
+func TestGenerateStatement(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	day := func(d int) time.Time { return from.AddDate(0, 0, d) }
+
+	tests := []struct {
+		name         string
+		acct         string
+		from         time.Time
+		to           time.Time
+		transactions []database.BankTransactionOrm
+		wantBalances []float64
+		wantClosing  float64
+		wantErr      error
+	}{
+		{"running balance", "7835697001", from, to, []database.BankTransactionOrm{
+			{TransactionType: dbank.TransactionTypeIn, Amount: 50, TransactionTimestamp: day(1)},
+			{TransactionType: dbank.TransactionTypeOut, Amount: 120, TransactionTimestamp: day(2)},
+			{TransactionType: dbank.TransactionTypeIn, Amount: 0.5, TransactionTimestamp: day(3)},
+		}, []float64{150, 30, 30.5}, 30.5, nil},
+		{"transactions around the period", "7835697001", from, to, []database.BankTransactionOrm{
+			{TransactionType: dbank.TransactionTypeOut, Amount: 20, TransactionTimestamp: day(-3)},
+			{TransactionType: dbank.TransactionTypeIn, Amount: 10, TransactionTimestamp: day(5)},
+			{TransactionType: dbank.TransactionTypeOut, Amount: 40, TransactionTimestamp: to},
+		}, []float64{110}, 110, nil},
+		{"no transactions", "7835697001", from, to, nil, nil, 100, nil},
+		{"empty period", "7835697001", from, from, nil, nil, 0, dbank.ErrStatementInvalidPeriod},
+		{"reversed period", "7835697001", to, from, nil, nil, 0, dbank.ErrStatementInvalidPeriod},
+		{"unknown account", "7835697999", from, to, nil, nil, 0, dbank.ErrStatementAccountNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the account holds 100 at the start of the period
+			before := netAmount(tt.transactions, time.Time{}) - netAmount(tt.transactions, from)
+			b := NewBankService(newStatementDb(100-before, tt.transactions))
+
+			st, err := b.GenerateStatement(tt.acct, tt.from, tt.to)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("GenerateStatement() = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if st.OpeningBalance != 100 || st.ClosingBalance != tt.wantClosing || len(st.Lines) != len(tt.wantBalances) {
+				t.Fatalf("GenerateStatement() = opening %v closing %v with %v lines, want 100, %v, %v", st.OpeningBalance, st.ClosingBalance, len(st.Lines), tt.wantClosing, len(tt.wantBalances))
+			}
+
+			for i, want := range tt.wantBalances {
+				if st.Lines[i].RunningBalance != want {
+					t.Errorf("line %v running balance = %v, want %v", i, st.Lines[i].RunningBalance, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateStatementReconciles(t *testing.T) {
+	opened := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	transactions := []database.BankTransactionOrm{
+		{TransactionType: dbank.TransactionTypeIn, Amount: 50, TransactionTimestamp: opened.AddDate(0, 0, 2)},
+		{TransactionType: dbank.TransactionTypeOut, Amount: 70.25, TransactionTimestamp: opened.AddDate(0, 1, 3)},
+		{TransactionType: dbank.TransactionTypeIn, Amount: 8, TransactionTimestamp: opened.AddDate(0, 2, 1)},
+	}
+
+	// the account was opened with a balance that no transaction accounts for
+	db := newStatementDb(1000, transactions)
+	b := NewBankService(db)
+
+	var closing float64
+
+	for month := 0; month < 3; month++ {
+		st, err := b.GenerateStatement("7835697001", opened.AddDate(0, month, 0), opened.AddDate(0, month+1, 0))
+
+		if err != nil {
+			t.Fatalf("GenerateStatement() = %v", err)
+		}
+
+		if month == 0 && st.OpeningBalance != 1000 {
+			t.Errorf("first opening balance = %v, want the balance the account was opened with 1000", st.OpeningBalance)
+		}
+
+		if month > 0 && st.OpeningBalance != closing {
+			t.Errorf("month %v opening balance = %v, want the previous closing balance %v", month, st.OpeningBalance, closing)
+		}
+
+		closing = st.ClosingBalance
+	}
+
+	if closing != db.balance {
+		t.Errorf("last closing balance = %v, want the stored balance %v", closing, db.balance)
+	}
+}
+
+func TestExportStatement(t *testing.T) {
+	st := testStatement()
+	b := NewBankService(nil)
+
+	tests := []struct {
+		name    string
+		format  string
+		check   func(t *testing.T, content []byte)
+		wantErr error
+	}{
+		{"csv", dbank.StatementFormatCsv, func(t *testing.T, content []byte) {
+			r := csv.NewReader(bytes.NewReader(content))
+			r.FieldsPerRecord = -1
+			records, err := r.ReadAll()
+
+			if err != nil {
+				t.Fatalf("csv = %v", err)
+			}
+
+			if got := records[4]; got[0] != "period_end" || got[1] != "2024-03-31" {
+				t.Errorf("period end record = %v, want the last day of the period", got)
+			}
+
+			// the blank line between the header and the transactions is skipped
+			line := records[len(records)-2]
+			if line[2] != dbank.TransactionTypeOut || line[3] != "24.50" || line[4] != "75.50" || line[5] != `Coffee, "large"` {
+				t.Errorf("transaction record = %v", line)
+			}
+
+			if closing := records[len(records)-1]; closing[2] != "CLOSING" || closing[4] != "75.50" {
+				t.Errorf("closing record = %v", closing)
+			}
+		}, nil},
+		{"json", dbank.StatementFormatJson, func(t *testing.T, content []byte) {
+			var got statementJson
+
+			if err := json.Unmarshal(content, &got); err != nil {
+				t.Fatalf("json = %v", err)
+			}
+
+			if got.PeriodStart != "2024-03-01" || got.PeriodEnd != "2024-03-31" || got.ClosingBalance != 75.5 || len(got.Lines) != 1 {
+				t.Errorf("json = %+v", got)
+			}
+
+			if got.Lines[0].Timestamp != "2024-03-05T09:30:00Z" || got.Lines[0].TransactionUuid != st.Lines[0].TransactionUuid.String() {
+				t.Errorf("json line = %+v", got.Lines[0])
+			}
+		}, nil},
+		{"text", dbank.StatementFormatText, func(t *testing.T, content []byte) {
+			text := string(content)
+
+			for _, want := range []string{"Period   : 2024-03-01 to 2024-03-31", "2024-03-05 09:30:00", "75.50  Closing balance"} {
+				if !strings.Contains(text, want) {
+					t.Errorf("text does not contain %q:\n%v", want, text)
+				}
+			}
+		}, nil},
+		{"unknown format", "PDF", nil, dbank.ErrStatementUnknownFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := b.ExportStatement(st, tt.format)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("ExportStatement() = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.check != nil {
+				tt.check(t, content)
+			}
+		})
+	}
+}
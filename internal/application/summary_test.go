@@ -0,0 +1,129 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// summaryDb serves one account, summarizes its transactions and stores the
+// reports it is given, any other call panics
+type summaryDb struct {
+	port.BankDatabasePort
+	transactions []database.BankTransactionOrm
+	reports      map[int64]database.BankTransactionSummaryReportOrm
+}
+
+func (d *summaryDb) GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error) {
+	return database.BankAccountOrm{AccountNumber: acct, Currency: "USD"}, nil
+}
+
+func (d *summaryDb) GetBankTransactionSummaries(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]database.BankTransactionSummaryOrm, error) {
+	var res []database.BankTransactionSummaryOrm
+
+	for _, t := range d.transactions {
+		if t.TransactionTimestamp.Before(from) || !t.TransactionTimestamp.Before(to) {
+			continue
+		}
+
+		ps := dbank.SummaryPeriodStart(t.TransactionTimestamp, period)
+
+		if len(res) == 0 || !res[len(res)-1].SummaryOnDate.Equal(ps) {
+			res = append(res, database.BankTransactionSummaryOrm{SummaryOnDate: ps})
+		}
+
+		s := &res[len(res)-1]
+		s.Count++
+
+		if t.TransactionType == dbank.TransactionTypeOut {
+			s.SumOut += t.Amount
+		} else {
+			s.SumIn += t.Amount
+		}
+	}
+
+	return res, nil
+}
+
+func (d *summaryDb) GetBankTransactionSummaryReports(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]database.BankTransactionSummaryReportOrm, error) {
+	var res []database.BankTransactionSummaryReportOrm
+
+	for ps := from; ps.Before(to); ps = dbank.NextSummaryPeriod(ps, period) {
+		if r, ok := d.reports[ps.Unix()]; ok {
+			res = append(res, r)
+		}
+	}
+
+	return res, nil
+}
+
+func (d *summaryDb) CreateBankTransactionSummaryReports(reports []database.BankTransactionSummaryReportOrm) error {
+	for _, r := range reports {
+		if _, ok := d.reports[r.PeriodStart.Unix()]; !ok {
+			d.reports[r.PeriodStart.Unix()] = r
+		}
+	}
+
+	return nil
+}
+
+func TestSummarizeTransactionsByPeriodStoresClosedPeriods(t *testing.T) {
+	today := dbank.SummaryPeriodStart(time.Now(), dbank.SummaryPeriodDay)
+	day := func(d int, h int) time.Time { return today.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour) }
+
+	db := &summaryDb{
+		transactions: []database.BankTransactionOrm{
+			{TransactionType: dbank.TransactionTypeIn, Amount: 10, TransactionTimestamp: day(-3, 18)},
+			{TransactionType: dbank.TransactionTypeIn, Amount: 50, TransactionTimestamp: day(-2, 10)},
+			{TransactionType: dbank.TransactionTypeOut, Amount: 20, TransactionTimestamp: day(-2, 11)},
+			{TransactionType: dbank.TransactionTypeIn, Amount: 5, TransactionTimestamp: today},
+		},
+		reports: map[int64]database.BankTransactionSummaryReportOrm{},
+	}
+
+	b := NewBankService(db)
+
+	// the first and the current day are partial, only the two days between
+	// are closed
+	from, to := day(-3, 12), day(1, 0)
+
+	res, err := b.SummarizeTransactionsByPeriod("7835697001", dbank.SummaryPeriodDay, from, to)
+
+	if err != nil {
+		t.Fatalf("SummarizeTransactionsByPeriod() = %v", err)
+	}
+
+	if len(res) != 3 || res[1].SummaryOnDate != day(-2, 0) || res[1].SumTotal != 30 || res[1].Count != 2 {
+		t.Fatalf("SummarizeTransactionsByPeriod() = %+v, want 3 days with 30 over 2 transactions on the second", res)
+	}
+
+	if len(db.reports) != 2 {
+		t.Fatalf("%v reports stored, want the 2 closed days", len(db.reports))
+	}
+
+	if r := db.reports[day(-2, 0).Unix()]; r.SumIn != 50 || r.SumOut != 20 || r.Count != 2 || !r.PeriodEnd.Equal(day(-1, 0)) {
+		t.Errorf("stored report = %+v, want 50 in and 20 out over 2 transactions", r)
+	}
+
+	if r, ok := db.reports[day(-1, 0).Unix()]; !ok || r.Count != 0 {
+		t.Errorf("stored report of the day without transactions = %+v, %v, want an empty one", r, ok)
+	}
+
+	// a transaction the stored report doesn't know about shows the report is
+	// read back instead of summarized again
+	db.transactions = append(db.transactions, database.BankTransactionOrm{TransactionType: dbank.TransactionTypeIn, Amount: 100, TransactionTimestamp: day(-2, 12)})
+
+	res, err = b.SummarizeTransactionsByPeriod("7835697001", dbank.SummaryPeriodDay, from, to)
+
+	if err != nil {
+		t.Fatalf("SummarizeTransactionsByPeriod() again = %v", err)
+	}
+
+	if len(res) != 3 || res[1].SumTotal != 30 || res[1].Count != 2 {
+		t.Errorf("SummarizeTransactionsByPeriod() again = %+v, want the stored 30 over 2 transactions on the second day", res)
+	}
+}
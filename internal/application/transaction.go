@@ -0,0 +1,123 @@
+package application
+
+import (
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+)
+
+const (
+	defaultTransactionLimit = 100
+	maxTransactionLimit     = 1000
+)
+
+func toTransactionMetadataOrm(m dbank.TransactionMetadata) database.TransactionMetadataOrm {
+	return database.TransactionMetadataOrm{
+		Category:              m.Category,
+		Tags:                  m.Tags,
+		CounterpartyName:      m.CounterpartyName,
+		CounterpartyReference: m.CounterpartyReference,
+		ExternalReferenceId:   m.ExternalReferenceId,
+	}
+}
+
+func toTransactionMetadata(m database.TransactionMetadataOrm) dbank.TransactionMetadata {
+	return dbank.TransactionMetadata{
+		Category:              m.Category,
+		Tags:                  m.Tags,
+		CounterpartyName:      m.CounterpartyName,
+		CounterpartyReference: m.CounterpartyReference,
+		ExternalReferenceId:   m.ExternalReferenceId,
+	}
+}
+
+// ListTransactions returns a page of the transactions of an account matching
+// f, newest first, and the token of the next page. The token is empty on the
+// last page.
+func (b *BankService) ListTransactions(f dbank.TransactionFilter) ([]dbank.TransactionRecord, string, error) {
+	// filters are normalized the same way as the metadata they match
+	m := dbank.TransactionMetadata{
+		Category:            f.Category,
+		Tags:                []string{f.Tag},
+		CounterpartyName:    f.CounterpartyName,
+		ExternalReferenceId: f.ExternalReferenceId,
+	}.Normalize()
+
+	q := database.BankTransactionQuery{
+		TransactionType:     f.TransactionType,
+		From:                f.From,
+		To:                  f.To,
+		Category:            m.Category,
+		CounterpartyName:    m.CounterpartyName,
+		ExternalReferenceId: m.ExternalReferenceId,
+		Limit:               f.Limit,
+	}
+
+	if len(m.Tags) > 0 {
+		q.Tag = m.Tags[0]
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = defaultTransactionLimit
+	} else if q.Limit > maxTransactionLimit {
+		q.Limit = maxTransactionLimit
+	}
+
+	if f.PageToken != "" {
+		afterTimestamp, afterUuid, err := parsePageToken(f.PageToken, dbank.ErrTransactionInvalidPageToken)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		q.AfterTimestamp = afterTimestamp
+		q.AfterUuid = afterUuid
+	}
+
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(f.AccountNumber)
+
+	if err != nil {
+		return nil, "", accountLookupError(f.AccountNumber, err, dbank.ErrAccountNotFound)
+	}
+
+	q.AccountUuid = bankAccountOrm.AccountUuid
+
+	// one extra row tells whether there is a next page
+	limit := q.Limit
+	q.Limit++
+
+	transactionOrms, err := b.db.FindBankTransactions(q)
+
+	if err != nil {
+		return nil, "", dbank.NewUnavailableError("transaction lookup", err)
+	}
+
+	var nextPageToken string
+
+	if len(transactionOrms) > limit {
+		transactionOrms = transactionOrms[:limit]
+		last := transactionOrms[limit-1]
+		nextPageToken = pageToken(last.TransactionTimestamp, last.TransactionUuid)
+	}
+
+	res := make([]dbank.TransactionRecord, 0, len(transactionOrms))
+
+	for _, t := range transactionOrms {
+		r := dbank.TransactionRecord{
+			TransactionUuid: t.TransactionUuid,
+			AccountNumber:   bankAccountOrm.AccountNumber,
+			TransactionType: t.TransactionType,
+			Amount:          t.Amount,
+			Timestamp:       t.TransactionTimestamp,
+			Notes:           t.Notes,
+			Metadata:        toTransactionMetadata(t.Metadata),
+		}
+
+		if t.TransferUuid != nil {
+			r.TransferUuid = *t.TransferUuid
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nextPageToken, nil
+}
@@ -0,0 +1,358 @@
+package application
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTransferLimit = 100
+	maxTransferLimit     = 1000
+)
+
+func transferTransitionError(transferUuid uuid.UUID, from string, to string) error {
+	return &dbank.ConflictError{
+		Reason:   dbank.ReasonConflict,
+		Resource: dbank.ResourceTransfer,
+		Key:      transferUuid.String(),
+		Detail:   "transfer can't move from " + from + " to " + to,
+		Err:      dbank.ErrTransferInvalidTransition,
+	}
+}
+
+// transitionTransfer moves a transfer from one state to the next. The update
+// only applies while the transfer is still in state from, so two callers
+// can't both make the same transition.
+func (b *BankService) transitionTransfer(transferUuid uuid.UUID, from string, to string, failureReason string, ts time.Time) error {
+	if !dbank.CanTransitionTransfer(from, to) {
+		return transferTransitionError(transferUuid, from, to)
+	}
+
+	ok, err := b.db.UpdateBankTransferStatus(transferUuid, []string{from}, to, failureReason, ts)
+
+	if err != nil {
+		return dbank.NewUnavailableError("transfer status update", err)
+	}
+
+	if !ok {
+		return transferTransitionError(transferUuid, from, to)
+	}
+
+	return nil
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
+
+func toTransfer(t database.BankTransferOrm, fromAcct string, toAcct string) dbank.Transfer {
+	return dbank.Transfer{
+		TransferUuid:      t.TransferUuid,
+		FromAccountNumber: fromAcct,
+		ToAccountNumber:   toAcct,
+		Currency:          t.Currency,
+		Amount:            t.Amount,
+		Status:            t.Status,
+		FailureReason:     t.FailureReason,
+		ReversedAmount:    t.ReversedAmount,
+		ReversalStatus:    t.ReversalStatus,
+		CreatedAt:         t.CreatedAt,
+		ProcessingAt:      timeOrZero(t.ProcessingAt),
+		CompletedAt:       timeOrZero(t.CompletedAt),
+		FailedAt:          timeOrZero(t.FailedAt),
+		ReversedAt:        timeOrZero(t.ReversedAt),
+	}
+}
+
+// accountNumberOf resolves an account uuid through numbers, which caches the
+// accounts already looked up
+func (b *BankService) accountNumberOf(numbers map[uuid.UUID]string, accountUuid uuid.UUID) (string, error) {
+	if acct, ok := numbers[accountUuid]; ok {
+		return acct, nil
+	}
+
+	bankAccountOrm, err := b.db.GetBankAccountByUuid(accountUuid)
+
+	if err != nil {
+		return "", dbank.NewUnavailableError("account lookup", err)
+	}
+
+	numbers[accountUuid] = bankAccountOrm.AccountNumber
+
+	return bankAccountOrm.AccountNumber, nil
+}
+
+// withTransactions adds to each transfer the debits and credits it posted
+func (b *BankService) withTransactions(transfers []dbank.Transfer, numbers map[uuid.UUID]string) error {
+	transferUuids := make([]uuid.UUID, 0, len(transfers))
+	for _, t := range transfers {
+		transferUuids = append(transferUuids, t.TransferUuid)
+	}
+
+	transactionOrms, err := b.db.GetBankTransactionsByTransfers(transferUuids)
+
+	if err != nil {
+		return dbank.NewUnavailableError("transfer transaction lookup", err)
+	}
+
+	byTransfer := make(map[uuid.UUID][]dbank.TransferTransaction, len(transfers))
+
+	for _, t := range transactionOrms {
+		acct, err := b.accountNumberOf(numbers, t.AccountUuid)
+
+		if err != nil {
+			return err
+		}
+
+		byTransfer[*t.TransferUuid] = append(byTransfer[*t.TransferUuid], dbank.TransferTransaction{
+			TransactionUuid: t.TransactionUuid,
+			AccountNumber:   acct,
+			TransactionType: t.TransactionType,
+			Amount:          t.Amount,
+			Timestamp:       t.TransactionTimestamp,
+			Notes:           t.Notes,
+		})
+	}
+
+	for i := range transfers {
+		transfers[i].Transactions = byTransfer[transfers[i].TransferUuid]
+	}
+
+	return nil
+}
+
+// GetTransfer returns the lifecycle of a transfer with its reversals and the
+// transactions it posted. When acct is set the transfer must be from or to
+// that account.
+func (b *BankService) GetTransfer(acct string, transferUuid uuid.UUID) (dbank.Transfer, error) {
+	transferOrm, err := b.db.GetBankTransfer(transferUuid)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return dbank.Transfer{}, transferNotFoundError(transferUuid)
+	} else if err != nil {
+		return dbank.Transfer{}, dbank.NewUnavailableError("transfer lookup", err)
+	}
+
+	numbers := map[uuid.UUID]string{}
+
+	fromAcct, err := b.accountNumberOf(numbers, transferOrm.FromAccountUuid)
+
+	if err != nil {
+		return dbank.Transfer{}, err
+	}
+
+	toAcct, err := b.accountNumberOf(numbers, transferOrm.ToAccountUuid)
+
+	if err != nil {
+		return dbank.Transfer{}, err
+	}
+
+	if acct != "" && acct != fromAcct && acct != toAcct {
+		return dbank.Transfer{}, transferNotFoundError(transferUuid)
+	}
+
+	reversalOrms, err := b.db.GetBankTransferReversals(transferUuid)
+
+	if err != nil {
+		return dbank.Transfer{}, dbank.NewUnavailableError("transfer reversal lookup", err)
+	}
+
+	cur, err := dbank.Currencies.Find(transferOrm.Currency)
+
+	if err != nil {
+		return dbank.Transfer{}, err
+	}
+
+	t := toTransfer(transferOrm, fromAcct, toAcct)
+	t.Reversals = make([]dbank.TransferReversal, 0, len(reversalOrms))
+
+	var reversed float64
+
+	for _, r := range reversalOrms {
+		reversed = cur.Round(reversed + r.Amount)
+
+		status := dbank.ReversalStatusPartial
+		if reversed >= transferOrm.Amount {
+			status = dbank.ReversalStatusFull
+		}
+
+		t.Reversals = append(t.Reversals, dbank.TransferReversal{
+			ReversalUuid:          r.ReversalUuid,
+			TransferUuid:          r.TransferUuid,
+			Amount:                r.Amount,
+			Reason:                r.Reason,
+			Currency:              transferOrm.Currency,
+			ReversedAmount:        reversed,
+			RemainingAmount:       cur.Round(transferOrm.Amount - reversed),
+			ReversalStatus:        status,
+			DebitTransactionUuid:  r.DebitTransactionUuid,
+			CreditTransactionUuid: r.CreditTransactionUuid,
+			Timestamp:             r.CreatedAt,
+			Origin:                dbank.Origin{Actor: r.CreatedBy},
+		})
+	}
+
+	if t.Fees, err = b.GetTransferFees(transferUuid); err != nil {
+		return dbank.Transfer{}, err
+	}
+
+	transfers := []dbank.Transfer{t}
+
+	if err := b.withTransactions(transfers, numbers); err != nil {
+		return dbank.Transfer{}, err
+	}
+
+	return transfers[0], nil
+}
+
+// pageToken points after the last row of a page, rows are listed by
+// descending timestamp then uuid
+func pageToken(ts time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%d|%v", ts.UnixNano(), id)))
+}
+
+// parsePageToken reads a token of pageToken, invalid is returned for a token
+// that can't be read
+func parsePageToken(token string, invalid error) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	ts, id, ok := strings.Cut(string(raw), "|")
+
+	if !ok {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	rowUuid, err := uuid.Parse(id)
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	return time.Unix(0, nanos), rowUuid, nil
+}
+
+// accountUuidOf resolves an optional account number of a filter
+func (b *BankService) accountUuidOf(acct string) (uuid.UUID, error) {
+	if acct == "" {
+		return uuid.Nil, nil
+	}
+
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if err != nil {
+		return uuid.Nil, accountLookupError(acct, err, dbank.ErrAccountNotFound)
+	}
+
+	return bankAccountOrm.AccountUuid, nil
+}
+
+// ListTransfers returns a page of transfers matching f, newest first, with
+// the transactions each one posted and the token of the next page. The
+// token is empty on the last page.
+func (b *BankService) ListTransfers(f dbank.TransferFilter) ([]dbank.Transfer, string, error) {
+	q := database.BankTransferQuery{
+		Status:    f.Status,
+		Currency:  f.Currency,
+		MinAmount: f.MinAmount,
+		MaxAmount: f.MaxAmount,
+		From:      f.From,
+		To:        f.To,
+		Limit:     f.Limit,
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = defaultTransferLimit
+	} else if q.Limit > maxTransferLimit {
+		q.Limit = maxTransferLimit
+	}
+
+	if f.PageToken != "" {
+		afterTimestamp, afterUuid, err := parsePageToken(f.PageToken, dbank.ErrTransferInvalidPageToken)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		q.AfterTimestamp = afterTimestamp
+		q.AfterUuid = afterUuid
+	}
+
+	var err error
+
+	if q.AccountUuid, err = b.accountUuidOf(f.AccountNumber); err != nil {
+		return nil, "", err
+	}
+
+	if q.FromAccountUuid, err = b.accountUuidOf(f.FromAccountNumber); err != nil {
+		return nil, "", err
+	}
+
+	if q.ToAccountUuid, err = b.accountUuidOf(f.ToAccountNumber); err != nil {
+		return nil, "", err
+	}
+
+	// one extra row tells whether there is a next page
+	limit := q.Limit
+	q.Limit++
+
+	transferOrms, err := b.db.FindBankTransfers(q)
+
+	if err != nil {
+		return nil, "", dbank.NewUnavailableError("transfer lookup", err)
+	}
+
+	var nextPageToken string
+
+	if len(transferOrms) > limit {
+		transferOrms = transferOrms[:limit]
+		nextPageToken = pageToken(transferOrms[limit-1].TransferTimestamp, transferOrms[limit-1].TransferUuid)
+	}
+
+	numbers := map[uuid.UUID]string{}
+	res := make([]dbank.Transfer, 0, len(transferOrms))
+
+	for _, t := range transferOrms {
+		fromAcct, err := b.accountNumberOf(numbers, t.FromAccountUuid)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		toAcct, err := b.accountNumberOf(numbers, t.ToAccountUuid)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		res = append(res, toTransfer(t, fromAcct, toAcct))
+	}
+
+	if err := b.withTransactions(res, numbers); err != nil {
+		return nil, "", err
+	}
+
+	return res, nextPageToken, nil
+}
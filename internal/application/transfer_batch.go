@@ -0,0 +1,703 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+var transferBatchColumns = []string{"from_account_number", "to_account_number", "currency", "amount", "reference"}
+
+type transferBatchLineJson struct {
+	FromAccountNumber string  `json:"from_account_number"`
+	ToAccountNumber   string  `json:"to_account_number"`
+	Currency          string  `json:"currency"`
+	Amount            float64 `json:"amount"`
+	Reference         string  `json:"reference"`
+}
+
+// ParseTransferBatch reads the transfers of a batch file. A CSV file starts
+// with a header naming the columns from_account_number, to_account_number,
+// currency, amount and optionally reference. A JSON file is an array of
+// objects with the same keys. Lines that can't be read are returned as
+// invalid, only a file that can't be read at all is an error.
+func (b *BankService) ParseTransferBatch(format string, content []byte) ([]dbank.TransferBatchLine, error) {
+	if len(content) > dbank.MaxTransferBatchSize {
+		return nil, fmt.Errorf("%w : more than %v bytes", dbank.ErrTransferBatchTooLarge, dbank.MaxTransferBatchSize)
+	}
+
+	var lines []dbank.TransferBatchLine
+	var err error
+
+	switch format {
+	case dbank.TransferBatchFormatCsv:
+		lines, err = parseTransferBatchCsv(content)
+	case dbank.TransferBatchFormatJson:
+		lines, err = parseTransferBatchJson(content)
+	default:
+		return nil, dbank.ErrTransferBatchUnknownFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, dbank.ErrTransferBatchEmpty
+	}
+
+	if len(lines) > dbank.MaxTransferBatchLines {
+		return nil, fmt.Errorf("%w : more than %v transfers", dbank.ErrTransferBatchTooLarge, dbank.MaxTransferBatchLines)
+	}
+
+	return lines, nil
+}
+
+func parseTransferBatchCsv(content []byte) ([]dbank.TransferBatchLine, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+
+	if err == io.EOF {
+		return nil, dbank.ErrTransferBatchEmpty
+	} else if err != nil {
+		return nil, fmt.Errorf("%w : %v", dbank.ErrTransferBatchMalformed, err)
+	}
+
+	columns := map[string]int{}
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	for _, c := range transferBatchColumns[:4] {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("%w : missing column %v", dbank.ErrTransferBatchMalformed, c)
+		}
+	}
+
+	var lines []dbank.TransferBatchLine
+
+	for {
+		record, err := r.Read()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w : %v", dbank.ErrTransferBatchMalformed, err)
+		}
+
+		lineNumber, _ := r.FieldPos(0)
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		l := dbank.TransferBatchLine{
+			LineNumber:        lineNumber,
+			FromAccountNumber: field("from_account_number"),
+			ToAccountNumber:   field("to_account_number"),
+			Currency:          strings.ToUpper(field("currency")),
+			Reference:         field("reference"),
+			Status:            dbank.TransferBatchLineStatusPending,
+		}
+
+		if amount, err := strconv.ParseFloat(field("amount"), 64); err != nil {
+			l.Status = dbank.TransferBatchLineStatusInvalid
+			l.Error = "amount is not a number"
+		} else {
+			l.Amount = amount
+		}
+
+		lines = append(lines, l)
+	}
+
+	return lines, nil
+}
+
+func parseTransferBatchJson(content []byte) ([]dbank.TransferBatchLine, error) {
+	var records []transferBatchLineJson
+
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("%w : %v", dbank.ErrTransferBatchMalformed, err)
+	}
+
+	lines := make([]dbank.TransferBatchLine, 0, len(records))
+
+	for i, r := range records {
+		lines = append(lines, dbank.TransferBatchLine{
+			LineNumber:        i + 1,
+			FromAccountNumber: strings.TrimSpace(r.FromAccountNumber),
+			ToAccountNumber:   strings.TrimSpace(r.ToAccountNumber),
+			Currency:          strings.ToUpper(strings.TrimSpace(r.Currency)),
+			Amount:            r.Amount,
+			Reference:         strings.TrimSpace(r.Reference),
+			Status:            dbank.TransferBatchLineStatusPending,
+		})
+	}
+
+	return lines, nil
+}
+
+// batchAccounts caches the accounts looked up while validating a batch
+type batchAccounts map[string]*database.BankAccountOrm
+
+func (b *BankService) batchAccount(accounts batchAccounts, acct string) (*database.BankAccountOrm, error) {
+	if a, ok := accounts[acct]; ok {
+		return a, nil
+	}
+
+	bankAccountOrm, err := b.db.GetBankAccountByAccountNumber(acct)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		accounts[acct] = nil
+		return nil, nil
+	} else if err != nil {
+		return nil, dbank.NewUnavailableError("account lookup", err)
+	}
+
+	accounts[acct] = &bankAccountOrm
+
+	return &bankAccountOrm, nil
+}
+
+// validateTransferBatchLine returns why a line can't be transferred, or an
+// empty string when it can
+func (b *BankService) validateTransferBatchLine(accounts batchAccounts, products map[uuid.UUID]dbank.AccountProduct, l *dbank.TransferBatchLine) (string, error) {
+	switch {
+	case l.FromAccountNumber == "":
+		return "from_account_number is required", nil
+	case l.ToAccountNumber == "":
+		return "to_account_number is required", nil
+	case l.FromAccountNumber == l.ToAccountNumber:
+		return "destination account must be different from source account", nil
+	case len(l.Reference) > dbank.MaxTransferBatchReference:
+		return fmt.Sprintf("reference is longer than %v characters", dbank.MaxTransferBatchReference), nil
+	}
+
+	cur, err := dbank.Currencies.FindEnabled(l.Currency)
+
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	l.Amount = cur.Round(l.Amount)
+
+	if !(l.Amount > 0) || math.IsInf(l.Amount, 0) {
+		return "amount must be positive in the minor unit of the currency", nil
+	}
+
+	from, err := b.batchAccount(accounts, l.FromAccountNumber)
+
+	if err != nil {
+		return "", err
+	}
+
+	if from == nil {
+		return dbank.ErrTransferSourceAccountNotFound.Error(), nil
+	}
+
+	to, err := b.batchAccount(accounts, l.ToAccountNumber)
+
+	if err != nil {
+		return "", err
+	}
+
+	if to == nil {
+		return dbank.ErrTransferDestinationAccountNotFound.Error(), nil
+	}
+
+	if from.Currency != cur.Code || to.Currency != cur.Code {
+		return dbank.ErrCurrencyMismatch.Error(), nil
+	}
+
+	if err := b.checkProductTransfer(products, *from, *to, cur.Code); isProductRuleError(err) {
+		return err.Error(), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// validateTransferBatch checks every line before anything is transferred,
+// caching the accounts it looks up in accounts. In all-or-nothing mode the
+// total sent from each account, fees included, must also be available up
+// front, and leave the minimum balance of its product, as a batch that would
+// run dry half way is rolled back. It returns whether the batch may run.
+func (b *BankService) validateTransferBatch(batch *dbank.TransferBatch, accounts batchAccounts, now time.Time) (bool, error) {
+	products := map[uuid.UUID]dbank.AccountProduct{}
+	totals := map[string]float64{}
+	valid := true
+
+	for i := range batch.Lines {
+		l := &batch.Lines[i]
+
+		if l.Status == dbank.TransferBatchLineStatusPending {
+			reason, err := b.validateTransferBatchLine(accounts, products, l)
+
+			if err != nil {
+				return false, err
+			}
+
+			if reason != "" {
+				l.Status = dbank.TransferBatchLineStatusInvalid
+				l.Error = reason
+			}
+		}
+
+		if l.Status == dbank.TransferBatchLineStatusInvalid {
+			valid = false
+			continue
+		}
+
+		if batch.Mode != dbank.TransferBatchModeAllOrNothing {
+			continue
+		}
+
+		cur, err := dbank.Currencies.FindEnabled(l.Currency)
+
+		if err != nil {
+			return false, err
+		}
+
+		fees, err := b.assessFees(*accounts[l.FromAccountNumber], dbank.FeeOperationTransfer, cur, l.Amount, false)
+
+		if err != nil {
+			return false, err
+		}
+
+		totals[l.FromAccountNumber] = cur.Round(totals[l.FromAccountNumber] + l.Amount + fees.total)
+	}
+
+	if batch.Mode != dbank.TransferBatchModeAllOrNothing {
+		return true, nil
+	}
+
+	for acct, total := range totals {
+		available, held, err := b.availableBalance(*accounts[acct], now)
+
+		if err != nil {
+			return false, err
+		}
+
+		var reason string
+
+		if available < total {
+			reason = fmt.Sprintf("batch total %v with fees exceeds available balance %v of %v", total, available, acct)
+		} else if err := b.checkProductMinBalance(products, *accounts[acct], held, total); isProductRuleError(err) {
+			reason = err.Error()
+		} else if err != nil {
+			return false, err
+		}
+
+		if reason == "" {
+			continue
+		}
+
+		valid = false
+
+		for i := range batch.Lines {
+			l := &batch.Lines[i]
+			if l.FromAccountNumber == acct && l.Status == dbank.TransferBatchLineStatusPending {
+				l.Status = dbank.TransferBatchLineStatusInvalid
+				l.Error = reason
+			}
+		}
+	}
+
+	return valid, nil
+}
+
+func toTransferBatchOrms(batch dbank.TransferBatch) (database.TransferBatchOrm, []database.TransferBatchLineOrm) {
+	batchOrm := database.TransferBatchOrm{
+		BatchUuid:      batch.BatchUuid,
+		FileName:       batch.FileName,
+		Format:         batch.Format,
+		Mode:           batch.Mode,
+		Status:         batch.Status,
+		LineCount:      len(batch.Lines),
+		SucceededCount: batch.SucceededCount,
+		FailedCount:    batch.FailedCount,
+		CreatedBy:      batch.CreatedBy,
+		CreatedAt:      batch.CreatedAt,
+		UpdatedAt:      batch.CreatedAt,
+	}
+
+	if !batch.CompletedAt.IsZero() {
+		batchOrm.CompletedAt = &batch.CompletedAt
+	}
+
+	lineOrms := make([]database.TransferBatchLineOrm, 0, len(batch.Lines))
+
+	for _, l := range batch.Lines {
+		lineOrms = append(lineOrms, toTransferBatchLineOrm(batch.BatchUuid, l))
+	}
+
+	return batchOrm, lineOrms
+}
+
+func toTransferBatchLineOrm(batchUuid uuid.UUID, l dbank.TransferBatchLine) database.TransferBatchLineOrm {
+	lineOrm := database.TransferBatchLineOrm{
+		BatchUuid:         batchUuid,
+		LineNumber:        l.LineNumber,
+		FromAccountNumber: l.FromAccountNumber,
+		ToAccountNumber:   l.ToAccountNumber,
+		Currency:          l.Currency,
+		Amount:            l.Amount,
+		Reference:         l.Reference,
+		Status:            l.Status,
+		Error:             l.Error,
+		UpdatedAt:         time.Now(),
+	}
+
+	if l.TransferUuid != uuid.Nil {
+		lineOrm.TransferUuid = &l.TransferUuid
+	}
+
+	return lineOrm
+}
+
+// SubmitTransferBatch validates a batch, stores it and runs its transfers
+// one by one in file order. A batch with an invalid line is rejected as a
+// whole in all-or-nothing mode, which runs in a single database transaction,
+// best-effort mode skips such lines. The returned batch is the report of
+// every line.
+func (b *BankService) SubmitTransferBatch(batch dbank.TransferBatch) (dbank.TransferBatch, error) {
+	res, err := b.submitTransferBatch(batch)
+
+	var total float64
+	for _, l := range res.Lines {
+		if l.Status == dbank.TransferBatchLineStatusSuccess {
+			total += l.Amount
+		}
+	}
+
+	b.recordAudit(batch.Origin, dbank.AuditActionSubmitBatch, "", total, "",
+		fmt.Sprintf("batch %v %v %v lines %v succeeded %v", res.BatchUuid, batch.FileName, res.Status, len(res.Lines), res.SucceededCount), err)
+
+	return res, err
+}
+
+func (b *BankService) submitTransferBatch(batch dbank.TransferBatch) (dbank.TransferBatch, error) {
+	switch batch.Mode {
+	case dbank.TransferBatchModeAllOrNothing, dbank.TransferBatchModeBestEffort:
+	default:
+		return dbank.TransferBatch{}, dbank.ErrTransferBatchUnknownMode
+	}
+
+	if len(batch.Lines) == 0 {
+		return dbank.TransferBatch{}, dbank.ErrTransferBatchEmpty
+	}
+
+	now := time.Now()
+
+	batch.BatchUuid = uuid.New()
+	batch.CreatedBy = batch.Origin.Actor
+	batch.CreatedAt = now
+	batch.Status = dbank.TransferBatchStatusProcessing
+
+	accounts := batchAccounts{}
+	valid, err := b.validateTransferBatch(&batch, accounts, now)
+
+	if err != nil {
+		return dbank.TransferBatch{}, err
+	}
+
+	if !valid && batch.Mode == dbank.TransferBatchModeAllOrNothing {
+		batch.Status = dbank.TransferBatchStatusRejected
+		batch.CompletedAt = now
+
+		for i := range batch.Lines {
+			if batch.Lines[i].Status == dbank.TransferBatchLineStatusPending {
+				batch.Lines[i].Status = dbank.TransferBatchLineStatusSkipped
+			}
+		}
+	}
+
+	batch.FailedCount = 0
+	for _, l := range batch.Lines {
+		if l.Status == dbank.TransferBatchLineStatusInvalid {
+			batch.FailedCount++
+		}
+	}
+
+	batchOrm, lineOrms := toTransferBatchOrms(batch)
+
+	if err := b.db.CreateTransferBatch(batchOrm, lineOrms); err != nil {
+		return dbank.TransferBatch{}, dbank.NewUnavailableError("transfer batch creation", err)
+	}
+
+	if batch.Status == dbank.TransferBatchStatusRejected {
+		return batch, nil
+	}
+
+	b.runTransferBatch(&batch, accounts)
+
+	batchOrm, _ = toTransferBatchOrms(batch)
+
+	if err := b.db.UpdateTransferBatch(batchOrm); err != nil {
+		log.Printf("Can't update transfer batch %v : %v\n", batch.BatchUuid, err)
+		return batch, dbank.NewUnavailableError("transfer batch update", err)
+	}
+
+	return batch, nil
+}
+
+// updateTransferBatchLine records the outcome of a line as soon as it is
+// known, so that the report survives a crash half way through the batch
+func (b *BankService) updateTransferBatchLine(batch *dbank.TransferBatch, l dbank.TransferBatchLine) {
+	if err := b.db.UpdateTransferBatchLine(toTransferBatchLineOrm(batch.BatchUuid, l)); err != nil {
+		log.Printf("Can't update line %v of transfer batch %v : %v\n", l.LineNumber, batch.BatchUuid, err)
+	}
+}
+
+// runTransferBatchLine transfers a pending line with transfer and records
+// the outcome on the line and in the counts of the batch
+func runTransferBatchLine(batch *dbank.TransferBatch, l *dbank.TransferBatchLine, transfer func(tt dbank.TrasferTransaction) (uuid.UUID, bool, error)) (dbank.TrasferTransaction, error) {
+	tt := dbank.TrasferTransaction{
+		FromAccountNumber: l.FromAccountNumber,
+		ToAccountNumber:   l.ToAccountNumber,
+		Currency:          l.Currency,
+		Amount:            l.Amount,
+		Origin:            batch.Origin,
+	}
+
+	transferUuid, ok, err := transfer(tt)
+
+	l.TransferUuid = transferUuid
+
+	if ok {
+		l.Status = dbank.TransferBatchLineStatusSuccess
+		batch.SucceededCount++
+	} else {
+		l.Status = dbank.TransferBatchLineStatusFailed
+		if err != nil {
+			l.Error = err.Error()
+		}
+		batch.FailedCount++
+	}
+
+	return tt, err
+}
+
+func (b *BankService) runTransferBatch(batch *dbank.TransferBatch, accounts batchAccounts) {
+	if batch.Mode == dbank.TransferBatchModeAllOrNothing {
+		b.runAllOrNothingTransferBatch(batch, accounts)
+	} else {
+		for i := range batch.Lines {
+			l := &batch.Lines[i]
+
+			if l.Status != dbank.TransferBatchLineStatusPending {
+				continue
+			}
+
+			runTransferBatchLine(batch, l, b.Transfer)
+			b.updateTransferBatchLine(batch, *l)
+		}
+	}
+
+	batch.CompletedAt = time.Now()
+
+	switch {
+	case batch.Status != dbank.TransferBatchStatusProcessing:
+	case batch.FailedCount == 0:
+		batch.Status = dbank.TransferBatchStatusCompleted
+	case batch.SucceededCount == 0:
+		batch.Status = dbank.TransferBatchStatusFailed
+	default:
+		batch.Status = dbank.TransferBatchStatusPartiallyCompleted
+	}
+}
+
+// errTransferBatchLineFailed rolls back an all-or-nothing batch once one of
+// its transfers failed
+var errTransferBatchLineFailed = errors.New("transfer batch line failed")
+
+type transferBatchAttempt struct {
+	tt           dbank.TrasferTransaction
+	transferUuid uuid.UUID
+	err          error
+}
+
+// runAllOrNothingTransferBatch runs the transfers of the batch in file order
+// in one database transaction that locks every account of the batch first.
+// The first failure rolls back every transfer of the batch, none of the
+// money moved by the batch can be spent before it is committed. The lines
+// and the audit events of the transfers are recorded once the transaction is
+// over, as the audit chain is locked until its transaction ends.
+func (b *BankService) runAllOrNothingTransferBatch(batch *dbank.TransferBatch, accounts batchAccounts) {
+	var attempts []transferBatchAttempt
+
+	err := b.inTransaction(func(tb *BankService) error {
+		if _, err := tb.lockAccounts(transferBatchAccountOrms(batch, accounts)...); err != nil {
+			return err
+		}
+
+		for i := range batch.Lines {
+			l := &batch.Lines[i]
+
+			if l.Status != dbank.TransferBatchLineStatusPending {
+				continue
+			}
+
+			tt, err := runTransferBatchLine(batch, l, tb.transfer)
+			attempts = append(attempts, transferBatchAttempt{tt: tt, transferUuid: l.TransferUuid, err: err})
+
+			if l.Status == dbank.TransferBatchLineStatusFailed {
+				return errTransferBatchLineFailed
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		batch.Status = dbank.TransferBatchStatusRolledBack
+
+		if !errors.Is(err, errTransferBatchLineFailed) {
+			log.Printf("Can't run transfer batch %v : %v\n", batch.BatchUuid, err)
+			batch.Status = dbank.TransferBatchStatusFailed
+		}
+
+		rolledBack := fmt.Errorf("rolled back with transfer batch %v", batch.BatchUuid)
+
+		for i := range attempts {
+			if attempts[i].err == nil {
+				attempts[i].err = rolledBack
+			}
+		}
+	}
+
+	for _, a := range attempts {
+		b.recordAudit(a.tt.Origin, dbank.AuditActionTransfer, a.tt.FromAccountNumber, a.tt.Amount, a.tt.Currency,
+			fmt.Sprintf("transfer %v to %v", a.transferUuid, a.tt.ToAccountNumber), a.err)
+	}
+
+	for i := range batch.Lines {
+		l := &batch.Lines[i]
+
+		if l.Status == dbank.TransferBatchLineStatusInvalid {
+			continue
+		}
+
+		if err != nil {
+			// the transfers of the batch are gone with its transaction
+			l.TransferUuid = uuid.Nil
+
+			switch l.Status {
+			case dbank.TransferBatchLineStatusSuccess:
+				l.Status = dbank.TransferBatchLineStatusRolledBack
+				batch.SucceededCount--
+			case dbank.TransferBatchLineStatusPending:
+				l.Status = dbank.TransferBatchLineStatusSkipped
+			}
+
+			if l.Error == "" && !errors.Is(err, errTransferBatchLineFailed) {
+				l.Error = err.Error()
+			}
+		}
+
+		b.updateTransferBatchLine(batch, *l)
+	}
+}
+
+// transferBatchAccountOrms returns the accounts the pending lines of the
+// batch send from or to, each once
+func transferBatchAccountOrms(batch *dbank.TransferBatch, accounts batchAccounts) []database.BankAccountOrm {
+	seen := map[string]bool{}
+	var res []database.BankAccountOrm
+
+	for _, l := range batch.Lines {
+		if l.Status != dbank.TransferBatchLineStatusPending {
+			continue
+		}
+
+		for _, acct := range []string{l.FromAccountNumber, l.ToAccountNumber} {
+			if a := accounts[acct]; a != nil && !seen[acct] {
+				seen[acct] = true
+				res = append(res, *a)
+			}
+		}
+	}
+
+	return res
+}
+
+func transferBatchNotFoundError(batchUuid uuid.UUID) error {
+	return &dbank.NotFoundError{
+		Reason:   dbank.ReasonTransferBatchNotFound,
+		Resource: dbank.ResourceTransferBatch,
+		Key:      batchUuid.String(),
+		Err:      dbank.ErrTransferBatchNotFound,
+	}
+}
+
+// GetTransferBatch returns a batch with the outcome of every line
+func (b *BankService) GetTransferBatch(batchUuid uuid.UUID) (dbank.TransferBatch, error) {
+	batchOrm, err := b.db.GetTransferBatch(batchUuid)
+
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return dbank.TransferBatch{}, transferBatchNotFoundError(batchUuid)
+	} else if err != nil {
+		return dbank.TransferBatch{}, dbank.NewUnavailableError("transfer batch lookup", err)
+	}
+
+	lineOrms, err := b.db.GetTransferBatchLines(batchUuid)
+
+	if err != nil {
+		return dbank.TransferBatch{}, dbank.NewUnavailableError("transfer batch line lookup", err)
+	}
+
+	batch := dbank.TransferBatch{
+		BatchUuid:      batchOrm.BatchUuid,
+		FileName:       batchOrm.FileName,
+		Format:         batchOrm.Format,
+		Mode:           batchOrm.Mode,
+		Status:         batchOrm.Status,
+		SucceededCount: batchOrm.SucceededCount,
+		FailedCount:    batchOrm.FailedCount,
+		CreatedBy:      batchOrm.CreatedBy,
+		CreatedAt:      batchOrm.CreatedAt,
+		CompletedAt:    timeOrZero(batchOrm.CompletedAt),
+		Lines:          make([]dbank.TransferBatchLine, 0, len(lineOrms)),
+	}
+
+	for _, l := range lineOrms {
+		line := dbank.TransferBatchLine{
+			LineNumber:        l.LineNumber,
+			FromAccountNumber: l.FromAccountNumber,
+			ToAccountNumber:   l.ToAccountNumber,
+			Currency:          l.Currency,
+			Amount:            l.Amount,
+			Reference:         l.Reference,
+			Status:            l.Status,
+			Error:             l.Error,
+		}
+
+		if l.TransferUuid != nil {
+			line.TransferUuid = *l.TransferUuid
+		}
+
+		batch.Lines = append(batch.Lines, line)
+	}
+
+	return batch, nil
+}
@@ -0,0 +1,128 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+type transferBatchReportLineJson struct {
+	LineNumber        int     `json:"line_number"`
+	FromAccountNumber string  `json:"from_account_number"`
+	ToAccountNumber   string  `json:"to_account_number"`
+	Currency          string  `json:"currency"`
+	Amount            float64 `json:"amount"`
+	Reference         string  `json:"reference"`
+	Status            string  `json:"status"`
+	TransferUuid      string  `json:"transfer_uuid,omitempty"`
+	Error             string  `json:"error,omitempty"`
+}
+
+type transferBatchReportJson struct {
+	BatchUuid      string                        `json:"batch_uuid"`
+	FileName       string                        `json:"file_name"`
+	Mode           string                        `json:"mode"`
+	Status         string                        `json:"status"`
+	LineCount      int                           `json:"line_count"`
+	SucceededCount int                           `json:"succeeded_count"`
+	FailedCount    int                           `json:"failed_count"`
+	CreatedBy      string                        `json:"created_by"`
+	CreatedAt      string                        `json:"created_at"`
+	CompletedAt    string                        `json:"completed_at,omitempty"`
+	Lines          []transferBatchReportLineJson `json:"lines"`
+}
+
+// ExportTransferBatchReport returns the outcome of every line of a batch as
+// a CSV or JSON file
+func (b *BankService) ExportTransferBatchReport(batch dbank.TransferBatch, format string) ([]byte, error) {
+	switch format {
+	case dbank.TransferBatchFormatCsv:
+		return exportTransferBatchCsv(batch)
+	case dbank.TransferBatchFormatJson:
+		return exportTransferBatchJson(batch)
+	default:
+		return nil, dbank.ErrTransferBatchUnknownFormat
+	}
+}
+
+func optionalUuid(u uuid.UUID) string {
+	if u == uuid.Nil {
+		return ""
+	}
+
+	return u.String()
+}
+
+func optionalTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func exportTransferBatchCsv(batch dbank.TransferBatch) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{
+		{"line_number", "from_account_number", "to_account_number", "currency", "amount", "reference", "status", "transfer_uuid", "error"},
+	}
+
+	for _, l := range batch.Lines {
+		records = append(records, []string{
+			strconv.Itoa(l.LineNumber),
+			l.FromAccountNumber,
+			l.ToAccountNumber,
+			l.Currency,
+			formatAmount(l.Amount),
+			l.Reference,
+			l.Status,
+			optionalUuid(l.TransferUuid),
+			l.Error,
+		})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func exportTransferBatchJson(batch dbank.TransferBatch) ([]byte, error) {
+	res := transferBatchReportJson{
+		BatchUuid:      batch.BatchUuid.String(),
+		FileName:       batch.FileName,
+		Mode:           batch.Mode,
+		Status:         batch.Status,
+		LineCount:      len(batch.Lines),
+		SucceededCount: batch.SucceededCount,
+		FailedCount:    batch.FailedCount,
+		CreatedBy:      batch.CreatedBy,
+		CreatedAt:      optionalTimestamp(batch.CreatedAt),
+		CompletedAt:    optionalTimestamp(batch.CompletedAt),
+		Lines:          make([]transferBatchReportLineJson, 0, len(batch.Lines)),
+	}
+
+	for _, l := range batch.Lines {
+		res.Lines = append(res.Lines, transferBatchReportLineJson{
+			LineNumber:        l.LineNumber,
+			FromAccountNumber: l.FromAccountNumber,
+			ToAccountNumber:   l.ToAccountNumber,
+			Currency:          l.Currency,
+			Amount:            l.Amount,
+			Reference:         l.Reference,
+			Status:            l.Status,
+			TransferUuid:      optionalUuid(l.TransferUuid),
+			Error:             l.Error,
+		})
+	}
+
+	return json.MarshalIndent(res, "", "  ")
+}
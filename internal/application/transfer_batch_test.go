@@ -0,0 +1,216 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// batchDb serves accounts by number, their products and fee rules and the
+// amount held on every account, any other call panics
+type batchDb struct {
+	*feeDb
+	accounts map[string]database.BankAccountOrm
+	products map[uuid.UUID]database.BankAccountProductOrm
+	held     float64
+}
+
+func (d *batchDb) GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error) {
+	a, ok := d.accounts[acct]
+	if !ok {
+		return database.BankAccountOrm{}, database.ErrRecordNotFound
+	}
+
+	return a, nil
+}
+
+func (d *batchDb) GetBankHoldsActiveAmount(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	return d.held, nil
+}
+
+func (d *batchDb) GetBankAccountProduct(productUuid uuid.UUID) (database.BankAccountProductOrm, error) {
+	return d.products[productUuid], nil
+}
+
+func (d *batchDb) GetBankAccountProductCurrencies(productUuid uuid.UUID) ([]database.BankAccountProductCurrencyOrm, error) {
+	return []database.BankAccountProductCurrencyOrm{{ProductUuid: productUuid, Currency: "USD"}}, nil
+}
+
+func (d *batchDb) GetBankAccountProductInterestTiers(productUuid uuid.UUID) ([]database.BankAccountProductInterestTierOrm, error) {
+	return nil, nil
+}
+
+func TestParseTransferBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		want    []dbank.TransferBatchLine
+		wantErr error
+	}{
+		{"csv", dbank.TransferBatchFormatCsv,
+			"from_account_number,to_account_number,currency,amount,reference\n" +
+				"7835697001,7835697002,usd,10.5,Rent\n" +
+				"7835697001, 7835697003 ,EUR,abc,\n",
+			[]dbank.TransferBatchLine{
+				{LineNumber: 2, FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: 10.5, Reference: "Rent", Status: dbank.TransferBatchLineStatusPending},
+				{LineNumber: 3, FromAccountNumber: "7835697001", ToAccountNumber: "7835697003", Currency: "EUR", Status: dbank.TransferBatchLineStatusInvalid, Error: "amount is not a number"},
+			}, nil},
+		{"csv columns in any order without reference", dbank.TransferBatchFormatCsv,
+			"Amount, Currency, To_Account_Number, From_Account_Number\n" +
+				"20,USD,7835697002,7835697001\n",
+			[]dbank.TransferBatchLine{
+				{LineNumber: 2, FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: 20, Status: dbank.TransferBatchLineStatusPending},
+			}, nil},
+		{"csv short record", dbank.TransferBatchFormatCsv,
+			"from_account_number,to_account_number,currency,amount\n" +
+				"7835697001,7835697002\n",
+			[]dbank.TransferBatchLine{
+				{LineNumber: 2, FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Status: dbank.TransferBatchLineStatusInvalid, Error: "amount is not a number"},
+			}, nil},
+		{"csv line numbers count quoted new lines", dbank.TransferBatchFormatCsv,
+			"from_account_number,to_account_number,currency,amount,reference\n" +
+				"7835697001,7835697002,USD,1,\"two\nlines\"\n" +
+				"7835697001,7835697002,USD,2,\n",
+			[]dbank.TransferBatchLine{
+				{LineNumber: 2, FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: 1, Reference: "two\nlines", Status: dbank.TransferBatchLineStatusPending},
+				{LineNumber: 4, FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: 2, Status: dbank.TransferBatchLineStatusPending},
+			}, nil},
+		{"csv missing column", dbank.TransferBatchFormatCsv, "from_account_number,to_account_number,amount\n1,2,3\n", nil, dbank.ErrTransferBatchMalformed},
+		{"csv bad quoting", dbank.TransferBatchFormatCsv, "from_account_number,to_account_number,currency,amount\n1,2,\"USD,3\n", nil, dbank.ErrTransferBatchMalformed},
+		{"csv header only", dbank.TransferBatchFormatCsv, "from_account_number,to_account_number,currency,amount\n", nil, dbank.ErrTransferBatchEmpty},
+		{"csv empty", dbank.TransferBatchFormatCsv, "", nil, dbank.ErrTransferBatchEmpty},
+		{"json", dbank.TransferBatchFormatJson,
+			`[{"from_account_number":" 7835697001","to_account_number":"7835697002","currency":"usd","amount":10.5,"reference":" Rent "},
+			  {"from_account_number":"7835697001","to_account_number":"7835697003","currency":"EUR","amount":1}]`,
+			[]dbank.TransferBatchLine{
+				{LineNumber: 1, FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: 10.5, Reference: "Rent", Status: dbank.TransferBatchLineStatusPending},
+				{LineNumber: 2, FromAccountNumber: "7835697001", ToAccountNumber: "7835697003", Currency: "EUR", Amount: 1, Status: dbank.TransferBatchLineStatusPending},
+			}, nil},
+		{"json amount as string", dbank.TransferBatchFormatJson, `[{"amount":"10"}]`, nil, dbank.ErrTransferBatchMalformed},
+		{"json object", dbank.TransferBatchFormatJson, `{"amount":10}`, nil, dbank.ErrTransferBatchMalformed},
+		{"json empty array", dbank.TransferBatchFormatJson, `[]`, nil, dbank.ErrTransferBatchEmpty},
+		{"unknown format", "XML", "<batch/>", nil, dbank.ErrTransferBatchUnknownFormat},
+		{"too many lines", dbank.TransferBatchFormatCsv,
+			"from_account_number,to_account_number,currency,amount\n" +
+				strings.Repeat("1,2,USD,1\n", dbank.MaxTransferBatchLines+1),
+			nil, dbank.ErrTransferBatchTooLarge},
+	}
+
+	b := NewBankService(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := b.ParseTransferBatch(tt.format, []byte(tt.content))
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("ParseTransferBatch() = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(lines) != len(tt.want) {
+				t.Fatalf("ParseTransferBatch() = %v lines, want %v", len(lines), len(tt.want))
+			}
+
+			for i := range lines {
+				if lines[i] != tt.want[i] {
+					t.Errorf("line %v = %+v, want %+v", i, lines[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTransferBatch(t *testing.T) {
+	productUuid := uuid.New()
+
+	accounts := map[string]database.BankAccountOrm{
+		"7835697001": {AccountUuid: uuid.New(), AccountNumber: "7835697001", Currency: "USD", CurrentBalance: 100, ProductUuid: &productUuid},
+		"7835697002": {AccountUuid: uuid.New(), AccountNumber: "7835697002", Currency: "USD"},
+		"7835697003": {AccountUuid: uuid.New(), AccountNumber: "7835697003", Currency: "EUR"},
+	}
+
+	line := func(from string, to string, cur string, amount float64) dbank.TransferBatchLine {
+		return dbank.TransferBatchLine{FromAccountNumber: from, ToAccountNumber: to, Currency: cur, Amount: amount, Status: dbank.TransferBatchLineStatusPending}
+	}
+
+	tests := []struct {
+		name       string
+		mode       string
+		lines      []dbank.TransferBatchLine
+		want       bool
+		wantStatus []string
+	}{
+		{"valid", dbank.TransferBatchModeAllOrNothing, []dbank.TransferBatchLine{
+			line("7835697001", "7835697002", "USD", 40),
+			line("7835697001", "7835697002", "USD", 50),
+		}, true, []string{dbank.TransferBatchLineStatusPending, dbank.TransferBatchLineStatusPending}},
+		{"line errors", dbank.TransferBatchModeAllOrNothing, []dbank.TransferBatchLine{
+			line("7835697001", "7835697001", "USD", 10),
+			line("7835697001", "7835697002", "KWD", 10),
+			line("7835697001", "7835697002", "USD", 0.001),
+			line("7835697001", "7835697999", "USD", 10),
+			line("7835697001", "7835697003", "EUR", 10),
+			line("7835697001", "7835697002", "USD", 10),
+		}, false, []string{
+			dbank.TransferBatchLineStatusInvalid,
+			dbank.TransferBatchLineStatusInvalid,
+			dbank.TransferBatchLineStatusInvalid,
+			dbank.TransferBatchLineStatusInvalid,
+			dbank.TransferBatchLineStatusInvalid,
+			dbank.TransferBatchLineStatusPending,
+		}},
+		{"best effort runs despite invalid lines", dbank.TransferBatchModeBestEffort, []dbank.TransferBatchLine{
+			line("7835697001", "", "USD", 10),
+			line("7835697001", "7835697002", "USD", 10),
+		}, true, []string{dbank.TransferBatchLineStatusInvalid, dbank.TransferBatchLineStatusPending}},
+		// 45 + 50 is within the balance but not with the fee of each line
+		{"total with fees beyond the balance", dbank.TransferBatchModeAllOrNothing, []dbank.TransferBatchLine{
+			line("7835697001", "7835697002", "USD", 45),
+			line("7835697001", "7835697002", "USD", 50),
+		}, false, []string{dbank.TransferBatchLineStatusInvalid, dbank.TransferBatchLineStatusInvalid}},
+		{"best effort does not check the total", dbank.TransferBatchModeBestEffort, []dbank.TransferBatchLine{
+			line("7835697001", "7835697002", "USD", 45),
+			line("7835697001", "7835697002", "USD", 50),
+		}, true, []string{dbank.TransferBatchLineStatusPending, dbank.TransferBatchLineStatusPending}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &batchDb{
+				feeDb: &feeDb{
+					revenue: database.BankAccountOrm{AccountUuid: uuid.New()},
+					rules: []database.BankFeeRuleOrm{
+						{FeeRuleUuid: uuid.New(), ProductUuid: &productUuid, FeeName: "Transfer fee", Operation: dbank.FeeOperationTransfer, FeeType: dbank.FeeTypeFlat, FlatAmount: 3},
+					},
+				},
+				accounts: accounts,
+				products: map[uuid.UUID]database.BankAccountProductOrm{
+					productUuid: {ProductUuid: productUuid, ProductCode: "CURRENT", TransfersOutAllowed: true},
+				},
+			}
+			b := NewBankService(db)
+			batch := &dbank.TransferBatch{Mode: tt.mode, Lines: tt.lines}
+
+			got, err := b.validateTransferBatch(batch, batchAccounts{}, time.Now())
+
+			if err != nil || got != tt.want {
+				t.Fatalf("validateTransferBatch() = %v, %v, want %v", got, err, tt.want)
+			}
+
+			for i, l := range batch.Lines {
+				if l.Status != tt.wantStatus[i] {
+					t.Errorf("line %v = %v %q, want %v", i, l.Status, l.Error, tt.wantStatus[i])
+				}
+
+				if l.Status == dbank.TransferBatchLineStatusInvalid && l.Error == "" {
+					t.Errorf("line %v is invalid without a reason", i)
+				}
+			}
+		})
+	}
+}
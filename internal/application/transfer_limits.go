@@ -0,0 +1,123 @@
+package application
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+)
+
+func toTransferLimit(l database.BankTransferLimitOrm, acct string) dbank.TransferLimit {
+	limit := dbank.TransferLimit{
+		Name:                 l.LimitName,
+		MaxAmountPerTransfer: l.MaxAmountPerTransfer,
+		MaxDailyAmount:       l.MaxDailyAmount,
+		MaxMonthlyAmount:     l.MaxMonthlyAmount,
+		MaxTransfersPerHour:  l.MaxTransfersPerHour,
+	}
+
+	if l.AccountUuid != nil {
+		limit.AccountNumber = acct
+	}
+
+	if l.Currency != nil {
+		limit.Currency = *l.Currency
+	}
+
+	return limit
+}
+
+// checkTransferLimits evaluates every limit that applies to a transfer out of
+// the account. Daily and monthly windows start at midnight UTC, the hourly
+// window is the last sixty minutes.
+func (b *BankService) checkTransferLimits(fromAccountOrm database.BankAccountOrm, currency string, amount float64, now time.Time) error {
+	limitOrms, err := b.db.GetBankTransferLimits(fromAccountOrm.AccountUuid, fromAccountOrm.ProductUuid, currency)
+
+	if err != nil {
+		return dbank.NewUnavailableError("transfer limit lookup", err)
+	}
+
+	utc := now.UTC()
+	dayStart := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC)
+	hourStart := now.Add(-time.Hour)
+
+	totals := map[time.Time]database.BankTransferTotalsOrm{}
+
+	totalsSince := func(since time.Time) (database.BankTransferTotalsOrm, error) {
+		if t, ok := totals[since]; ok {
+			return t, nil
+		}
+
+		t, err := b.db.GetBankTransferTotalsSince(fromAccountOrm.AccountUuid, since)
+
+		if err != nil {
+			return t, dbank.NewUnavailableError("transfer totals", err)
+		}
+
+		totals[since] = t
+
+		return t, nil
+	}
+
+	for _, l := range limitOrms {
+		limit := toTransferLimit(l, fromAccountOrm.AccountNumber)
+
+		violation := &dbank.TransferLimitError{
+			AccountNumber: fromAccountOrm.AccountNumber,
+			Limit:         limit.Name,
+			Requested:     amount,
+		}
+
+		if limit.MaxAmountPerTransfer > 0 && amount > limit.MaxAmountPerTransfer {
+			violation.Rule = dbank.TransferRuleMaxAmountPerTransfer
+			violation.Maximum = limit.MaxAmountPerTransfer
+			return violation
+		}
+
+		if limit.MaxDailyAmount > 0 {
+			t, err := totalsSince(dayStart)
+			if err != nil {
+				return err
+			}
+
+			if t.Amount+amount > limit.MaxDailyAmount {
+				violation.Rule = dbank.TransferRuleMaxDailyAmount
+				violation.Maximum = limit.MaxDailyAmount
+				violation.Current = t.Amount
+				return violation
+			}
+		}
+
+		if limit.MaxMonthlyAmount > 0 {
+			t, err := totalsSince(monthStart)
+			if err != nil {
+				return err
+			}
+
+			if t.Amount+amount > limit.MaxMonthlyAmount {
+				violation.Rule = dbank.TransferRuleMaxMonthlyAmount
+				violation.Maximum = limit.MaxMonthlyAmount
+				violation.Current = t.Amount
+				return violation
+			}
+		}
+
+		if limit.MaxTransfersPerHour > 0 {
+			t, err := totalsSince(hourStart)
+			if err != nil {
+				return err
+			}
+
+			if t.Count+1 > limit.MaxTransfersPerHour {
+				violation.Rule = dbank.TransferRuleMaxTransfersPerHour
+				violation.Maximum = float64(limit.MaxTransfersPerHour)
+				violation.Current = float64(t.Count)
+				violation.Requested = 1
+				return violation
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// limitsDb serves the limits, transfer totals, holds and products the debit
+// checks read, any other call panics
+type limitsDb struct {
+	port.BankDatabasePort
+	limits  []database.BankTransferLimitOrm
+	totals  map[time.Time]database.BankTransferTotalsOrm
+	held    float64
+	product database.BankAccountProductOrm
+}
+
+func (d *limitsDb) GetBankTransferLimits(accountUuid uuid.UUID, productUuid *uuid.UUID, currency string) ([]database.BankTransferLimitOrm, error) {
+	return d.limits, nil
+}
+
+func (d *limitsDb) GetBankTransferTotalsSince(fromAccountUuid uuid.UUID, since time.Time) (database.BankTransferTotalsOrm, error) {
+	return d.totals[since], nil
+}
+
+func (d *limitsDb) GetBankHoldsActiveAmount(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	return d.held, nil
+}
+
+func (d *limitsDb) GetBankAccountProduct(productUuid uuid.UUID) (database.BankAccountProductOrm, error) {
+	return d.product, nil
+}
+
+func (d *limitsDb) GetBankAccountProductCurrencies(productUuid uuid.UUID) ([]database.BankAccountProductCurrencyOrm, error) {
+	return nil, nil
+}
+
+func (d *limitsDb) GetBankAccountProductInterestTiers(productUuid uuid.UUID) ([]database.BankAccountProductInterestTierOrm, error) {
+	return nil, nil
+}
+
+func TestCheckTransferLimits(t *testing.T) {
+	now := time.Date(2024, 3, 14, 15, 30, 0, 0, time.UTC)
+	dayStart := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	hourStart := now.Add(-time.Hour)
+
+	totals := map[time.Time]database.BankTransferTotalsOrm{
+		dayStart:   {Amount: 400, Count: 3},
+		monthStart: {Amount: 4000, Count: 20},
+		hourStart:  {Amount: 100, Count: 2},
+	}
+
+	tests := []struct {
+		name     string
+		limit    database.BankTransferLimitOrm
+		amount   float64
+		wantRule string
+	}{
+		{"no limit", database.BankTransferLimitOrm{}, 1e9, ""},
+		{"per transfer within", database.BankTransferLimitOrm{MaxAmountPerTransfer: 500}, 500, ""},
+		{"per transfer over", database.BankTransferLimitOrm{MaxAmountPerTransfer: 500}, 500.01, dbank.TransferRuleMaxAmountPerTransfer},
+		{"daily within", database.BankTransferLimitOrm{MaxDailyAmount: 500}, 100, ""},
+		{"daily over", database.BankTransferLimitOrm{MaxDailyAmount: 500}, 100.01, dbank.TransferRuleMaxDailyAmount},
+		{"monthly over", database.BankTransferLimitOrm{MaxMonthlyAmount: 4050}, 60, dbank.TransferRuleMaxMonthlyAmount},
+		{"hourly count within", database.BankTransferLimitOrm{MaxTransfersPerHour: 3}, 1, ""},
+		{"hourly count over", database.BankTransferLimitOrm{MaxTransfersPerHour: 2}, 1, dbank.TransferRuleMaxTransfersPerHour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.limit.LimitName = tt.name
+			b := NewBankService(&limitsDb{limits: []database.BankTransferLimitOrm{tt.limit}, totals: totals})
+
+			err := b.checkTransferLimits(database.BankAccountOrm{AccountNumber: "7835697001"}, "USD", tt.amount, now)
+
+			if tt.wantRule == "" {
+				if err != nil {
+					t.Errorf("checkTransferLimits() = %v, want nil", err)
+				}
+				return
+			}
+
+			var violation *dbank.TransferLimitError
+			if !errors.As(err, &violation) || violation.Rule != tt.wantRule || violation.Limit != tt.name {
+				t.Errorf("checkTransferLimits() = %v, want %v of %q", err, tt.wantRule, tt.name)
+			}
+		})
+	}
+}
+
+func TestCheckDebit(t *testing.T) {
+	productUuid := uuid.New()
+
+	tests := []struct {
+		name      string
+		balance   float64
+		overdraft float64
+		held      float64
+		product   *uuid.UUID
+		debit     float64
+		wantErr   error
+	}{
+		{"covered by balance", 100, 0, 0, nil, 100, nil},
+		{"covered by overdraft", 100, 50, 0, nil, 150, nil},
+		{"beyond overdraft", 100, 50, 0, nil, 150.01, dbank.ErrTransferTransactionPair},
+		{"reserved by holds", 100, 0, 30, nil, 80, dbank.ErrTransferTransactionPair},
+		{"keeps product minimum", 100, 0, 0, &productUuid, 75, nil},
+		{"breaks product minimum", 100, 0, 0, &productUuid, 75.01, dbank.ErrProductMinBalance},
+		{"holds count against product minimum", 100, 0, 10, &productUuid, 70, dbank.ErrProductMinBalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBankService(&limitsDb{
+				held:    tt.held,
+				product: database.BankAccountProductOrm{ProductUuid: productUuid, MinBalance: 25},
+			})
+
+			accountOrm := database.BankAccountOrm{
+				AccountNumber:  "7835697001",
+				CurrentBalance: tt.balance,
+				OverdraftLimit: tt.overdraft,
+				ProductUuid:    tt.product,
+			}
+
+			err := b.checkDebit(map[uuid.UUID]dbank.AccountProduct{}, accountOrm, tt.debit, time.Now(), dbank.ErrTransferTransactionPair)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("checkDebit() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package application
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/adapter/database"
+	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// transferDb answers status updates with updated and err and records them,
+// and lists transfers from newest to oldest past the query cursor, any other
+// call panics
+type transferDb struct {
+	port.BankDatabasePort
+	updated   bool
+	err       error
+	updates   []string
+	transfers []database.BankTransferOrm
+	queries   []database.BankTransferQuery
+}
+
+func (d *transferDb) FindBankTransfers(q database.BankTransferQuery) ([]database.BankTransferOrm, error) {
+	d.queries = append(d.queries, q)
+
+	var res []database.BankTransferOrm
+	for _, t := range d.transfers {
+		if !q.AfterTimestamp.IsZero() && !t.TransferTimestamp.Before(q.AfterTimestamp) {
+			continue
+		}
+
+		if len(res) == q.Limit {
+			break
+		}
+
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (d *transferDb) GetBankAccountByUuid(accountUuid uuid.UUID) (database.BankAccountOrm, error) {
+	return database.BankAccountOrm{AccountUuid: accountUuid, AccountNumber: accountUuid.String()[:8]}, nil
+}
+
+func (d *transferDb) GetBankTransactionsByTransfers(transferUuids []uuid.UUID) ([]database.BankTransactionOrm, error) {
+	return nil, nil
+}
+
+func (d *transferDb) UpdateBankTransferStatus(transferUuid uuid.UUID, from []string, status string, failureReason string, ts time.Time) (bool, error) {
+	d.updates = append(d.updates, from[0]+">"+status)
+	return d.updated, d.err
+}
+
+// pairFailureDb serves accounts without holds, fees or transfer limits whose
+// transfers fail to post their transaction pair with err, it records the
+// transfer statuses. Any other call panics.
+type pairFailureDb struct {
+	port.BankDatabasePort
+	accounts []database.BankAccountOrm
+	err      error
+	statuses []string
+	reason   string
+}
+
+func (d *pairFailureDb) PortTransaction(fn func(tx port.BankDatabasePort) error) error {
+	return fn(d)
+}
+
+func (d *pairFailureDb) GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error) {
+	for _, a := range d.accounts {
+		if a.AccountNumber == acct {
+			return a, nil
+		}
+	}
+
+	return database.BankAccountOrm{}, database.ErrRecordNotFound
+}
+
+func (d *pairFailureDb) LockBankAccounts(accountUuids []uuid.UUID) ([]database.BankAccountOrm, error) {
+	return d.accounts, nil
+}
+
+func (d *pairFailureDb) GetBankHoldsActiveAmount(accountUuid uuid.UUID, ts time.Time) (float64, error) {
+	return 0, nil
+}
+
+func (d *pairFailureDb) GetBankFeeRules(productUuid *uuid.UUID, accountUuid uuid.UUID, operation string) ([]database.BankFeeRuleOrm, error) {
+	return nil, nil
+}
+
+func (d *pairFailureDb) GetBankFeeRuleTiers(feeRuleUuids []uuid.UUID) ([]database.BankFeeRuleTierOrm, error) {
+	return nil, nil
+}
+
+func (d *pairFailureDb) GetBankTransferLimits(accountUuid uuid.UUID, productUuid *uuid.UUID, currency string) ([]database.BankTransferLimitOrm, error) {
+	return nil, nil
+}
+
+func (d *pairFailureDb) CreateTransfer(transfer database.BankTransferOrm) (uuid.UUID, error) {
+	d.statuses = append(d.statuses, transfer.Status)
+	return transfer.TransferUuid, nil
+}
+
+func (d *pairFailureDb) UpdateBankTransferStatus(transferUuid uuid.UUID, from []string, status string, failureReason string, ts time.Time) (bool, error) {
+	d.statuses = append(d.statuses, status)
+	d.reason = failureReason
+
+	return true, nil
+}
+
+func (d *pairFailureDb) CreateTransferTransactionPair(fromAccountOrm database.BankAccountOrm, toAccountOrm database.BankAccountOrm, fromTransactionOrm database.BankTransactionOrm, toTransactionOrm database.BankTransactionOrm) (bool, error) {
+	return false, d.err
+}
+
+func (d *pairFailureDb) AppendAuditEvent(e database.AuditEventOrm, hashFn func(e database.AuditEventOrm) string) (database.AuditEventOrm, error) {
+	return e, nil
+}
+
+func TestTransferFailedNotRetryable(t *testing.T) {
+	storageErr := errors.New("deadlock detected")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+	}{
+		{"storage failure", storageErr, storageErr.Error()},
+		{"pair not created", nil, dbank.ErrTransferTransactionPair.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &pairFailureDb{
+				accounts: []database.BankAccountOrm{
+					{AccountUuid: uuid.New(), AccountNumber: "7835697001", Currency: "USD", CurrentBalance: 100},
+					{AccountUuid: uuid.New(), AccountNumber: "7835697002", Currency: "USD"},
+				},
+				err: tt.err,
+			}
+
+			b := NewBankService(db)
+
+			transferUuid, ok, err := b.Transfer(dbank.TrasferTransaction{FromAccountNumber: "7835697001", ToAccountNumber: "7835697002", Currency: "USD", Amount: 10})
+
+			var failed *dbank.TransferFailedError
+
+			// a failed transfer is recorded, retrying the request would
+			// start another one
+			if ok || !errors.As(err, &failed) || failed.TransferUuid != transferUuid {
+				t.Fatalf("Transfer() = %v, %v, %v, want a failed transfer error for the transfer", transferUuid, ok, err)
+			}
+
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("Transfer() = %v, want it to wrap %v", err, tt.err)
+			}
+
+			want := []string{dbank.TransferStatusPending, dbank.TransferStatusProcessing, dbank.TransferStatusFailed}
+
+			if len(db.statuses) != len(want) || db.statuses[2] != want[2] || db.reason != tt.wantReason {
+				t.Errorf("transfer statuses = %v failed with %q, want %v failed with %q", db.statuses, db.reason, want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestTransitionTransfer(t *testing.T) {
+	storageErr := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		db          *transferDb
+		from        string
+		to          string
+		wantErr     error
+		wantUpdates int
+	}{
+		{"allowed", &transferDb{updated: true}, dbank.TransferStatusPending, dbank.TransferStatusProcessing, nil, 1},
+		{"not allowed", &transferDb{updated: true}, dbank.TransferStatusFailed, dbank.TransferStatusCompleted, dbank.ErrTransferInvalidTransition, 0},
+		{"moved by someone else", &transferDb{}, dbank.TransferStatusProcessing, dbank.TransferStatusCompleted, dbank.ErrTransferInvalidTransition, 1},
+		{"storage failure", &transferDb{err: storageErr}, dbank.TransferStatusCompleted, dbank.TransferStatusReversed, storageErr, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBankService(tt.db)
+
+			err := b.transitionTransfer(uuid.New(), tt.from, tt.to, "", time.Now())
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("transitionTransfer() = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(tt.db.updates) != tt.wantUpdates {
+				t.Errorf("status updates = %v, want %v", tt.db.updates, tt.wantUpdates)
+			}
+		})
+	}
+}
+
+func TestParsePageToken(t *testing.T) {
+	ts := time.Date(2024, 3, 14, 15, 30, 0, 123456789, time.UTC)
+	id := uuid.New()
+	invalid := errors.New("invalid token")
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"round trip", pageToken(ts, id), nil},
+		{"not base64", "%%%", invalid},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("1|" + id.String())), invalid},
+		{"no separator", encode("1710430200"), invalid},
+		{"timestamp not a number", encode("yesterday|" + id.String()), invalid},
+		{"not a uuid", encode("1710430200|42"), invalid},
+		{"empty", "", invalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTs, gotId, err := parsePageToken(tt.token, invalid)
+
+			if err != tt.wantErr {
+				t.Fatalf("parsePageToken() = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && (!gotTs.Equal(ts) || gotId != id) {
+				t.Errorf("parsePageToken() = %v %v, want %v %v", gotTs, gotId, ts, id)
+			}
+		})
+	}
+}
+
+func TestListTransfersPages(t *testing.T) {
+	newest := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	var transfers []database.BankTransferOrm
+	for i := 0; i < 5; i++ {
+		transfers = append(transfers, database.BankTransferOrm{
+			TransferUuid:      uuid.New(),
+			FromAccountUuid:   uuid.New(),
+			ToAccountUuid:     uuid.New(),
+			TransferTimestamp: newest.Add(-time.Duration(i) * time.Minute),
+			Status:            dbank.TransferStatusCompleted,
+		})
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantPages []int
+		wantLimit int
+	}{
+		{"pages of two", 2, []int{2, 2, 1}, 2},
+		{"exact page", 5, []int{5}, 5},
+		{"default limit", 0, []int{5}, defaultTransferLimit},
+		{"limit capped", maxTransferLimit + 1, []int{5}, maxTransferLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &transferDb{transfers: transfers}
+			b := NewBankService(db)
+
+			var pages []int
+			var seen []uuid.UUID
+			token := ""
+
+			for {
+				page, next, err := b.ListTransfers(dbank.TransferFilter{Limit: tt.limit, PageToken: token})
+
+				if err != nil {
+					t.Fatalf("ListTransfers() = %v", err)
+				}
+
+				pages = append(pages, len(page))
+				for _, tr := range page {
+					seen = append(seen, tr.TransferUuid)
+				}
+
+				if next == "" {
+					break
+				}
+
+				token = next
+			}
+
+			if len(pages) != len(tt.wantPages) {
+				t.Fatalf("pages = %v, want %v", pages, tt.wantPages)
+			}
+
+			for i := range pages {
+				if pages[i] != tt.wantPages[i] {
+					t.Fatalf("pages = %v, want %v", pages, tt.wantPages)
+				}
+			}
+
+			for i, id := range seen {
+				if id != transfers[i].TransferUuid {
+					t.Fatalf("transfer %v = %v, want %v", i, id, transfers[i].TransferUuid)
+				}
+			}
+
+			// one row more than the page is asked for to detect a next page
+			if q := db.queries[0]; q.Limit != tt.wantLimit+1 {
+				t.Errorf("query limit = %v, want %v", q.Limit, tt.wantLimit+1)
+			}
+		})
+	}
+}
+
+func TestListTransfersInvalidPageToken(t *testing.T) {
+	b := NewBankService(&transferDb{})
+
+	if _, _, err := b.ListTransfers(dbank.TransferFilter{PageToken: "not-a-token"}); !errors.Is(err, dbank.ErrTransferInvalidPageToken) {
+		t.Errorf("ListTransfers() = %v, want %v", err, dbank.ErrTransferInvalidPageToken)
+	}
+}
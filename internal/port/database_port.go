@@ -13,11 +13,66 @@ type DummyDatabasePort interface {
 }
 
 type BankDatabasePort interface {
+	Transaction(fn func(tx *database.DatabaseAdapter) error) error
+	LockBankAccounts(accountUuids []uuid.UUID) ([]database.BankAccountOrm, error)
 	GetBankAccountByAccountNumber(acct string) (database.BankAccountOrm, error)
+	GetBankAccountByUuid(accountUuid uuid.UUID) (database.BankAccountOrm, error)
 	CreateExchangeRate(r database.BankExchangeRateOrm) (uuid.UUID, error)
 	GetExchangeRateAtTimestamp(fromCur string, toCur string, ts time.Time) (database.BankExchangeRateOrm, error)
 	CreateTransaction(acct database.BankAccountOrm, t database.BankTransactionOrm) (uuid.UUID, error)
 	CreateTransfer(transfer database.BankTransferOrm) (uuid.UUID, error)
 	CreateTransferTransactionPair(fromAccountOrm database.BankAccountOrm, toAccountOrm database.BankAccountOrm, fromTransactionOrm database.BankTransactionOrm, toTransactionOrm database.BankTransactionOrm) (bool, error)
-	UpdateBankTransferStatus(transfer database.BankTransferOrm, status bool) error
+	UpdateBankTransferStatus(transferUuid uuid.UUID, from []string, status string, failureReason string, ts time.Time) (bool, error)
+	GetBankTransactionsInPeriod(accountUuid uuid.UUID, from time.Time, to time.Time) ([]database.BankTransactionOrm, error)
+	GetBankAccountBalanceAt(accountUuid uuid.UUID, ts time.Time) (float64, error)
+	FindBankTransactions(q database.BankTransactionQuery) ([]database.BankTransactionOrm, error)
+	GetBankPrincipal(subject string) (database.BankPrincipalOrm, error)
+	GetBankAccountAccess(accountUuid uuid.UUID, subject string) (database.BankAccountAccessOrm, error)
+	AppendAuditEvent(e database.AuditEventOrm, hashFn func(e database.AuditEventOrm) string) (database.AuditEventOrm, error)
+	FindAuditEvents(actor string, action string, acct string, from time.Time, to time.Time, afterSequence int64, limit int) ([]database.AuditEventOrm, error)
+	GetBankTransactionSummaries(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]database.BankTransactionSummaryOrm, error)
+	GetBankTransactionSummaryReports(accountUuid uuid.UUID, period string, from time.Time, to time.Time) ([]database.BankTransactionSummaryReportOrm, error)
+	CreateBankTransactionSummaryReports(reports []database.BankTransactionSummaryReportOrm) error
+	DeleteBankTransactionSummaryReports(accountUuid uuid.UUID, ts time.Time) error
+	GetBankTransferLimits(accountUuid uuid.UUID, productUuid *uuid.UUID, currency string) ([]database.BankTransferLimitOrm, error)
+	GetBankTransferTotalsSince(fromAccountUuid uuid.UUID, since time.Time) (database.BankTransferTotalsOrm, error)
+	CreateBankHold(h database.BankHoldOrm) (uuid.UUID, error)
+	GetBankHold(holdUuid uuid.UUID) (database.BankHoldOrm, error)
+	GetBankHoldsActiveAmount(accountUuid uuid.UUID, ts time.Time) (float64, error)
+	CaptureBankHold(h database.BankHoldOrm, t database.BankTransactionOrm, ts time.Time) (bool, error)
+	UpdateBankHoldStatus(holdUuid uuid.UUID, status string) (bool, error)
+	ExpireBankHolds(ts time.Time) (int64, error)
+	CreateScheduledTransfer(s database.ScheduledTransferOrm) (uuid.UUID, error)
+	GetScheduledTransfer(scheduleUuid uuid.UUID) (database.ScheduledTransferOrm, error)
+	FindScheduledTransfers(fromAccountUuid uuid.UUID) ([]database.ScheduledTransferOrm, error)
+	GetScheduledTransferExecutions(scheduleUuid uuid.UUID) ([]database.ScheduledTransferExecutionOrm, error)
+	UpdateScheduledTransferStatus(scheduleUuid uuid.UUID, status string) (bool, error)
+	ClaimDueScheduledTransfer(ts time.Time, leaseUntil time.Time) (database.ScheduledTransferOrm, bool, error)
+	GetStartedScheduledTransferExecution(scheduleUuid uuid.UUID) (database.ScheduledTransferExecutionOrm, error)
+	CreateScheduledTransferExecution(e database.ScheduledTransferExecutionOrm) (uuid.UUID, error)
+	FinishScheduledTransferExecution(e database.ScheduledTransferExecutionOrm, s database.ScheduledTransferOrm) error
+	GetBankTransfer(transferUuid uuid.UUID) (database.BankTransferOrm, error)
+	FindBankTransfers(q database.BankTransferQuery) ([]database.BankTransferOrm, error)
+	GetBankTransactionsByTransfers(transferUuids []uuid.UUID) ([]database.BankTransactionOrm, error)
+	GetBankTransferReversals(transferUuid uuid.UUID) ([]database.BankTransferReversalOrm, error)
+	ReverseBankTransfer(r database.BankTransferReversalOrm, debit database.BankTransactionOrm, credit database.BankTransactionOrm) (bool, error)
+	CreateTransferBatch(b database.TransferBatchOrm, lines []database.TransferBatchLineOrm) error
+	GetTransferBatch(batchUuid uuid.UUID) (database.TransferBatchOrm, error)
+	GetTransferBatchLines(batchUuid uuid.UUID) ([]database.TransferBatchLineOrm, error)
+	UpdateTransferBatchLine(l database.TransferBatchLineOrm) error
+	UpdateTransferBatch(b database.TransferBatchOrm) error
+	GetBankAccountProduct(productUuid uuid.UUID) (database.BankAccountProductOrm, error)
+	GetBankAccountProductCurrencies(productUuid uuid.UUID) ([]database.BankAccountProductCurrencyOrm, error)
+	GetBankAccountProductInterestTiers(productUuid uuid.UUID) ([]database.BankAccountProductInterestTierOrm, error)
+	FindInterestBearingBankAccounts() ([]database.BankAccountOrm, error)
+	GetLatestBankInterestAccrual(accountUuid uuid.UUID) (database.BankInterestAccrualOrm, error)
+	CreateBankInterestAccrual(a database.BankInterestAccrualOrm) (bool, error)
+	FindUncapitalizedBankInterestPeriods(periodStart time.Time) ([]database.BankInterestPeriodOrm, error)
+	CreateBankInterestCapitalization(c database.BankInterestCapitalizationOrm) (database.BankInterestCapitalizationOrm, error)
+	FinishBankInterestCapitalization(accountUuid uuid.UUID, periodStart time.Time, status string, transactionUuid *uuid.UUID) (bool, error)
+	GetBankFeeRules(productUuid *uuid.UUID, accountUuid uuid.UUID, operation string) ([]database.BankFeeRuleOrm, error)
+	GetBankFeeRuleTiers(feeRuleUuids []uuid.UUID) ([]database.BankFeeRuleTierOrm, error)
+	GetBankFeeRevenueAccount(currency string) (database.BankAccountOrm, error)
+	CreateBankFeeCharge(c database.BankFeeChargeOrm, debit database.BankTransactionOrm, credit database.BankTransactionOrm) error
+	GetBankFeeChargesByTransfer(transferUuid uuid.UUID) ([]database.BankFeeChargeOrm, error)
 }
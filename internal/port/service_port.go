@@ -3,15 +3,41 @@ package port
 import (
 	"time"
 
+	"github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/auth"
 	dbank "github.com/abhilashdk2016/my-grpc-go-server/internal/application/domain/bank"
 	"github.com/google/uuid"
 )
 
 type BankServicePort interface {
 	FindCurrentBalance(acct string) (float64, error)
+	FindAccountBalance(acct string) (dbank.AccountBalance, error)
 	CreateExchangeRate(r dbank.ExchangeRate) (uuid.UUID, error)
+	GenerateExchangeRate(r dbank.ExchangeRate) (uuid.UUID, error)
 	FindExchangeRate(fromCur string, toCur string, ts time.Time) (float64, error)
 	CreateTransaction(acct string, t dbank.Transaction) (uuid.UUID, error)
+	ListTransactions(f dbank.TransactionFilter) ([]dbank.TransactionRecord, string, error)
 	CalculateTransactionSummary(tcur *dbank.TransactionSummary, trans dbank.Transaction) error
 	Transfer(tt dbank.TrasferTransaction) (uuid.UUID, bool, error)
+	GenerateStatement(acct string, from time.Time, to time.Time) (dbank.Statement, error)
+	ExportStatement(st dbank.Statement, format string) ([]byte, error)
+	Authorize(origin dbank.Origin, p auth.Principal, action string, acct string) error
+	ListAuditEvents(f dbank.AuditEventFilter) ([]dbank.AuditEvent, error)
+	VerifyAuditChain() (dbank.AuditChainVerification, error)
+	PlaceHold(h dbank.Hold) (dbank.Hold, error)
+	CaptureHold(origin dbank.Origin, acct string, holdUuid uuid.UUID, amount float64) (dbank.Hold, error)
+	ReleaseHold(origin dbank.Origin, acct string, holdUuid uuid.UUID) (dbank.Hold, error)
+	ExpireHolds(ts time.Time) (int64, error)
+	CreateScheduledTransfer(s dbank.ScheduledTransfer) (dbank.ScheduledTransfer, error)
+	GetScheduledTransfer(acct string, scheduleUuid uuid.UUID) (dbank.ScheduledTransfer, error)
+	ListScheduledTransfers(acct string) ([]dbank.ScheduledTransfer, error)
+	CancelScheduledTransfer(origin dbank.Origin, acct string, scheduleUuid uuid.UUID) (dbank.ScheduledTransfer, error)
+	ReverseTransfer(r dbank.TransferReversal) (dbank.TransferReversal, error)
+	GetTransfer(acct string, transferUuid uuid.UUID) (dbank.Transfer, error)
+	GetTransferFees(transferUuid uuid.UUID) ([]dbank.Fee, error)
+	ListTransfers(f dbank.TransferFilter) ([]dbank.Transfer, string, error)
+	ParseTransferBatch(format string, content []byte) ([]dbank.TransferBatchLine, error)
+	SubmitTransferBatch(batch dbank.TransferBatch) (dbank.TransferBatch, error)
+	GetTransferBatch(batchUuid uuid.UUID) (dbank.TransferBatch, error)
+	ExportTransferBatchReport(batch dbank.TransferBatch, format string) ([]byte, error)
+	SummarizeTransactionsByPeriod(acct string, period string, from time.Time, to time.Time) ([]dbank.TransactionSummary, error)
 }
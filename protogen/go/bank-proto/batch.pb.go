@@ -0,0 +1,817 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v3.12.4
+// source: proto/bank/type/batch.proto
+
+package bank_proto
+
+import (
+	datetime "google.golang.org/genproto/googleapis/type/datetime"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TransferBatchFormat int32
+
+const (
+	TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED TransferBatchFormat = 0
+	TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV         TransferBatchFormat = 1
+	TransferBatchFormat_TRANSFER_BATCH_FORMAT_JSON        TransferBatchFormat = 2
+)
+
+// Enum value maps for TransferBatchFormat.
+var (
+	TransferBatchFormat_name = map[int32]string{
+		0: "TRANSFER_BATCH_FORMAT_UNSPECIFIED",
+		1: "TRANSFER_BATCH_FORMAT_CSV",
+		2: "TRANSFER_BATCH_FORMAT_JSON",
+	}
+	TransferBatchFormat_value = map[string]int32{
+		"TRANSFER_BATCH_FORMAT_UNSPECIFIED": 0,
+		"TRANSFER_BATCH_FORMAT_CSV":         1,
+		"TRANSFER_BATCH_FORMAT_JSON":        2,
+	}
+)
+
+func (x TransferBatchFormat) Enum() *TransferBatchFormat {
+	p := new(TransferBatchFormat)
+	*p = x
+	return p
+}
+
+func (x TransferBatchFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferBatchFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bank_type_batch_proto_enumTypes[0].Descriptor()
+}
+
+func (TransferBatchFormat) Type() protoreflect.EnumType {
+	return &file_proto_bank_type_batch_proto_enumTypes[0]
+}
+
+func (x TransferBatchFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferBatchFormat.Descriptor instead.
+func (TransferBatchFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bank_type_batch_proto_rawDescGZIP(), []int{0}
+}
+
+type TransferBatchMode int32
+
+const (
+	TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED    TransferBatchMode = 0
+	TransferBatchMode_TRANSFER_BATCH_MODE_ALL_OR_NOTHING TransferBatchMode = 1
+	TransferBatchMode_TRANSFER_BATCH_MODE_BEST_EFFORT    TransferBatchMode = 2
+)
+
+// Enum value maps for TransferBatchMode.
+var (
+	TransferBatchMode_name = map[int32]string{
+		0: "TRANSFER_BATCH_MODE_UNSPECIFIED",
+		1: "TRANSFER_BATCH_MODE_ALL_OR_NOTHING",
+		2: "TRANSFER_BATCH_MODE_BEST_EFFORT",
+	}
+	TransferBatchMode_value = map[string]int32{
+		"TRANSFER_BATCH_MODE_UNSPECIFIED":    0,
+		"TRANSFER_BATCH_MODE_ALL_OR_NOTHING": 1,
+		"TRANSFER_BATCH_MODE_BEST_EFFORT":    2,
+	}
+)
+
+func (x TransferBatchMode) Enum() *TransferBatchMode {
+	p := new(TransferBatchMode)
+	*p = x
+	return p
+}
+
+func (x TransferBatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferBatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bank_type_batch_proto_enumTypes[1].Descriptor()
+}
+
+func (TransferBatchMode) Type() protoreflect.EnumType {
+	return &file_proto_bank_type_batch_proto_enumTypes[1]
+}
+
+func (x TransferBatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferBatchMode.Descriptor instead.
+func (TransferBatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bank_type_batch_proto_rawDescGZIP(), []int{1}
+}
+
+type TransferBatchStatus int32
+
+const (
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_UNSPECIFIED         TransferBatchStatus = 0
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_PROCESSING          TransferBatchStatus = 1
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_REJECTED            TransferBatchStatus = 2
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_COMPLETED           TransferBatchStatus = 3
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED TransferBatchStatus = 4
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_ROLLED_BACK         TransferBatchStatus = 5
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_FAILED              TransferBatchStatus = 6
+)
+
+// Enum value maps for TransferBatchStatus.
+var (
+	TransferBatchStatus_name = map[int32]string{
+		0: "TRANSFER_BATCH_STATUS_UNSPECIFIED",
+		1: "TRANSFER_BATCH_STATUS_PROCESSING",
+		2: "TRANSFER_BATCH_STATUS_REJECTED",
+		3: "TRANSFER_BATCH_STATUS_COMPLETED",
+		4: "TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED",
+		5: "TRANSFER_BATCH_STATUS_ROLLED_BACK",
+		6: "TRANSFER_BATCH_STATUS_FAILED",
+	}
+	TransferBatchStatus_value = map[string]int32{
+		"TRANSFER_BATCH_STATUS_UNSPECIFIED":         0,
+		"TRANSFER_BATCH_STATUS_PROCESSING":          1,
+		"TRANSFER_BATCH_STATUS_REJECTED":            2,
+		"TRANSFER_BATCH_STATUS_COMPLETED":           3,
+		"TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED": 4,
+		"TRANSFER_BATCH_STATUS_ROLLED_BACK":         5,
+		"TRANSFER_BATCH_STATUS_FAILED":              6,
+	}
+)
+
+func (x TransferBatchStatus) Enum() *TransferBatchStatus {
+	p := new(TransferBatchStatus)
+	*p = x
+	return p
+}
+
+func (x TransferBatchStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferBatchStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bank_type_batch_proto_enumTypes[2].Descriptor()
+}
+
+func (TransferBatchStatus) Type() protoreflect.EnumType {
+	return &file_proto_bank_type_batch_proto_enumTypes[2]
+}
+
+func (x TransferBatchStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferBatchStatus.Descriptor instead.
+func (TransferBatchStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bank_type_batch_proto_rawDescGZIP(), []int{2}
+}
+
+// TransferBatchChunk is a piece of a batch file. file_name, format and mode
+// are read from the first chunk only.
+type TransferBatchChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName string              `protobuf:"bytes,1,opt,name=file_name,proto3" json:"file_name,omitempty"`
+	Format   TransferBatchFormat `protobuf:"varint,2,opt,name=format,proto3,enum=bank.TransferBatchFormat" json:"format,omitempty"`
+	Mode     TransferBatchMode   `protobuf:"varint,3,opt,name=mode,proto3,enum=bank.TransferBatchMode" json:"mode,omitempty"`
+	Content  []byte              `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *TransferBatchChunk) Reset() {
+	*x = TransferBatchChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_batch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchChunk) ProtoMessage() {}
+
+func (x *TransferBatchChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_batch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchChunk.ProtoReflect.Descriptor instead.
+func (*TransferBatchChunk) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_batch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferBatchChunk) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *TransferBatchChunk) GetFormat() TransferBatchFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED
+}
+
+func (x *TransferBatchChunk) GetMode() TransferBatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED
+}
+
+func (x *TransferBatchChunk) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type TransferBatchLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LineNumber        int32   `protobuf:"varint,1,opt,name=line_number,proto3" json:"line_number,omitempty"`
+	FromAccountNumber string  `protobuf:"bytes,2,opt,name=from_account_number,proto3" json:"from_account_number,omitempty"`
+	ToAccountNumber   string  `protobuf:"bytes,3,opt,name=to_account_number,proto3" json:"to_account_number,omitempty"`
+	Currency          string  `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount            float64 `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reference         string  `protobuf:"bytes,6,opt,name=reference,proto3" json:"reference,omitempty"`
+	Status            string  `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	TransferUuid      string  `protobuf:"bytes,8,opt,name=transfer_uuid,proto3" json:"transfer_uuid,omitempty"`
+	Error             string  `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *TransferBatchLine) Reset() {
+	*x = TransferBatchLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_batch_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchLine) ProtoMessage() {}
+
+func (x *TransferBatchLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_batch_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchLine.ProtoReflect.Descriptor instead.
+func (*TransferBatchLine) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_batch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransferBatchLine) GetLineNumber() int32 {
+	if x != nil {
+		return x.LineNumber
+	}
+	return 0
+}
+
+func (x *TransferBatchLine) GetFromAccountNumber() string {
+	if x != nil {
+		return x.FromAccountNumber
+	}
+	return ""
+}
+
+func (x *TransferBatchLine) GetToAccountNumber() string {
+	if x != nil {
+		return x.ToAccountNumber
+	}
+	return ""
+}
+
+func (x *TransferBatchLine) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TransferBatchLine) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferBatchLine) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *TransferBatchLine) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransferBatchLine) GetTransferUuid() string {
+	if x != nil {
+		return x.TransferUuid
+	}
+	return ""
+}
+
+func (x *TransferBatchLine) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type TransferBatchReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchUuid string `protobuf:"bytes,1,opt,name=batch_uuid,proto3" json:"batch_uuid,omitempty"`
+	// report_format asks for the report as a file in content as well
+	ReportFormat TransferBatchFormat `protobuf:"varint,2,opt,name=report_format,proto3,enum=bank.TransferBatchFormat" json:"report_format,omitempty"`
+}
+
+func (x *TransferBatchReportRequest) Reset() {
+	*x = TransferBatchReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_batch_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchReportRequest) ProtoMessage() {}
+
+func (x *TransferBatchReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_batch_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchReportRequest.ProtoReflect.Descriptor instead.
+func (*TransferBatchReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_batch_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransferBatchReportRequest) GetBatchUuid() string {
+	if x != nil {
+		return x.BatchUuid
+	}
+	return ""
+}
+
+func (x *TransferBatchReportRequest) GetReportFormat() TransferBatchFormat {
+	if x != nil {
+		return x.ReportFormat
+	}
+	return TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED
+}
+
+type TransferBatchReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchUuid      string               `protobuf:"bytes,1,opt,name=batch_uuid,proto3" json:"batch_uuid,omitempty"`
+	FileName       string               `protobuf:"bytes,2,opt,name=file_name,proto3" json:"file_name,omitempty"`
+	Format         TransferBatchFormat  `protobuf:"varint,3,opt,name=format,proto3,enum=bank.TransferBatchFormat" json:"format,omitempty"`
+	Mode           TransferBatchMode    `protobuf:"varint,4,opt,name=mode,proto3,enum=bank.TransferBatchMode" json:"mode,omitempty"`
+	Status         TransferBatchStatus  `protobuf:"varint,5,opt,name=status,proto3,enum=bank.TransferBatchStatus" json:"status,omitempty"`
+	LineCount      int32                `protobuf:"varint,6,opt,name=line_count,proto3" json:"line_count,omitempty"`
+	SucceededCount int32                `protobuf:"varint,7,opt,name=succeeded_count,proto3" json:"succeeded_count,omitempty"`
+	FailedCount    int32                `protobuf:"varint,8,opt,name=failed_count,proto3" json:"failed_count,omitempty"`
+	CreatedBy      string               `protobuf:"bytes,9,opt,name=created_by,proto3" json:"created_by,omitempty"`
+	CreatedAt      *datetime.DateTime   `protobuf:"bytes,10,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	CompletedAt    *datetime.DateTime   `protobuf:"bytes,11,opt,name=completed_at,proto3" json:"completed_at,omitempty"`
+	Lines          []*TransferBatchLine `protobuf:"bytes,12,rep,name=lines,proto3" json:"lines,omitempty"`
+	ReportFormat   TransferBatchFormat  `protobuf:"varint,13,opt,name=report_format,proto3,enum=bank.TransferBatchFormat" json:"report_format,omitempty"`
+	Content        []byte               `protobuf:"bytes,14,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *TransferBatchReport) Reset() {
+	*x = TransferBatchReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_batch_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchReport) ProtoMessage() {}
+
+func (x *TransferBatchReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_batch_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchReport.ProtoReflect.Descriptor instead.
+func (*TransferBatchReport) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_batch_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransferBatchReport) GetBatchUuid() string {
+	if x != nil {
+		return x.BatchUuid
+	}
+	return ""
+}
+
+func (x *TransferBatchReport) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *TransferBatchReport) GetFormat() TransferBatchFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED
+}
+
+func (x *TransferBatchReport) GetMode() TransferBatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED
+}
+
+func (x *TransferBatchReport) GetStatus() TransferBatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransferBatchStatus_TRANSFER_BATCH_STATUS_UNSPECIFIED
+}
+
+func (x *TransferBatchReport) GetLineCount() int32 {
+	if x != nil {
+		return x.LineCount
+	}
+	return 0
+}
+
+func (x *TransferBatchReport) GetSucceededCount() int32 {
+	if x != nil {
+		return x.SucceededCount
+	}
+	return 0
+}
+
+func (x *TransferBatchReport) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *TransferBatchReport) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *TransferBatchReport) GetCreatedAt() *datetime.DateTime {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TransferBatchReport) GetCompletedAt() *datetime.DateTime {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *TransferBatchReport) GetLines() []*TransferBatchLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *TransferBatchReport) GetReportFormat() TransferBatchFormat {
+	if x != nil {
+		return x.ReportFormat
+	}
+	return TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED
+}
+
+func (x *TransferBatchReport) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+var File_proto_bank_type_batch_proto protoreflect.FileDescriptor
+
+var file_proto_bank_type_batch_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70,
+	0x65, 0x2f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x62,
+	0x61, 0x6e, 0x6b, 0x1a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xac, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x62, 0x61,
+	0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x22, 0xbb, 0x02, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x69,
+	0x6e, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x13,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2c,
+	0x0a, 0x11, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x74, 0x6f, 0x5f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x7d, 0x0a, 0x1a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x75, 0x75, 0x69, 0x64,
+	0x12, 0x3f, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x52, 0x0d, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x22, 0xf0, 0x04, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x69,
+	0x6e, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x12,
+	0x39, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0c, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x12, 0x2d, 0x0a, 0x05, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x69,
+	0x6e, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0d, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0d, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x2a, 0x7b, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x25, 0x0a, 0x21, 0x54,
+	0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43, 0x53, 0x56, 0x10,
+	0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x10,
+	0x02, 0x2a, 0x85, 0x01, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x52, 0x41, 0x4e, 0x53,
+	0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x26, 0x0a, 0x22,
+	0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x5f, 0x41, 0x4c, 0x4c, 0x5f, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x48, 0x49,
+	0x4e, 0x47, 0x10, 0x01, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52,
+	0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x42, 0x45, 0x53, 0x54,
+	0x5f, 0x45, 0x46, 0x46, 0x4f, 0x52, 0x54, 0x10, 0x02, 0x2a, 0xa3, 0x02, 0x0a, 0x13, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x25, 0x0a, 0x21, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x54, 0x52, 0x41, 0x4e,
+	0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x22,
+	0x0a, 0x1e, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50,
+	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x2d, 0x0a, 0x29, 0x54, 0x52, 0x41, 0x4e, 0x53,
+	0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x50, 0x41, 0x52, 0x54, 0x49, 0x41, 0x4c, 0x4c, 0x59, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c,
+	0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x25, 0x0a, 0x21, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46,
+	0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x52, 0x4f, 0x4c, 0x4c, 0x45, 0x44, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x05, 0x12, 0x20, 0x0a,
+	0x1c, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x06, 0x42,
+	0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x62,
+	0x68, 0x69, 0x6c, 0x61, 0x73, 0x68, 0x64, 0x6b, 0x32, 0x30, 0x31, 0x36, 0x2f, 0x6d, 0x79, 0x2d,
+	0x67, 0x72, 0x70, 0x63, 0x2d, 0x67, 0x6f, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2d,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_bank_type_batch_proto_rawDescOnce sync.Once
+	file_proto_bank_type_batch_proto_rawDescData = file_proto_bank_type_batch_proto_rawDesc
+)
+
+func file_proto_bank_type_batch_proto_rawDescGZIP() []byte {
+	file_proto_bank_type_batch_proto_rawDescOnce.Do(func() {
+		file_proto_bank_type_batch_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_bank_type_batch_proto_rawDescData)
+	})
+	return file_proto_bank_type_batch_proto_rawDescData
+}
+
+var file_proto_bank_type_batch_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_bank_type_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_bank_type_batch_proto_goTypes = []interface{}{
+	(TransferBatchFormat)(0),           // 0: bank.TransferBatchFormat
+	(TransferBatchMode)(0),             // 1: bank.TransferBatchMode
+	(TransferBatchStatus)(0),           // 2: bank.TransferBatchStatus
+	(*TransferBatchChunk)(nil),         // 3: bank.TransferBatchChunk
+	(*TransferBatchLine)(nil),          // 4: bank.TransferBatchLine
+	(*TransferBatchReportRequest)(nil), // 5: bank.TransferBatchReportRequest
+	(*TransferBatchReport)(nil),        // 6: bank.TransferBatchReport
+	(*datetime.DateTime)(nil),          // 7: google.type.DateTime
+}
+var file_proto_bank_type_batch_proto_depIdxs = []int32{
+	0,  // 0: bank.TransferBatchChunk.format:type_name -> bank.TransferBatchFormat
+	1,  // 1: bank.TransferBatchChunk.mode:type_name -> bank.TransferBatchMode
+	0,  // 2: bank.TransferBatchReportRequest.report_format:type_name -> bank.TransferBatchFormat
+	0,  // 3: bank.TransferBatchReport.format:type_name -> bank.TransferBatchFormat
+	1,  // 4: bank.TransferBatchReport.mode:type_name -> bank.TransferBatchMode
+	2,  // 5: bank.TransferBatchReport.status:type_name -> bank.TransferBatchStatus
+	7,  // 6: bank.TransferBatchReport.created_at:type_name -> google.type.DateTime
+	7,  // 7: bank.TransferBatchReport.completed_at:type_name -> google.type.DateTime
+	4,  // 8: bank.TransferBatchReport.lines:type_name -> bank.TransferBatchLine
+	0,  // 9: bank.TransferBatchReport.report_format:type_name -> bank.TransferBatchFormat
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_proto_bank_type_batch_proto_init() }
+func file_proto_bank_type_batch_proto_init() {
+	if File_proto_bank_type_batch_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_bank_type_batch_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferBatchChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_batch_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferBatchLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_batch_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferBatchReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_batch_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferBatchReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_bank_type_batch_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_bank_type_batch_proto_goTypes,
+		DependencyIndexes: file_proto_bank_type_batch_proto_depIdxs,
+		EnumInfos:         file_proto_bank_type_batch_proto_enumTypes,
+		MessageInfos:      file_proto_bank_type_batch_proto_msgTypes,
+	}.Build()
+	File_proto_bank_type_batch_proto = out.File
+	file_proto_bank_type_batch_proto_rawDesc = nil
+	file_proto_bank_type_batch_proto_goTypes = nil
+	file_proto_bank_type_batch_proto_depIdxs = nil
+}
@@ -0,0 +1,907 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v3.12.4
+// source: proto/bank/type/schedule.proto
+
+package bank_proto
+
+import (
+	datetime "google.golang.org/genproto/googleapis/type/datetime"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ScheduleFrequency int32
+
+const (
+	ScheduleFrequency_SCHEDULE_FREQUENCY_UNSPECIFIED ScheduleFrequency = 0
+	ScheduleFrequency_SCHEDULE_FREQUENCY_ONCE        ScheduleFrequency = 1
+	ScheduleFrequency_SCHEDULE_FREQUENCY_DAILY       ScheduleFrequency = 2
+	ScheduleFrequency_SCHEDULE_FREQUENCY_WEEKLY      ScheduleFrequency = 3
+	ScheduleFrequency_SCHEDULE_FREQUENCY_MONTHLY     ScheduleFrequency = 4
+)
+
+// Enum value maps for ScheduleFrequency.
+var (
+	ScheduleFrequency_name = map[int32]string{
+		0: "SCHEDULE_FREQUENCY_UNSPECIFIED",
+		1: "SCHEDULE_FREQUENCY_ONCE",
+		2: "SCHEDULE_FREQUENCY_DAILY",
+		3: "SCHEDULE_FREQUENCY_WEEKLY",
+		4: "SCHEDULE_FREQUENCY_MONTHLY",
+	}
+	ScheduleFrequency_value = map[string]int32{
+		"SCHEDULE_FREQUENCY_UNSPECIFIED": 0,
+		"SCHEDULE_FREQUENCY_ONCE":        1,
+		"SCHEDULE_FREQUENCY_DAILY":       2,
+		"SCHEDULE_FREQUENCY_WEEKLY":      3,
+		"SCHEDULE_FREQUENCY_MONTHLY":     4,
+	}
+)
+
+func (x ScheduleFrequency) Enum() *ScheduleFrequency {
+	p := new(ScheduleFrequency)
+	*p = x
+	return p
+}
+
+func (x ScheduleFrequency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduleFrequency) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bank_type_schedule_proto_enumTypes[0].Descriptor()
+}
+
+func (ScheduleFrequency) Type() protoreflect.EnumType {
+	return &file_proto_bank_type_schedule_proto_enumTypes[0]
+}
+
+func (x ScheduleFrequency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduleFrequency.Descriptor instead.
+func (ScheduleFrequency) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{0}
+}
+
+type ScheduleStatus int32
+
+const (
+	ScheduleStatus_SCHEDULE_STATUS_UNSPECIFIED ScheduleStatus = 0
+	ScheduleStatus_SCHEDULE_STATUS_ACTIVE      ScheduleStatus = 1
+	ScheduleStatus_SCHEDULE_STATUS_COMPLETED   ScheduleStatus = 2
+	ScheduleStatus_SCHEDULE_STATUS_CANCELLED   ScheduleStatus = 3
+)
+
+// Enum value maps for ScheduleStatus.
+var (
+	ScheduleStatus_name = map[int32]string{
+		0: "SCHEDULE_STATUS_UNSPECIFIED",
+		1: "SCHEDULE_STATUS_ACTIVE",
+		2: "SCHEDULE_STATUS_COMPLETED",
+		3: "SCHEDULE_STATUS_CANCELLED",
+	}
+	ScheduleStatus_value = map[string]int32{
+		"SCHEDULE_STATUS_UNSPECIFIED": 0,
+		"SCHEDULE_STATUS_ACTIVE":      1,
+		"SCHEDULE_STATUS_COMPLETED":   2,
+		"SCHEDULE_STATUS_CANCELLED":   3,
+	}
+)
+
+func (x ScheduleStatus) Enum() *ScheduleStatus {
+	p := new(ScheduleStatus)
+	*p = x
+	return p
+}
+
+func (x ScheduleStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduleStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bank_type_schedule_proto_enumTypes[1].Descriptor()
+}
+
+func (ScheduleStatus) Type() protoreflect.EnumType {
+	return &file_proto_bank_type_schedule_proto_enumTypes[1]
+}
+
+func (x ScheduleStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduleStatus.Descriptor instead.
+func (ScheduleStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+type ScheduledTransferRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromAccountNumber string             `protobuf:"bytes,1,opt,name=from_account_number,proto3" json:"from_account_number,omitempty"`
+	ToAccountNumber   string             `protobuf:"bytes,2,opt,name=to_account_number,proto3" json:"to_account_number,omitempty"`
+	Currency          string             `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount            float64            `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Frequency         ScheduleFrequency  `protobuf:"varint,5,opt,name=frequency,proto3,enum=bank.ScheduleFrequency" json:"frequency,omitempty"`
+	Interval          int32              `protobuf:"varint,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	StartAt           *datetime.DateTime `protobuf:"bytes,7,opt,name=start_at,proto3" json:"start_at,omitempty"`
+	EndAt             *datetime.DateTime `protobuf:"bytes,8,opt,name=end_at,proto3" json:"end_at,omitempty"`
+}
+
+func (x *ScheduledTransferRequest) Reset() {
+	*x = ScheduledTransferRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_schedule_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransferRequest) ProtoMessage() {}
+
+func (x *ScheduledTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_schedule_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransferRequest.ProtoReflect.Descriptor instead.
+func (*ScheduledTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ScheduledTransferRequest) GetFromAccountNumber() string {
+	if x != nil {
+		return x.FromAccountNumber
+	}
+	return ""
+}
+
+func (x *ScheduledTransferRequest) GetToAccountNumber() string {
+	if x != nil {
+		return x.ToAccountNumber
+	}
+	return ""
+}
+
+func (x *ScheduledTransferRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ScheduledTransferRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ScheduledTransferRequest) GetFrequency() ScheduleFrequency {
+	if x != nil {
+		return x.Frequency
+	}
+	return ScheduleFrequency_SCHEDULE_FREQUENCY_UNSPECIFIED
+}
+
+func (x *ScheduledTransferRequest) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *ScheduledTransferRequest) GetStartAt() *datetime.DateTime {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransferRequest) GetEndAt() *datetime.DateTime {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+type ScheduledTransferLookup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	ScheduleUuid  string `protobuf:"bytes,2,opt,name=schedule_uuid,proto3" json:"schedule_uuid,omitempty"`
+}
+
+func (x *ScheduledTransferLookup) Reset() {
+	*x = ScheduledTransferLookup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_schedule_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledTransferLookup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransferLookup) ProtoMessage() {}
+
+func (x *ScheduledTransferLookup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_schedule_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransferLookup.ProtoReflect.Descriptor instead.
+func (*ScheduledTransferLookup) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScheduledTransferLookup) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *ScheduledTransferLookup) GetScheduleUuid() string {
+	if x != nil {
+		return x.ScheduleUuid
+	}
+	return ""
+}
+
+type ScheduledTransferListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+}
+
+func (x *ScheduledTransferListRequest) Reset() {
+	*x = ScheduledTransferListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_schedule_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledTransferListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransferListRequest) ProtoMessage() {}
+
+func (x *ScheduledTransferListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_schedule_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransferListRequest.ProtoReflect.Descriptor instead.
+func (*ScheduledTransferListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ScheduledTransferListRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+type ScheduledTransferExecution struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExecutionUuid string             `protobuf:"bytes,1,opt,name=execution_uuid,proto3" json:"execution_uuid,omitempty"`
+	ScheduledFor  *datetime.DateTime `protobuf:"bytes,2,opt,name=scheduled_for,proto3" json:"scheduled_for,omitempty"`
+	Attempt       int32              `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Status        string             `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TransferUuid  string             `protobuf:"bytes,5,opt,name=transfer_uuid,proto3" json:"transfer_uuid,omitempty"`
+	Error         string             `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt     *datetime.DateTime `protobuf:"bytes,7,opt,name=started_at,proto3" json:"started_at,omitempty"`
+	FinishedAt    *datetime.DateTime `protobuf:"bytes,8,opt,name=finished_at,proto3" json:"finished_at,omitempty"`
+}
+
+func (x *ScheduledTransferExecution) Reset() {
+	*x = ScheduledTransferExecution{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_schedule_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledTransferExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransferExecution) ProtoMessage() {}
+
+func (x *ScheduledTransferExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_schedule_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransferExecution.ProtoReflect.Descriptor instead.
+func (*ScheduledTransferExecution) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScheduledTransferExecution) GetExecutionUuid() string {
+	if x != nil {
+		return x.ExecutionUuid
+	}
+	return ""
+}
+
+func (x *ScheduledTransferExecution) GetScheduledFor() *datetime.DateTime {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+func (x *ScheduledTransferExecution) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *ScheduledTransferExecution) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledTransferExecution) GetTransferUuid() string {
+	if x != nil {
+		return x.TransferUuid
+	}
+	return ""
+}
+
+func (x *ScheduledTransferExecution) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduledTransferExecution) GetStartedAt() *datetime.DateTime {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransferExecution) GetFinishedAt() *datetime.DateTime {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type ScheduledTransfer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduleUuid      string                        `protobuf:"bytes,1,opt,name=schedule_uuid,proto3" json:"schedule_uuid,omitempty"`
+	FromAccountNumber string                        `protobuf:"bytes,2,opt,name=from_account_number,proto3" json:"from_account_number,omitempty"`
+	ToAccountNumber   string                        `protobuf:"bytes,3,opt,name=to_account_number,proto3" json:"to_account_number,omitempty"`
+	Currency          string                        `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount            float64                       `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Frequency         ScheduleFrequency             `protobuf:"varint,6,opt,name=frequency,proto3,enum=bank.ScheduleFrequency" json:"frequency,omitempty"`
+	Interval          int32                         `protobuf:"varint,7,opt,name=interval,proto3" json:"interval,omitempty"`
+	StartAt           *datetime.DateTime            `protobuf:"bytes,8,opt,name=start_at,proto3" json:"start_at,omitempty"`
+	EndAt             *datetime.DateTime            `protobuf:"bytes,9,opt,name=end_at,proto3" json:"end_at,omitempty"`
+	NextRunAt         *datetime.DateTime            `protobuf:"bytes,10,opt,name=next_run_at,proto3" json:"next_run_at,omitempty"`
+	Status            ScheduleStatus                `protobuf:"varint,11,opt,name=status,proto3,enum=bank.ScheduleStatus" json:"status,omitempty"`
+	CreatedBy         string                        `protobuf:"bytes,12,opt,name=created_by,proto3" json:"created_by,omitempty"`
+	Executions        []*ScheduledTransferExecution `protobuf:"bytes,13,rep,name=executions,proto3" json:"executions,omitempty"`
+}
+
+func (x *ScheduledTransfer) Reset() {
+	*x = ScheduledTransfer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_schedule_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransfer) ProtoMessage() {}
+
+func (x *ScheduledTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_schedule_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransfer.ProtoReflect.Descriptor instead.
+func (*ScheduledTransfer) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScheduledTransfer) GetScheduleUuid() string {
+	if x != nil {
+		return x.ScheduleUuid
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetFromAccountNumber() string {
+	if x != nil {
+		return x.FromAccountNumber
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetToAccountNumber() string {
+	if x != nil {
+		return x.ToAccountNumber
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ScheduledTransfer) GetFrequency() ScheduleFrequency {
+	if x != nil {
+		return x.Frequency
+	}
+	return ScheduleFrequency_SCHEDULE_FREQUENCY_UNSPECIFIED
+}
+
+func (x *ScheduledTransfer) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *ScheduledTransfer) GetStartAt() *datetime.DateTime {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransfer) GetEndAt() *datetime.DateTime {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransfer) GetNextRunAt() *datetime.DateTime {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransfer) GetStatus() ScheduleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ScheduleStatus_SCHEDULE_STATUS_UNSPECIFIED
+}
+
+func (x *ScheduledTransfer) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetExecutions() []*ScheduledTransferExecution {
+	if x != nil {
+		return x.Executions
+	}
+	return nil
+}
+
+type ScheduledTransferList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduledTransfers []*ScheduledTransfer `protobuf:"bytes,1,rep,name=scheduled_transfers,proto3" json:"scheduled_transfers,omitempty"`
+}
+
+func (x *ScheduledTransferList) Reset() {
+	*x = ScheduledTransferList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bank_type_schedule_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledTransferList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransferList) ProtoMessage() {}
+
+func (x *ScheduledTransferList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bank_type_schedule_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransferList.ProtoReflect.Descriptor instead.
+func (*ScheduledTransferList) Descriptor() ([]byte, []int) {
+	return file_proto_bank_type_schedule_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ScheduledTransferList) GetScheduledTransfers() []*ScheduledTransfer {
+	if x != nil {
+		return x.ScheduledTransfers
+	}
+	return nil
+}
+
+var File_proto_bank_type_schedule_proto protoreflect.FileDescriptor
+
+var file_proto_bank_type_schedule_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70,
+	0x65, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x04, 0x62, 0x61, 0x6e, 0x6b, 0x1a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x74, 0x69,
+	0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe3, 0x02, 0x0a, 0x18, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x13, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x74, 0x6f, 0x5f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x66, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x46, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x31, 0x0a, 0x08,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x12,
+	0x2d, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x74, 0x22, 0x67,
+	0x0a, 0x17, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x75, 0x75,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x22, 0x46, 0x0a, 0x1c, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22,
+	0xdf, 0x02, 0x0a, 0x1a, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26,
+	0x0a, 0x0e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x12, 0x3b, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x5f, 0x66, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f,
+	0x66, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x35, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x22, 0xcb, 0x04, 0x0a, 0x11, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x12, 0x30, 0x0a,
+	0x13, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x2c, 0x0a, 0x11, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x74, 0x6f, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x35, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x09, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x08, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x06,
+	0x65, 0x6e, 0x64, 0x5f, 0x61, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72,
+	0x75, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x74, 0x12,
+	0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x12, 0x40, 0x0a,
+	0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x62, 0x0a, 0x15, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x49, 0x0a, 0x13, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x13,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x73, 0x2a, 0xb1, 0x01, 0x0a, 0x11, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x43, 0x48,
+	0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f, 0x46, 0x52, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x59, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a,
+	0x17, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f, 0x46, 0x52, 0x45, 0x51, 0x55, 0x45,
+	0x4e, 0x43, 0x59, 0x5f, 0x4f, 0x4e, 0x43, 0x45, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x43,
+	0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f, 0x46, 0x52, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x59,
+	0x5f, 0x44, 0x41, 0x49, 0x4c, 0x59, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x43, 0x48, 0x45,
+	0x44, 0x55, 0x4c, 0x45, 0x5f, 0x46, 0x52, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x57,
+	0x45, 0x45, 0x4b, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x43, 0x48, 0x45, 0x44,
+	0x55, 0x4c, 0x45, 0x5f, 0x46, 0x52, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x4d, 0x4f,
+	0x4e, 0x54, 0x48, 0x4c, 0x59, 0x10, 0x04, 0x2a, 0x8b, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x43,
+	0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53,
+	0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41,
+	0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x43, 0x48, 0x45, 0x44,
+	0x55, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c,
+	0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55,
+	0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c,
+	0x4c, 0x45, 0x44, 0x10, 0x03, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x62, 0x68, 0x69, 0x6c, 0x61, 0x73, 0x68, 0x64, 0x6b, 0x32, 0x30,
+	0x31, 0x36, 0x2f, 0x6d, 0x79, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x67, 0x6f, 0x2d, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f,
+	0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_bank_type_schedule_proto_rawDescOnce sync.Once
+	file_proto_bank_type_schedule_proto_rawDescData = file_proto_bank_type_schedule_proto_rawDesc
+)
+
+func file_proto_bank_type_schedule_proto_rawDescGZIP() []byte {
+	file_proto_bank_type_schedule_proto_rawDescOnce.Do(func() {
+		file_proto_bank_type_schedule_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_bank_type_schedule_proto_rawDescData)
+	})
+	return file_proto_bank_type_schedule_proto_rawDescData
+}
+
+var file_proto_bank_type_schedule_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_bank_type_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_bank_type_schedule_proto_goTypes = []interface{}{
+	(ScheduleFrequency)(0),               // 0: bank.ScheduleFrequency
+	(ScheduleStatus)(0),                  // 1: bank.ScheduleStatus
+	(*ScheduledTransferRequest)(nil),     // 2: bank.ScheduledTransferRequest
+	(*ScheduledTransferLookup)(nil),      // 3: bank.ScheduledTransferLookup
+	(*ScheduledTransferListRequest)(nil), // 4: bank.ScheduledTransferListRequest
+	(*ScheduledTransferExecution)(nil),   // 5: bank.ScheduledTransferExecution
+	(*ScheduledTransfer)(nil),            // 6: bank.ScheduledTransfer
+	(*ScheduledTransferList)(nil),        // 7: bank.ScheduledTransferList
+	(*datetime.DateTime)(nil),            // 8: google.type.DateTime
+}
+var file_proto_bank_type_schedule_proto_depIdxs = []int32{
+	0,  // 0: bank.ScheduledTransferRequest.frequency:type_name -> bank.ScheduleFrequency
+	8,  // 1: bank.ScheduledTransferRequest.start_at:type_name -> google.type.DateTime
+	8,  // 2: bank.ScheduledTransferRequest.end_at:type_name -> google.type.DateTime
+	8,  // 3: bank.ScheduledTransferExecution.scheduled_for:type_name -> google.type.DateTime
+	8,  // 4: bank.ScheduledTransferExecution.started_at:type_name -> google.type.DateTime
+	8,  // 5: bank.ScheduledTransferExecution.finished_at:type_name -> google.type.DateTime
+	0,  // 6: bank.ScheduledTransfer.frequency:type_name -> bank.ScheduleFrequency
+	8,  // 7: bank.ScheduledTransfer.start_at:type_name -> google.type.DateTime
+	8,  // 8: bank.ScheduledTransfer.end_at:type_name -> google.type.DateTime
+	8,  // 9: bank.ScheduledTransfer.next_run_at:type_name -> google.type.DateTime
+	1,  // 10: bank.ScheduledTransfer.status:type_name -> bank.ScheduleStatus
+	5,  // 11: bank.ScheduledTransfer.executions:type_name -> bank.ScheduledTransferExecution
+	6,  // 12: bank.ScheduledTransferList.scheduled_transfers:type_name -> bank.ScheduledTransfer
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_proto_bank_type_schedule_proto_init() }
+func file_proto_bank_type_schedule_proto_init() {
+	if File_proto_bank_type_schedule_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_bank_type_schedule_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledTransferRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_schedule_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledTransferLookup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_schedule_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledTransferListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_schedule_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledTransferExecution); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_schedule_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledTransfer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bank_type_schedule_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledTransferList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_bank_type_schedule_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_bank_type_schedule_proto_goTypes,
+		DependencyIndexes: file_proto_bank_type_schedule_proto_depIdxs,
+		EnumInfos:         file_proto_bank_type_schedule_proto_enumTypes,
+		MessageInfos:      file_proto_bank_type_schedule_proto_msgTypes,
+	}.Build()
+	File_proto_bank_type_schedule_proto = out.File
+	file_proto_bank_type_schedule_proto_rawDesc = nil
+	file_proto_bank_type_schedule_proto_goTypes = nil
+	file_proto_bank_type_schedule_proto_depIdxs = nil
+}
@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v3.12.4
+// source: proto/bank/service.proto
+
+package bank_proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_proto_bank_service_proto protoreflect.FileDescriptor
+
+var file_proto_bank_service_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x62, 0x61, 0x6e, 0x6b,
+	0x1a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70,
+	0x65, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65,
+	0x2f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x21, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65,
+	0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74,
+	0x79, 0x70, 0x65, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74,
+	0x79, 0x70, 0x65, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f,
+	0x74, 0x79, 0x70, 0x65, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70,
+	0x65, 0x2f, 0x68, 0x6f, 0x6c, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xb0, 0x08, 0x0a, 0x0b, 0x42, 0x61,
+	0x6e, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b,
+	0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x61,
+	0x6e, 0x6b, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x12, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x15,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x11, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x47, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12,
+	0x46, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x1f, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x10, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x75, 0x64, 0x69, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x17, 0x2e,
+	0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x09, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x48,
+	0x6f, 0x6c, 0x64, 0x12, 0x16, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65,
+	0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x62, 0x61,
+	0x6e, 0x6b, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x0b, 0x43, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x22, 0x00,
+	0x12, 0x35, 0x0a, 0x0b, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x48, 0x6f, 0x6c, 0x64, 0x12,
+	0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x48, 0x6f,
+	0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0f, 0x52, 0x65, 0x76, 0x65, 0x72,
+	0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x61, 0x6c,
+	0x22, 0x00, 0x12, 0x39, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x12, 0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x62, 0x61,
+	0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x22, 0x00, 0x12, 0x41, 0x0a,
+	0x0d, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0x1a,
+	0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00,
+	0x12, 0x4a, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x32, 0xf4, 0x02, 0x0a,
+	0x18, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x17, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x22, 0x00, 0x12,
+	0x50, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x1a, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x22,
+	0x00, 0x12, 0x5b, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0x22, 0x2e, 0x62, 0x61,
+	0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x53,
+	0x0a, 0x17, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x1a, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x22, 0x00, 0x32, 0xbf, 0x01, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x13,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x19, 0x2e,
+	0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x28, 0x01, 0x12, 0x57, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x20, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x22, 0x00, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x62, 0x68, 0x69, 0x6c, 0x61, 0x73, 0x68, 0x64, 0x6b, 0x32, 0x30,
+	0x31, 0x36, 0x2f, 0x6d, 0x79, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x67, 0x6f, 0x2d, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f,
+	0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var file_proto_bank_service_proto_goTypes = []interface{}{
+	(*CurrentBalanceRequest)(nil),        // 0: bank.CurrentBalanceRequest
+	(*ExchangeRateRequest)(nil),          // 1: bank.ExchangeRateRequest
+	(*Transaction)(nil),                  // 2: bank.Transaction
+	(*TransferRequest)(nil),              // 3: bank.TransferRequest
+	(*StatementRequest)(nil),             // 4: bank.StatementRequest
+	(*TransactionSummaryRequest)(nil),    // 5: bank.TransactionSummaryRequest
+	(*AuditEventRequest)(nil),            // 6: bank.AuditEventRequest
+	(*AuditChainRequest)(nil),            // 7: bank.AuditChainRequest
+	(*PlaceHoldRequest)(nil),             // 8: bank.PlaceHoldRequest
+	(*CaptureHoldRequest)(nil),           // 9: bank.CaptureHoldRequest
+	(*ReleaseHoldRequest)(nil),           // 10: bank.ReleaseHoldRequest
+	(*ReverseTransferRequest)(nil),       // 11: bank.ReverseTransferRequest
+	(*GetTransferRequest)(nil),           // 12: bank.GetTransferRequest
+	(*ListTransfersRequest)(nil),         // 13: bank.ListTransfersRequest
+	(*ListTransactionsRequest)(nil),      // 14: bank.ListTransactionsRequest
+	(*ScheduledTransferRequest)(nil),     // 15: bank.ScheduledTransferRequest
+	(*ScheduledTransferLookup)(nil),      // 16: bank.ScheduledTransferLookup
+	(*ScheduledTransferListRequest)(nil), // 17: bank.ScheduledTransferListRequest
+	(*TransferBatchChunk)(nil),           // 18: bank.TransferBatchChunk
+	(*TransferBatchReportRequest)(nil),   // 19: bank.TransferBatchReportRequest
+	(*CurrentBalanceResponse)(nil),       // 20: bank.CurrentBalanceResponse
+	(*ExchangeRateResponse)(nil),         // 21: bank.ExchangeRateResponse
+	(*TransactionSummary)(nil),           // 22: bank.TransactionSummary
+	(*TransferResponse)(nil),             // 23: bank.TransferResponse
+	(*StatementResponse)(nil),            // 24: bank.StatementResponse
+	(*TransactionSummaryReport)(nil),     // 25: bank.TransactionSummaryReport
+	(*AuditEventList)(nil),               // 26: bank.AuditEventList
+	(*AuditChainVerification)(nil),       // 27: bank.AuditChainVerification
+	(*Hold)(nil),                         // 28: bank.Hold
+	(*TransferReversal)(nil),             // 29: bank.TransferReversal
+	(*Transfer)(nil),                     // 30: bank.Transfer
+	(*TransferList)(nil),                 // 31: bank.TransferList
+	(*TransactionList)(nil),              // 32: bank.TransactionList
+	(*ScheduledTransfer)(nil),            // 33: bank.ScheduledTransfer
+	(*ScheduledTransferList)(nil),        // 34: bank.ScheduledTransferList
+	(*TransferBatchReport)(nil),          // 35: bank.TransferBatchReport
+}
+var file_proto_bank_service_proto_depIdxs = []int32{
+	0,  // 0: bank.BankService.GetCurrentBalance:input_type -> bank.CurrentBalanceRequest
+	1,  // 1: bank.BankService.FetchExchangeRates:input_type -> bank.ExchangeRateRequest
+	2,  // 2: bank.BankService.SummarizeTransactions:input_type -> bank.Transaction
+	3,  // 3: bank.BankService.TransferMultiple:input_type -> bank.TransferRequest
+	4,  // 4: bank.BankService.GenerateStatement:input_type -> bank.StatementRequest
+	5,  // 5: bank.BankService.GetTransactionSummaries:input_type -> bank.TransactionSummaryRequest
+	6,  // 6: bank.BankService.ListAuditEvents:input_type -> bank.AuditEventRequest
+	7,  // 7: bank.BankService.VerifyAuditChain:input_type -> bank.AuditChainRequest
+	8,  // 8: bank.BankService.PlaceHold:input_type -> bank.PlaceHoldRequest
+	9,  // 9: bank.BankService.CaptureHold:input_type -> bank.CaptureHoldRequest
+	10, // 10: bank.BankService.ReleaseHold:input_type -> bank.ReleaseHoldRequest
+	11, // 11: bank.BankService.ReverseTransfer:input_type -> bank.ReverseTransferRequest
+	12, // 12: bank.BankService.GetTransfer:input_type -> bank.GetTransferRequest
+	13, // 13: bank.BankService.ListTransfers:input_type -> bank.ListTransfersRequest
+	14, // 14: bank.BankService.ListTransactions:input_type -> bank.ListTransactionsRequest
+	15, // 15: bank.ScheduledTransferService.CreateScheduledTransfer:input_type -> bank.ScheduledTransferRequest
+	16, // 16: bank.ScheduledTransferService.GetScheduledTransfer:input_type -> bank.ScheduledTransferLookup
+	17, // 17: bank.ScheduledTransferService.ListScheduledTransfers:input_type -> bank.ScheduledTransferListRequest
+	16, // 18: bank.ScheduledTransferService.CancelScheduledTransfer:input_type -> bank.ScheduledTransferLookup
+	18, // 19: bank.TransferBatchService.UploadTransferBatch:input_type -> bank.TransferBatchChunk
+	19, // 20: bank.TransferBatchService.GetTransferBatchReport:input_type -> bank.TransferBatchReportRequest
+	20, // 21: bank.BankService.GetCurrentBalance:output_type -> bank.CurrentBalanceResponse
+	21, // 22: bank.BankService.FetchExchangeRates:output_type -> bank.ExchangeRateResponse
+	22, // 23: bank.BankService.SummarizeTransactions:output_type -> bank.TransactionSummary
+	23, // 24: bank.BankService.TransferMultiple:output_type -> bank.TransferResponse
+	24, // 25: bank.BankService.GenerateStatement:output_type -> bank.StatementResponse
+	25, // 26: bank.BankService.GetTransactionSummaries:output_type -> bank.TransactionSummaryReport
+	26, // 27: bank.BankService.ListAuditEvents:output_type -> bank.AuditEventList
+	27, // 28: bank.BankService.VerifyAuditChain:output_type -> bank.AuditChainVerification
+	28, // 29: bank.BankService.PlaceHold:output_type -> bank.Hold
+	28, // 30: bank.BankService.CaptureHold:output_type -> bank.Hold
+	28, // 31: bank.BankService.ReleaseHold:output_type -> bank.Hold
+	29, // 32: bank.BankService.ReverseTransfer:output_type -> bank.TransferReversal
+	30, // 33: bank.BankService.GetTransfer:output_type -> bank.Transfer
+	31, // 34: bank.BankService.ListTransfers:output_type -> bank.TransferList
+	32, // 35: bank.BankService.ListTransactions:output_type -> bank.TransactionList
+	33, // 36: bank.ScheduledTransferService.CreateScheduledTransfer:output_type -> bank.ScheduledTransfer
+	33, // 37: bank.ScheduledTransferService.GetScheduledTransfer:output_type -> bank.ScheduledTransfer
+	34, // 38: bank.ScheduledTransferService.ListScheduledTransfers:output_type -> bank.ScheduledTransferList
+	33, // 39: bank.ScheduledTransferService.CancelScheduledTransfer:output_type -> bank.ScheduledTransfer
+	35, // 40: bank.TransferBatchService.UploadTransferBatch:output_type -> bank.TransferBatchReport
+	35, // 41: bank.TransferBatchService.GetTransferBatchReport:output_type -> bank.TransferBatchReport
+	21, // [21:42] is the sub-list for method output_type
+	0,  // [0:21] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_bank_service_proto_init() }
+func file_proto_bank_service_proto_init() {
+	if File_proto_bank_service_proto != nil {
+		return
+	}
+	file_proto_bank_type_account_proto_init()
+	file_proto_bank_type_exchange_proto_init()
+	file_proto_bank_type_transaction_proto_init()
+	file_proto_bank_type_transfer_proto_init()
+	file_proto_bank_type_statement_proto_init()
+	file_proto_bank_type_audit_proto_init()
+	file_proto_bank_type_hold_proto_init()
+	file_proto_bank_type_schedule_proto_init()
+	file_proto_bank_type_batch_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_bank_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_proto_bank_service_proto_goTypes,
+		DependencyIndexes: file_proto_bank_service_proto_depIdxs,
+	}.Build()
+	File_proto_bank_service_proto = out.File
+	file_proto_bank_service_proto_rawDesc = nil
+	file_proto_bank_service_proto_goTypes = nil
+	file_proto_bank_service_proto_depIdxs = nil
+}
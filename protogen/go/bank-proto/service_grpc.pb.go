@@ -0,0 +1,1007 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: proto/bank/service.proto
+
+package bank_proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BankService_GetCurrentBalance_FullMethodName       = "/bank.BankService/GetCurrentBalance"
+	BankService_FetchExchangeRates_FullMethodName      = "/bank.BankService/FetchExchangeRates"
+	BankService_SummarizeTransactions_FullMethodName   = "/bank.BankService/SummarizeTransactions"
+	BankService_TransferMultiple_FullMethodName        = "/bank.BankService/TransferMultiple"
+	BankService_GenerateStatement_FullMethodName       = "/bank.BankService/GenerateStatement"
+	BankService_GetTransactionSummaries_FullMethodName = "/bank.BankService/GetTransactionSummaries"
+	BankService_ListAuditEvents_FullMethodName         = "/bank.BankService/ListAuditEvents"
+	BankService_VerifyAuditChain_FullMethodName        = "/bank.BankService/VerifyAuditChain"
+	BankService_PlaceHold_FullMethodName               = "/bank.BankService/PlaceHold"
+	BankService_CaptureHold_FullMethodName             = "/bank.BankService/CaptureHold"
+	BankService_ReleaseHold_FullMethodName             = "/bank.BankService/ReleaseHold"
+	BankService_ReverseTransfer_FullMethodName         = "/bank.BankService/ReverseTransfer"
+	BankService_GetTransfer_FullMethodName             = "/bank.BankService/GetTransfer"
+	BankService_ListTransfers_FullMethodName           = "/bank.BankService/ListTransfers"
+	BankService_ListTransactions_FullMethodName        = "/bank.BankService/ListTransactions"
+)
+
+// BankServiceClient is the client API for BankService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BankServiceClient interface {
+	GetCurrentBalance(ctx context.Context, in *CurrentBalanceRequest, opts ...grpc.CallOption) (*CurrentBalanceResponse, error)
+	FetchExchangeRates(ctx context.Context, in *ExchangeRateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExchangeRateResponse], error)
+	SummarizeTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Transaction, TransactionSummary], error)
+	// TransferMultiple runs independent transfers concurrently and answers
+	// each one as soon as it is done, with the correlation_id of its request.
+	// Transfers touching the same account keep the order they were sent in.
+	// The "x-transfer-order: stream" header runs them one by one and answers
+	// in request order, "x-transfer-error-mode: continue" answers a failed
+	// transfer with TRANSFER_STATUS_FAIL and goes on with the next one.
+	TransferMultiple(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TransferRequest, TransferResponse], error)
+	GenerateStatement(ctx context.Context, in *StatementRequest, opts ...grpc.CallOption) (*StatementResponse, error)
+	GetTransactionSummaries(ctx context.Context, in *TransactionSummaryRequest, opts ...grpc.CallOption) (*TransactionSummaryReport, error)
+	ListAuditEvents(ctx context.Context, in *AuditEventRequest, opts ...grpc.CallOption) (*AuditEventList, error)
+	VerifyAuditChain(ctx context.Context, in *AuditChainRequest, opts ...grpc.CallOption) (*AuditChainVerification, error)
+	PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*Hold, error)
+	CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*Hold, error)
+	ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*Hold, error)
+	ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*TransferReversal, error)
+	GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*TransferList, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*TransactionList, error)
+}
+
+type bankServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBankServiceClient(cc grpc.ClientConnInterface) BankServiceClient {
+	return &bankServiceClient{cc}
+}
+
+func (c *bankServiceClient) GetCurrentBalance(ctx context.Context, in *CurrentBalanceRequest, opts ...grpc.CallOption) (*CurrentBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurrentBalanceResponse)
+	err := c.cc.Invoke(ctx, BankService_GetCurrentBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) FetchExchangeRates(ctx context.Context, in *ExchangeRateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExchangeRateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[0], BankService_FetchExchangeRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExchangeRateRequest, ExchangeRateResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_FetchExchangeRatesClient = grpc.ServerStreamingClient[ExchangeRateResponse]
+
+func (c *bankServiceClient) SummarizeTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Transaction, TransactionSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[1], BankService_SummarizeTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Transaction, TransactionSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_SummarizeTransactionsClient = grpc.ClientStreamingClient[Transaction, TransactionSummary]
+
+func (c *bankServiceClient) TransferMultiple(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TransferRequest, TransferResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[2], BankService_TransferMultiple_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TransferRequest, TransferResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_TransferMultipleClient = grpc.BidiStreamingClient[TransferRequest, TransferResponse]
+
+func (c *bankServiceClient) GenerateStatement(ctx context.Context, in *StatementRequest, opts ...grpc.CallOption) (*StatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatementResponse)
+	err := c.cc.Invoke(ctx, BankService_GenerateStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) GetTransactionSummaries(ctx context.Context, in *TransactionSummaryRequest, opts ...grpc.CallOption) (*TransactionSummaryReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionSummaryReport)
+	err := c.cc.Invoke(ctx, BankService_GetTransactionSummaries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) ListAuditEvents(ctx context.Context, in *AuditEventRequest, opts ...grpc.CallOption) (*AuditEventList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditEventList)
+	err := c.cc.Invoke(ctx, BankService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) VerifyAuditChain(ctx context.Context, in *AuditChainRequest, opts ...grpc.CallOption) (*AuditChainVerification, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditChainVerification)
+	err := c.cc.Invoke(ctx, BankService_VerifyAuditChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*Hold, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, BankService_PlaceHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*Hold, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, BankService_CaptureHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*Hold, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, BankService_ReleaseHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*TransferReversal, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferReversal)
+	err := c.cc.Invoke(ctx, BankService_ReverseTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transfer)
+	err := c.cc.Invoke(ctx, BankService_GetTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*TransferList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferList)
+	err := c.cc.Invoke(ctx, BankService_ListTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*TransactionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionList)
+	err := c.cc.Invoke(ctx, BankService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BankServiceServer is the server API for BankService service.
+// All implementations must embed UnimplementedBankServiceServer
+// for forward compatibility.
+type BankServiceServer interface {
+	GetCurrentBalance(context.Context, *CurrentBalanceRequest) (*CurrentBalanceResponse, error)
+	FetchExchangeRates(*ExchangeRateRequest, grpc.ServerStreamingServer[ExchangeRateResponse]) error
+	SummarizeTransactions(grpc.ClientStreamingServer[Transaction, TransactionSummary]) error
+	// TransferMultiple runs independent transfers concurrently and answers
+	// each one as soon as it is done, with the correlation_id of its request.
+	// Transfers touching the same account keep the order they were sent in.
+	// The "x-transfer-order: stream" header runs them one by one and answers
+	// in request order, "x-transfer-error-mode: continue" answers a failed
+	// transfer with TRANSFER_STATUS_FAIL and goes on with the next one.
+	TransferMultiple(grpc.BidiStreamingServer[TransferRequest, TransferResponse]) error
+	GenerateStatement(context.Context, *StatementRequest) (*StatementResponse, error)
+	GetTransactionSummaries(context.Context, *TransactionSummaryRequest) (*TransactionSummaryReport, error)
+	ListAuditEvents(context.Context, *AuditEventRequest) (*AuditEventList, error)
+	VerifyAuditChain(context.Context, *AuditChainRequest) (*AuditChainVerification, error)
+	PlaceHold(context.Context, *PlaceHoldRequest) (*Hold, error)
+	CaptureHold(context.Context, *CaptureHoldRequest) (*Hold, error)
+	ReleaseHold(context.Context, *ReleaseHoldRequest) (*Hold, error)
+	ReverseTransfer(context.Context, *ReverseTransferRequest) (*TransferReversal, error)
+	GetTransfer(context.Context, *GetTransferRequest) (*Transfer, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*TransferList, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*TransactionList, error)
+	mustEmbedUnimplementedBankServiceServer()
+}
+
+// UnimplementedBankServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBankServiceServer struct{}
+
+func (UnimplementedBankServiceServer) GetCurrentBalance(context.Context, *CurrentBalanceRequest) (*CurrentBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentBalance not implemented")
+}
+func (UnimplementedBankServiceServer) FetchExchangeRates(*ExchangeRateRequest, grpc.ServerStreamingServer[ExchangeRateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FetchExchangeRates not implemented")
+}
+func (UnimplementedBankServiceServer) SummarizeTransactions(grpc.ClientStreamingServer[Transaction, TransactionSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method SummarizeTransactions not implemented")
+}
+func (UnimplementedBankServiceServer) TransferMultiple(grpc.BidiStreamingServer[TransferRequest, TransferResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method TransferMultiple not implemented")
+}
+func (UnimplementedBankServiceServer) GenerateStatement(context.Context, *StatementRequest) (*StatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateStatement not implemented")
+}
+func (UnimplementedBankServiceServer) GetTransactionSummaries(context.Context, *TransactionSummaryRequest) (*TransactionSummaryReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionSummaries not implemented")
+}
+func (UnimplementedBankServiceServer) ListAuditEvents(context.Context, *AuditEventRequest) (*AuditEventList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedBankServiceServer) VerifyAuditChain(context.Context, *AuditChainRequest) (*AuditChainVerification, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuditChain not implemented")
+}
+func (UnimplementedBankServiceServer) PlaceHold(context.Context, *PlaceHoldRequest) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceHold not implemented")
+}
+func (UnimplementedBankServiceServer) CaptureHold(context.Context, *CaptureHoldRequest) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHold not implemented")
+}
+func (UnimplementedBankServiceServer) ReleaseHold(context.Context, *ReleaseHoldRequest) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseHold not implemented")
+}
+func (UnimplementedBankServiceServer) ReverseTransfer(context.Context, *ReverseTransferRequest) (*TransferReversal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseTransfer not implemented")
+}
+func (UnimplementedBankServiceServer) GetTransfer(context.Context, *GetTransferRequest) (*Transfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransfer not implemented")
+}
+func (UnimplementedBankServiceServer) ListTransfers(context.Context, *ListTransfersRequest) (*TransferList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedBankServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*TransactionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedBankServiceServer) mustEmbedUnimplementedBankServiceServer() {}
+func (UnimplementedBankServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeBankServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BankServiceServer will
+// result in compilation errors.
+type UnsafeBankServiceServer interface {
+	mustEmbedUnimplementedBankServiceServer()
+}
+
+func RegisterBankServiceServer(s grpc.ServiceRegistrar, srv BankServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBankServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BankService_ServiceDesc, srv)
+}
+
+func _BankService_GetCurrentBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrentBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).GetCurrentBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_GetCurrentBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).GetCurrentBalance(ctx, req.(*CurrentBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_FetchExchangeRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExchangeRateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BankServiceServer).FetchExchangeRates(m, &grpc.GenericServerStream[ExchangeRateRequest, ExchangeRateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_FetchExchangeRatesServer = grpc.ServerStreamingServer[ExchangeRateResponse]
+
+func _BankService_SummarizeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BankServiceServer).SummarizeTransactions(&grpc.GenericServerStream[Transaction, TransactionSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_SummarizeTransactionsServer = grpc.ClientStreamingServer[Transaction, TransactionSummary]
+
+func _BankService_TransferMultiple_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BankServiceServer).TransferMultiple(&grpc.GenericServerStream[TransferRequest, TransferResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_TransferMultipleServer = grpc.BidiStreamingServer[TransferRequest, TransferResponse]
+
+func _BankService_GenerateStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).GenerateStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_GenerateStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).GenerateStatement(ctx, req.(*StatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_GetTransactionSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).GetTransactionSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_GetTransactionSummaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).GetTransactionSummaries(ctx, req.(*TransactionSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).ListAuditEvents(ctx, req.(*AuditEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_VerifyAuditChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).VerifyAuditChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_VerifyAuditChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).VerifyAuditChain(ctx, req.(*AuditChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_PlaceHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).PlaceHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_PlaceHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).PlaceHold(ctx, req.(*PlaceHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_CaptureHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).CaptureHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_CaptureHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).CaptureHold(ctx, req.(*CaptureHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_ReleaseHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).ReleaseHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_ReleaseHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).ReleaseHold(ctx, req.(*ReleaseHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_ReverseTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).ReverseTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_ReverseTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).ReverseTransfer(ctx, req.(*ReverseTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_GetTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).GetTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_GetTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).GetTransfer(ctx, req.(*GetTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_ListTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BankService_ServiceDesc is the grpc.ServiceDesc for BankService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BankService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.BankService",
+	HandlerType: (*BankServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCurrentBalance",
+			Handler:    _BankService_GetCurrentBalance_Handler,
+		},
+		{
+			MethodName: "GenerateStatement",
+			Handler:    _BankService_GenerateStatement_Handler,
+		},
+		{
+			MethodName: "GetTransactionSummaries",
+			Handler:    _BankService_GetTransactionSummaries_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _BankService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "VerifyAuditChain",
+			Handler:    _BankService_VerifyAuditChain_Handler,
+		},
+		{
+			MethodName: "PlaceHold",
+			Handler:    _BankService_PlaceHold_Handler,
+		},
+		{
+			MethodName: "CaptureHold",
+			Handler:    _BankService_CaptureHold_Handler,
+		},
+		{
+			MethodName: "ReleaseHold",
+			Handler:    _BankService_ReleaseHold_Handler,
+		},
+		{
+			MethodName: "ReverseTransfer",
+			Handler:    _BankService_ReverseTransfer_Handler,
+		},
+		{
+			MethodName: "GetTransfer",
+			Handler:    _BankService_GetTransfer_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _BankService_ListTransfers_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _BankService_ListTransactions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchExchangeRates",
+			Handler:       _BankService_FetchExchangeRates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SummarizeTransactions",
+			Handler:       _BankService_SummarizeTransactions_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TransferMultiple",
+			Handler:       _BankService_TransferMultiple_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/bank/service.proto",
+}
+
+const (
+	ScheduledTransferService_CreateScheduledTransfer_FullMethodName = "/bank.ScheduledTransferService/CreateScheduledTransfer"
+	ScheduledTransferService_GetScheduledTransfer_FullMethodName    = "/bank.ScheduledTransferService/GetScheduledTransfer"
+	ScheduledTransferService_ListScheduledTransfers_FullMethodName  = "/bank.ScheduledTransferService/ListScheduledTransfers"
+	ScheduledTransferService_CancelScheduledTransfer_FullMethodName = "/bank.ScheduledTransferService/CancelScheduledTransfer"
+)
+
+// ScheduledTransferServiceClient is the client API for ScheduledTransferService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ScheduledTransferServiceClient interface {
+	CreateScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	GetScheduledTransfer(ctx context.Context, in *ScheduledTransferLookup, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	ListScheduledTransfers(ctx context.Context, in *ScheduledTransferListRequest, opts ...grpc.CallOption) (*ScheduledTransferList, error)
+	CancelScheduledTransfer(ctx context.Context, in *ScheduledTransferLookup, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+}
+
+type scheduledTransferServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScheduledTransferServiceClient(cc grpc.ClientConnInterface) ScheduledTransferServiceClient {
+	return &scheduledTransferServiceClient{cc}
+}
+
+func (c *scheduledTransferServiceClient) CreateScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_CreateScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) GetScheduledTransfer(ctx context.Context, in *ScheduledTransferLookup, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_GetScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) ListScheduledTransfers(ctx context.Context, in *ScheduledTransferListRequest, opts ...grpc.CallOption) (*ScheduledTransferList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransferList)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_ListScheduledTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) CancelScheduledTransfer(ctx context.Context, in *ScheduledTransferLookup, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_CancelScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScheduledTransferServiceServer is the server API for ScheduledTransferService service.
+// All implementations must embed UnimplementedScheduledTransferServiceServer
+// for forward compatibility.
+type ScheduledTransferServiceServer interface {
+	CreateScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error)
+	GetScheduledTransfer(context.Context, *ScheduledTransferLookup) (*ScheduledTransfer, error)
+	ListScheduledTransfers(context.Context, *ScheduledTransferListRequest) (*ScheduledTransferList, error)
+	CancelScheduledTransfer(context.Context, *ScheduledTransferLookup) (*ScheduledTransfer, error)
+	mustEmbedUnimplementedScheduledTransferServiceServer()
+}
+
+// UnimplementedScheduledTransferServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScheduledTransferServiceServer struct{}
+
+func (UnimplementedScheduledTransferServiceServer) CreateScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScheduledTransfer not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) GetScheduledTransfer(context.Context, *ScheduledTransferLookup) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledTransfer not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) ListScheduledTransfers(context.Context, *ScheduledTransferListRequest) (*ScheduledTransferList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransfers not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) CancelScheduledTransfer(context.Context, *ScheduledTransferLookup) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledTransfer not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) mustEmbedUnimplementedScheduledTransferServiceServer() {
+}
+func (UnimplementedScheduledTransferServiceServer) testEmbeddedByValue() {}
+
+// UnsafeScheduledTransferServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScheduledTransferServiceServer will
+// result in compilation errors.
+type UnsafeScheduledTransferServiceServer interface {
+	mustEmbedUnimplementedScheduledTransferServiceServer()
+}
+
+func RegisterScheduledTransferServiceServer(s grpc.ServiceRegistrar, srv ScheduledTransferServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScheduledTransferServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScheduledTransferService_ServiceDesc, srv)
+}
+
+func _ScheduledTransferService_CreateScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).CreateScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_CreateScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).CreateScheduledTransfer(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_GetScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferLookup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).GetScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_GetScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).GetScheduledTransfer(ctx, req.(*ScheduledTransferLookup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_ListScheduledTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).ListScheduledTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_ListScheduledTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).ListScheduledTransfers(ctx, req.(*ScheduledTransferListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_CancelScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferLookup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).CancelScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_CancelScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).CancelScheduledTransfer(ctx, req.(*ScheduledTransferLookup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScheduledTransferService_ServiceDesc is the grpc.ServiceDesc for ScheduledTransferService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScheduledTransferService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.ScheduledTransferService",
+	HandlerType: (*ScheduledTransferServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateScheduledTransfer",
+			Handler:    _ScheduledTransferService_CreateScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "GetScheduledTransfer",
+			Handler:    _ScheduledTransferService_GetScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransfers",
+			Handler:    _ScheduledTransferService_ListScheduledTransfers_Handler,
+		},
+		{
+			MethodName: "CancelScheduledTransfer",
+			Handler:    _ScheduledTransferService_CancelScheduledTransfer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/bank/service.proto",
+}
+
+const (
+	TransferBatchService_UploadTransferBatch_FullMethodName    = "/bank.TransferBatchService/UploadTransferBatch"
+	TransferBatchService_GetTransferBatchReport_FullMethodName = "/bank.TransferBatchService/GetTransferBatchReport"
+)
+
+// TransferBatchServiceClient is the client API for TransferBatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TransferBatchServiceClient interface {
+	UploadTransferBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[TransferBatchChunk, TransferBatchReport], error)
+	GetTransferBatchReport(ctx context.Context, in *TransferBatchReportRequest, opts ...grpc.CallOption) (*TransferBatchReport, error)
+}
+
+type transferBatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransferBatchServiceClient(cc grpc.ClientConnInterface) TransferBatchServiceClient {
+	return &transferBatchServiceClient{cc}
+}
+
+func (c *transferBatchServiceClient) UploadTransferBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[TransferBatchChunk, TransferBatchReport], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TransferBatchService_ServiceDesc.Streams[0], TransferBatchService_UploadTransferBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TransferBatchChunk, TransferBatchReport]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransferBatchService_UploadTransferBatchClient = grpc.ClientStreamingClient[TransferBatchChunk, TransferBatchReport]
+
+func (c *transferBatchServiceClient) GetTransferBatchReport(ctx context.Context, in *TransferBatchReportRequest, opts ...grpc.CallOption) (*TransferBatchReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferBatchReport)
+	err := c.cc.Invoke(ctx, TransferBatchService_GetTransferBatchReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransferBatchServiceServer is the server API for TransferBatchService service.
+// All implementations must embed UnimplementedTransferBatchServiceServer
+// for forward compatibility.
+type TransferBatchServiceServer interface {
+	UploadTransferBatch(grpc.ClientStreamingServer[TransferBatchChunk, TransferBatchReport]) error
+	GetTransferBatchReport(context.Context, *TransferBatchReportRequest) (*TransferBatchReport, error)
+	mustEmbedUnimplementedTransferBatchServiceServer()
+}
+
+// UnimplementedTransferBatchServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTransferBatchServiceServer struct{}
+
+func (UnimplementedTransferBatchServiceServer) UploadTransferBatch(grpc.ClientStreamingServer[TransferBatchChunk, TransferBatchReport]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadTransferBatch not implemented")
+}
+func (UnimplementedTransferBatchServiceServer) GetTransferBatchReport(context.Context, *TransferBatchReportRequest) (*TransferBatchReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferBatchReport not implemented")
+}
+func (UnimplementedTransferBatchServiceServer) mustEmbedUnimplementedTransferBatchServiceServer() {}
+func (UnimplementedTransferBatchServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeTransferBatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TransferBatchServiceServer will
+// result in compilation errors.
+type UnsafeTransferBatchServiceServer interface {
+	mustEmbedUnimplementedTransferBatchServiceServer()
+}
+
+func RegisterTransferBatchServiceServer(s grpc.ServiceRegistrar, srv TransferBatchServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTransferBatchServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TransferBatchService_ServiceDesc, srv)
+}
+
+func _TransferBatchService_UploadTransferBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TransferBatchServiceServer).UploadTransferBatch(&grpc.GenericServerStream[TransferBatchChunk, TransferBatchReport]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransferBatchService_UploadTransferBatchServer = grpc.ClientStreamingServer[TransferBatchChunk, TransferBatchReport]
+
+func _TransferBatchService_GetTransferBatchReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferBatchReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferBatchServiceServer).GetTransferBatchReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferBatchService_GetTransferBatchReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferBatchServiceServer).GetTransferBatchReport(ctx, req.(*TransferBatchReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TransferBatchService_ServiceDesc is the grpc.ServiceDesc for TransferBatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TransferBatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.TransferBatchService",
+	HandlerType: (*TransferBatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTransferBatchReport",
+			Handler:    _TransferBatchService_GetTransferBatchReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadTransferBatch",
+			Handler:       _TransferBatchService_UploadTransferBatch_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/bank/service.proto",
+}